@@ -8,6 +8,7 @@ import (
 	"github.com/EliCDavis/polyform/generator/schema"
 
 	// Import these so they register their nodes with the generator
+	_ "github.com/EliCDavis/polyform/drawing/texturing/bake"
 	_ "github.com/EliCDavis/polyform/formats/colmap"
 	_ "github.com/EliCDavis/polyform/formats/gltf"
 	_ "github.com/EliCDavis/polyform/formats/obj"
@@ -31,6 +32,7 @@ import (
 	_ "github.com/EliCDavis/polyform/math/vector3"
 
 	_ "github.com/EliCDavis/polyform/modeling"
+	_ "github.com/EliCDavis/polyform/modeling/csg"
 	_ "github.com/EliCDavis/polyform/modeling/extrude"
 	_ "github.com/EliCDavis/polyform/modeling/meshops"
 	_ "github.com/EliCDavis/polyform/modeling/meshops/gausops"
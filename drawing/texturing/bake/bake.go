@@ -0,0 +1,248 @@
+package bake
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/drawing/texturing"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	DefaultResolution = 1024
+	DefaultPadding    = 4
+)
+
+type Parameters struct {
+	// Dimensions of the texture baked. Both default to DefaultResolution
+	// when 0 or less.
+	Width  int
+	Height int
+
+	// Attribute containing the UVs to bake against. Defaults to
+	// modeling.TexCoordAttribute when empty.
+	UVAttribute string
+
+	// Number of texels each UV island is grown outward by, preventing seams
+	// from showing up when the texture is filtered. Defaults to
+	// DefaultPadding when 0, and negative values disable padding entirely.
+	Padding int
+}
+
+func (p Parameters) withDefaults() Parameters {
+	if p.Width <= 0 {
+		p.Width = DefaultResolution
+	}
+
+	if p.Height <= 0 {
+		p.Height = DefaultResolution
+	}
+
+	if p.UVAttribute == "" {
+		p.UVAttribute = modeling.TexCoordAttribute
+	}
+
+	if p.Padding == 0 {
+		p.Padding = DefaultPadding
+	} else if p.Padding < 0 {
+		p.Padding = 0
+	}
+
+	return p
+}
+
+func validate(m modeling.Mesh, params Parameters) error {
+	if m.Topology() != modeling.TriangleTopology {
+		return fmt.Errorf("baking requires a triangle topology, received: %s", m.Topology().String())
+	}
+
+	if m.PrimitiveCount() > 0 && !m.HasFloat2Attribute(params.UVAttribute) {
+		return fmt.Errorf("baking requires the mesh to have the vector2 attribute: '%s'", params.UVAttribute)
+	}
+
+	return nil
+}
+
+// Rasterize walks every texel covered by the mesh's triangles when laid out
+// in UV space. The callback receives the vertex indices of the triangle
+// covering the texel, along with the barycentric coordinates of the texel's
+// center within it. Texels covered by multiple triangles are visited once
+// per triangle.
+func Rasterize(m modeling.Mesh, params Parameters, texel func(x, y int, tri [3]int, barycentric vector3.Float64)) error {
+	params = params.withDefaults()
+	if err := validate(m, params); err != nil {
+		return err
+	}
+
+	if m.PrimitiveCount() == 0 {
+		return nil
+	}
+
+	rasterize(m, params, texel)
+	return nil
+}
+
+func rasterize(m modeling.Mesh, params Parameters, texel func(x, y int, tri [3]int, barycentric vector3.Float64)) {
+	const epsilon = 1e-9
+
+	uvs := m.Float2Attribute(params.UVAttribute)
+	indices := m.Indices()
+	scale := vector2.New(float64(params.Width), float64(params.Height))
+
+	for i := 0; i < indices.Len(); i += 3 {
+		tri := [3]int{indices.At(i), indices.At(i + 1), indices.At(i + 2)}
+		a := uvs.At(tri[0]).MultByVector(scale)
+		b := uvs.At(tri[1]).MultByVector(scale)
+		c := uvs.At(tri[2]).MultByVector(scale)
+
+		v0 := b.Sub(a)
+		v1 := c.Sub(a)
+		denominator := v0.X()*v1.Y() - v1.X()*v0.Y()
+		if math.Abs(denominator) < epsilon {
+			continue
+		}
+
+		minX := max(int(math.Floor(math.Min(a.X(), math.Min(b.X(), c.X())))), 0)
+		maxX := min(int(math.Ceil(math.Max(a.X(), math.Max(b.X(), c.X())))), params.Width-1)
+		minY := max(int(math.Floor(math.Min(a.Y(), math.Min(b.Y(), c.Y())))), 0)
+		maxY := min(int(math.Ceil(math.Max(a.Y(), math.Max(b.Y(), c.Y())))), params.Height-1)
+
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				v2 := vector2.New(float64(x)+0.5, float64(y)+0.5).Sub(a)
+				u := (v2.X()*v1.Y() - v1.X()*v2.Y()) / denominator
+				v := (v0.X()*v2.Y() - v2.X()*v0.Y()) / denominator
+				w := 1 - u - v
+				if u < -epsilon || v < -epsilon || w < -epsilon {
+					continue
+				}
+				texel(x, y, tri, vector3.New(w, u, v))
+			}
+		}
+	}
+}
+
+// dilate grows the covered region of the texture outward by the number of
+// texels specified, copying over the value of a covered neighbor
+func dilate[T any](tex texturing.Texture[T], covered texturing.Texture[bool], texels int) {
+	neighbors := [...][2]int{
+		{-1, 0}, {1, 0}, {0, -1}, {0, 1},
+		{-1, -1}, {1, -1}, {-1, 1}, {1, 1},
+	}
+
+	type fill struct {
+		x, y, fromX, fromY int
+	}
+
+	for range texels {
+		fills := make([]fill, 0)
+		covered.Scan(func(x, y int, c bool) {
+			if c {
+				return
+			}
+
+			for _, n := range neighbors {
+				nx, ny := x+n[0], y+n[1]
+				if nx < 0 || ny < 0 || nx >= tex.Width() || ny >= tex.Height() {
+					continue
+				}
+
+				if covered.Get(nx, ny) {
+					fills = append(fills, fill{x: x, y: y, fromX: nx, fromY: ny})
+					return
+				}
+			}
+		})
+
+		if len(fills) == 0 {
+			return
+		}
+
+		for _, f := range fills {
+			tex.Set(f.x, f.y, tex.Get(f.fromX, f.fromY))
+			covered.Set(f.x, f.y, true)
+		}
+	}
+}
+
+// bake rasterizes the mesh into a texture, sampling a value for every texel
+// covered. Texels left uncovered after padding are set to the background.
+func bake[T any](m modeling.Mesh, params Parameters, background T, sample func(tri [3]int, barycentric vector3.Float64) T) (texturing.Texture[T], error) {
+	params = params.withDefaults()
+	if err := validate(m, params); err != nil {
+		return texturing.Texture[T]{}, err
+	}
+
+	tex := texturing.NewTexture[T](params.Width, params.Height)
+	tex.Fill(background)
+	if m.PrimitiveCount() == 0 {
+		return tex, nil
+	}
+
+	covered := texturing.NewTexture[bool](params.Width, params.Height)
+	rasterize(m, params, func(x, y int, tri [3]int, barycentric vector3.Float64) {
+		tex.Set(x, y, sample(tri, barycentric))
+		covered.Set(x, y, true)
+	})
+	dilate(tex, covered, params.Padding)
+
+	return tex, nil
+}
+
+func interpolate1(data *iter.ArrayIterator[float64], tri [3]int, barycentric vector3.Float64) float64 {
+	return data.At(tri[0])*barycentric.X() +
+		data.At(tri[1])*barycentric.Y() +
+		data.At(tri[2])*barycentric.Z()
+}
+
+func interpolate3(data *iter.ArrayIterator[vector3.Float64], tri [3]int, barycentric vector3.Float64) vector3.Float64 {
+	return data.At(tri[0]).Scale(barycentric.X()).
+		Add(data.At(tri[1]).Scale(barycentric.Y())).
+		Add(data.At(tri[2]).Scale(barycentric.Z()))
+}
+
+// Float1Attribute bakes the interpolated values of a per-vertex float1
+// attribute, like ambient occlusion, into a texture
+func Float1Attribute(m modeling.Mesh, attribute string, params Parameters) (texturing.Texture[float64], error) {
+	if m.PrimitiveCount() > 0 && !m.HasFloat1Attribute(attribute) {
+		return texturing.Texture[float64]{}, fmt.Errorf("baking requires the mesh to have the float1 attribute: '%s'", attribute)
+	}
+
+	data := m.Float1Attribute(attribute)
+	return bake(m, params, 0, func(tri [3]int, barycentric vector3.Float64) float64 {
+		return interpolate1(data, tri, barycentric)
+	})
+}
+
+// Float3Attribute bakes the interpolated values of a per-vertex float3
+// attribute, like color, into a texture
+func Float3Attribute(m modeling.Mesh, attribute string, params Parameters) (texturing.Texture[vector3.Float64], error) {
+	if m.PrimitiveCount() > 0 && !m.HasFloat3Attribute(attribute) {
+		return texturing.Texture[vector3.Float64]{}, fmt.Errorf("baking requires the mesh to have the vector3 attribute: '%s'", attribute)
+	}
+
+	data := m.Float3Attribute(attribute)
+	return bake(m, params, vector3.Zero[float64](), func(tri [3]int, barycentric vector3.Float64) vector3.Float64 {
+		return interpolate3(data, tri, barycentric)
+	})
+}
+
+// ObjectSpaceNormals bakes the mesh's interpolated vertex normals into a
+// texture
+func ObjectSpaceNormals(m modeling.Mesh, params Parameters) (texturing.Texture[vector3.Float64], error) {
+	if m.PrimitiveCount() > 0 && !m.HasFloat3Attribute(modeling.NormalAttribute) {
+		return texturing.Texture[vector3.Float64]{}, fmt.Errorf("baking requires the mesh to have the vector3 attribute: '%s'", modeling.NormalAttribute)
+	}
+
+	normals := m.Float3Attribute(modeling.NormalAttribute)
+	return bake(m, params, vector3.Zero[float64](), func(tri [3]int, barycentric vector3.Float64) vector3.Float64 {
+		n := interpolate3(normals, tri, barycentric)
+		if n.Length() == 0 {
+			return n
+		}
+		return n.Normalized()
+	})
+}
@@ -0,0 +1,183 @@
+package bake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EliCDavis/polyform/drawing/texturing/bake"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plane builds a y-up unit quad on the XZ plane, with UVs matching each
+// vertex's x and z position, and a height provided by the function
+func plane(height func(x, z float64) float64) modeling.Mesh {
+	positions := []vector3.Float64{
+		vector3.New(0, height(0, 0), 0),
+		vector3.New(1, height(1, 0), 0),
+		vector3.New(1, height(1, 1), 1),
+		vector3.New(0, height(0, 1), 1),
+	}
+	return modeling.NewTriangleMesh([]int{0, 2, 1, 0, 3, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat3Attribute(modeling.NormalAttribute, []vector3.Float64{
+			vector3.Up[float64](), vector3.Up[float64](), vector3.Up[float64](), vector3.Up[float64](),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.), vector2.New(1., 0.), vector2.New(1., 1.), vector2.New(0., 1.),
+		})
+}
+
+func flat(x, z float64) float64 { return 0 }
+
+func TestFloat3Attribute(t *testing.T) {
+	// ARRANGE ================================================================
+	m := plane(flat)
+	m = m.SetFloat3Attribute(modeling.ColorAttribute, []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(1., 0., 1.),
+		vector3.New(0., 0., 1.),
+	})
+
+	// ACT ====================================================================
+	tex, err := bake.Float3Attribute(m, modeling.ColorAttribute, bake.Parameters{Width: 16, Height: 8})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 16, tex.Width())
+	assert.Equal(t, 8, tex.Height())
+
+	// Colors were set up to match the UVs of each vertex
+	tex.Scan(func(x, y int, v vector3.Float64) {
+		assert.InDelta(t, (float64(x)+0.5)/16, v.X(), 1e-9)
+		assert.InDelta(t, (float64(y)+0.5)/8, v.Z(), 1e-9)
+	})
+
+	img := bake.ColorImage(tex)
+	r, _, b, a := img.At(15, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), a)
+	assert.Greater(t, r, b)
+}
+
+func TestFloat1Attribute_Padding(t *testing.T) {
+	// ARRANGE ================================================================
+	m := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.), vector3.New(1., 0., 0.), vector3.New(0., 0., 1.),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 0.), vector2.New(0.5, 0.), vector2.New(0., 0.5),
+		}).
+		SetFloat1Attribute("ao", []float64{0.5, 0.5, 0.5})
+
+	// ACT ====================================================================
+	padded, paddedErr := bake.Float1Attribute(m, "ao", bake.Parameters{Width: 8, Height: 8, Padding: 1})
+	unpadded, unpaddedErr := bake.Float1Attribute(m, "ao", bake.Parameters{Width: 8, Height: 8, Padding: -1})
+
+	// ASSERT =================================================================
+	require.NoError(t, paddedErr)
+	require.NoError(t, unpaddedErr)
+
+	assert.Equal(t, 0.5, padded.Get(0, 0))
+	assert.Equal(t, 0.5, unpadded.Get(0, 0))
+
+	// Texels just outside the triangle are filled in by padding
+	assert.Equal(t, 0.5, padded.Get(2, 2))
+	assert.Equal(t, 0., unpadded.Get(2, 2))
+
+	assert.Equal(t, 0., padded.Get(7, 7))
+}
+
+func TestHighPoly(t *testing.T) {
+	tests := map[string]struct {
+		height       func(x, z float64) float64
+		displacement func(u, v float64) float64
+		normal       vector3.Float64
+	}{
+		"flat": {
+			height:       func(x, z float64) float64 { return 0.1 },
+			displacement: func(u, v float64) float64 { return 0.1 },
+			normal:       vector3.New(0., 0., 1.),
+		},
+		"sloped along u": {
+			height:       func(x, z float64) float64 { return 0.2 * x },
+			displacement: func(u, v float64) float64 { return 0.2 * u },
+			normal:       vector3.New(-0.2, 0., 1.).Normalized(),
+		},
+		"sloped along v": {
+			height:       func(x, z float64) float64 { return 0.2 * z },
+			displacement: func(u, v float64) float64 { return 0.2 * v },
+
+			// Bitangents point towards decreasing v
+			normal: vector3.New(0., 0.2, 1.).Normalized(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			low := plane(flat)
+			high := meshops.FlatNormals(plane(tc.height))
+
+			// ACT ============================================================
+			detail, err := bake.HighPoly(low, high, bake.HighPolyParameters{
+				Parameters:   bake.Parameters{Width: 8, Height: 8},
+				CageDistance: 0.5,
+			})
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			detail.Normals.Scan(func(x, y int, n vector3.Float64) {
+				assert.InDelta(t, tc.normal.X(), n.X(), 1e-6)
+				assert.InDelta(t, tc.normal.Y(), n.Y(), 1e-6)
+				assert.InDelta(t, tc.normal.Z(), n.Z(), 1e-6)
+			})
+			detail.Displacement.Scan(func(x, y int, d float64) {
+				assert.InDelta(t, tc.displacement((float64(x)+0.5)/8, (float64(y)+0.5)/8), d, 1e-6)
+			})
+		})
+	}
+}
+
+func TestHighPoly_OverlappingUVs(t *testing.T) {
+	// ARRANGE ================================================================
+	low := plane(flat).Append(plane(flat))
+	high := meshops.FlatNormals(plane(func(x, z float64) float64 { return 0.1 }))
+
+	// ACT ====================================================================
+	detail, err := bake.HighPoly(low, high, bake.HighPolyParameters{
+		Parameters:   bake.Parameters{Width: 8, Height: 8},
+		CageDistance: 0.5,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	detail.Displacement.Scan(func(x, y int, d float64) {
+		assert.InDelta(t, 0.1, d, 1e-6)
+	})
+}
+
+func TestHighPolyContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := bake.HighPolyContext(ctx, plane(flat), plane(flat), bake.HighPolyParameters{
+		Parameters: bake.Parameters{Width: 8, Height: 8},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBake_RequiresUVs(t *testing.T) {
+	m := plane(flat).SetFloat3Attribute(modeling.ColorAttribute, make([]vector3.Float64, 4))
+
+	_, err := bake.Float3Attribute(m, modeling.ColorAttribute, bake.Parameters{UVAttribute: "missing"})
+	assert.EqualError(t, err, "baking requires the mesh to have the vector2 attribute: 'missing'")
+
+	_, err = bake.HighPoly(modeling.EmptyPointcloud(), m, bake.HighPolyParameters{})
+	assert.EqualError(t, err, "low poly: baking requires a triangle topology, received: point")
+}
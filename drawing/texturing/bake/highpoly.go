@@ -0,0 +1,234 @@
+package bake
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/EliCDavis/polyform/drawing/texturing"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// DefaultCageDistance is the fraction of the low poly mesh's bounding box
+// diagonal rays are cast from when no cage distance is provided
+const DefaultCageDistance = 0.05
+
+type HighPolyParameters struct {
+	Parameters
+
+	// How far out along the low poly mesh's normals rays are cast from
+	// towards the high poly mesh. Detail further than this from the low poly
+	// surface in either direction is missed. Defaults to DefaultCageDistance
+	// of the low poly mesh's bounding box diagonal when 0 or less.
+	CageDistance float64
+}
+
+// Detail captured from a high poly mesh, laid out using the low poly mesh's
+// UVs
+type Detail struct {
+	// Tangent space normals of the high poly surface. Tangents follow
+	// increasing U, and bitangents decreasing V, matching the convention
+	// glTF uses for normal textures.
+	Normals texturing.Texture[vector3.Float64]
+
+	// Signed distance from the low poly surface to the high poly surface
+	// along the low poly mesh's normals. Positive values sit outside the low
+	// poly surface.
+	Displacement texturing.Texture[float64]
+}
+
+// tangents computes per-vertex tangents from the mesh's UVs, with the w
+// component storing the handedness of the bitangent, following the
+// convention glTF uses: bitangent = cross(normal, tangent.xyz) * tangent.w
+func tangents(m modeling.Mesh, uvAttribute string) []vector4.Float64 {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	normals := m.Float3Attribute(modeling.NormalAttribute)
+	uvs := m.Float2Attribute(uvAttribute)
+	indices := m.Indices()
+
+	tan := make([]vector3.Float64, positions.Len())
+	bitan := make([]vector3.Float64, positions.Len())
+	for i := range tan {
+		tan[i] = vector3.Zero[float64]()
+		bitan[i] = vector3.Zero[float64]()
+	}
+
+	for i := 0; i < indices.Len(); i += 3 {
+		i1, i2, i3 := indices.At(i), indices.At(i+1), indices.At(i+2)
+		e1 := positions.At(i2).Sub(positions.At(i1))
+		e2 := positions.At(i3).Sub(positions.At(i1))
+		d1 := uvs.At(i2).Sub(uvs.At(i1))
+		d2 := uvs.At(i3).Sub(uvs.At(i1))
+
+		determinant := d1.X()*d2.Y() - d2.X()*d1.Y()
+		if determinant == 0 {
+			continue
+		}
+		r := 1 / determinant
+
+		// Direction of increasing u and v across the triangle
+		t := e1.Scale(d2.Y()).Sub(e2.Scale(d1.Y())).Scale(r)
+		b := e2.Scale(d1.X()).Sub(e1.Scale(d2.X())).Scale(r)
+		for _, v := range [...]int{i1, i2, i3} {
+			tan[v] = tan[v].Add(t)
+			bitan[v] = bitan[v].Add(b)
+		}
+	}
+
+	out := make([]vector4.Float64, len(tan))
+	for i := range tan {
+		n := normals.At(i)
+		t := tan[i].Sub(n.Scale(n.Dot(tan[i])))
+		if t.Length() == 0 {
+			out[i] = vector4.New(0., 0., 0., 1.)
+			continue
+		}
+		t = t.Normalized()
+
+		// Bitangents point towards decreasing v, as images (and glTF's UVs)
+		// place their origin in the top left
+		w := 1.
+		if n.Cross(t).Dot(bitan[i]) > 0 {
+			w = -1
+		}
+		out[i] = vector4.New(t.X(), t.Y(), t.Z(), w)
+	}
+	return out
+}
+
+type detailTexel struct {
+	x, y        int
+	tri         [3]int
+	barycentric vector3.Float64
+}
+
+// HighPoly captures the surface detail of the high poly mesh into textures
+// laid out using the low poly mesh's UVs, by casting rays inward along the
+// low poly mesh's normals from a cage surrounding it. Texels whose ray
+// never reaches the high poly mesh are left flat.
+//
+// The displacement texture can be converted into an image with
+// NormalizedImage, which normals.FromHeightmap can also take as input.
+func HighPoly(low, high modeling.Mesh, params HighPolyParameters) (Detail, error) {
+	return HighPolyContext(context.Background(), low, high, params)
+}
+
+// HighPolyContext is HighPoly, but stops casting rays once the context is
+// done, returning the context's error
+func HighPolyContext(ctx context.Context, low, high modeling.Mesh, params HighPolyParameters) (Detail, error) {
+	params.Parameters = params.withDefaults()
+	if err := validate(low, params.Parameters); err != nil {
+		return Detail{}, fmt.Errorf("low poly: %w", err)
+	}
+
+	if low.PrimitiveCount() > 0 {
+		if !low.HasFloat3Attribute(modeling.NormalAttribute) {
+			return Detail{}, fmt.Errorf("low poly: baking requires the mesh to have the vector3 attribute: '%s'", modeling.NormalAttribute)
+		}
+	}
+
+	if high.Topology() != modeling.TriangleTopology {
+		return Detail{}, fmt.Errorf("high poly: baking requires a triangle topology, received: %s", high.Topology().String())
+	}
+
+	detail := Detail{
+		Normals:      texturing.NewTexture[vector3.Float64](params.Width, params.Height),
+		Displacement: texturing.NewTexture[float64](params.Width, params.Height),
+	}
+	detail.Normals.Fill(vector3.Forward[float64]())
+
+	if low.PrimitiveCount() == 0 || high.PrimitiveCount() == 0 {
+		return detail, nil
+	}
+
+	if !high.HasFloat3Attribute(modeling.NormalAttribute) {
+		high = meshops.SmoothNormals(high)
+	}
+
+	cage := params.CageDistance
+	if cage <= 0 {
+		cage = low.BoundingBox(modeling.PositionAttribute).Size().Length() * DefaultCageDistance
+	}
+
+	texels := make([]detailTexel, 0)
+	covered := texturing.NewTexture[bool](params.Width, params.Height)
+	rasterize(low, params.Parameters, func(x, y int, tri [3]int, barycentric vector3.Float64) {
+		// Only the first triangle covering a texel is kept, so no two
+		// workers ever write to the same texel
+		if covered.Get(x, y) {
+			return
+		}
+		texels = append(texels, detailTexel{x: x, y: y, tri: tri, barycentric: barycentric})
+		covered.Set(x, y, true)
+	})
+
+	target := rendering.NewMeshWithAttributes(high, nil, []string{modeling.NormalAttribute}, nil)
+	positions := low.Float3Attribute(modeling.PositionAttribute)
+	normals := low.Float3Attribute(modeling.NormalAttribute)
+	tans := tangents(low, params.UVAttribute)
+
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			hit := rendering.NewHitRecord()
+			for i := start; i < len(texels); i += workers {
+				if ctx.Err() != nil {
+					return
+				}
+
+				texel := texels[i]
+				n := interpolate3(normals, texel.tri, texel.barycentric)
+				if n.Length() == 0 {
+					continue
+				}
+				n = n.Normalized()
+
+				p := interpolate3(positions, texel.tri, texel.barycentric)
+				ray := rendering.NewTemporalRay(p.Add(n.Scale(cage)), n.Scale(-1), 0)
+				if !target.Hit(&ray, 0, cage*2, hit) {
+					continue
+				}
+				detail.Displacement.Set(texel.x, texel.y, cage-hit.Distance)
+
+				t := vector3.Zero[float64]()
+				w := 0.
+				for c, v := range texel.tri {
+					tangent := tans[v]
+					t = t.Add(tangent.XYZ().Scale(texel.barycentric.Component(c)))
+					w += tangent.W() * texel.barycentric.Component(c)
+				}
+				t = t.Sub(n.Scale(n.Dot(t)))
+				if t.Length() == 0 {
+					continue
+				}
+				t = t.Normalized()
+
+				b := n.Cross(t)
+				if w < 0 {
+					b = b.Scale(-1)
+				}
+
+				hn := hit.Float3Data[modeling.NormalAttribute]
+				detail.Normals.Set(texel.x, texel.y, vector3.New(hn.Dot(t), hn.Dot(b), hn.Dot(n)).Normalized())
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return Detail{}, err
+	}
+
+	dilate(detail.Normals, covered.Copy(), params.Padding)
+	dilate(detail.Displacement, covered, params.Padding)
+
+	return detail, nil
+}
@@ -0,0 +1,66 @@
+package bake
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/EliCDavis/polyform/drawing/texturing"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func toByte(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
+
+// ColorImage converts a texture of RGB values ranging from 0 to 1 into an
+// image, clamping anything outside that range
+func ColorImage(tex texturing.Texture[vector3.Float64]) image.Image {
+	return tex.ToImage(func(v vector3.Float64) color.Color {
+		return color.RGBA{R: toByte(v.X()), G: toByte(v.Y()), B: toByte(v.Z()), A: 255}
+	})
+}
+
+// GrayscaleImage converts a texture of values ranging from 0 to 1 into a
+// grayscale image, clamping anything outside that range
+func GrayscaleImage(tex texturing.Texture[float64]) image.Image {
+	return tex.ToImage(func(v float64) color.Color {
+		return color.Gray{Y: toByte(v)}
+	})
+}
+
+// NormalizedImage converts a texture into a grayscale image, remapping the
+// texture's smallest value to black and its largest to white
+func NormalizedImage(tex texturing.Texture[float64]) image.Image {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	tex.Scan(func(x, y int, v float64) {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	})
+
+	scale := 0.
+	if hi > lo {
+		scale = 1 / (hi - lo)
+	}
+
+	return tex.ToImage(func(v float64) color.Color {
+		return color.Gray{Y: toByte((v - lo) * scale)}
+	})
+}
+
+// NormalImage converts a texture of unit vectors into a normal map, mapping
+// each component from [-1, 1] to [0, 255]
+func NormalImage(tex texturing.Texture[vector3.Float64]) image.Image {
+	return tex.ToImage(func(v vector3.Float64) color.Color {
+		return color.RGBA{
+			R: toByte((v.X() + 1) / 2),
+			G: toByte((v.Y() + 1) / 2),
+			B: toByte((v.Z() + 1) / 2),
+			A: 255,
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package bake
+
+import (
+	"context"
+	"image"
+
+	"github.com/EliCDavis/polyform/drawing/texturing"
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+	refutil.RegisterType[ColorNode](factory)
+	refutil.RegisterType[GrayscaleNode](factory)
+	refutil.RegisterType[ObjectSpaceNormalsNode](factory)
+	refutil.RegisterType[HighPolyNode](factory)
+	generator.RegisterTypes(factory)
+}
+
+func nodeParameters(resolution, padding nodes.Output[int]) Parameters {
+	size := nodes.TryGetOutputValue(resolution, DefaultResolution)
+	return Parameters{
+		Width:   size,
+		Height:  size,
+		Padding: nodes.TryGetOutputValue(padding, DefaultPadding),
+	}
+}
+
+func imageOutput[T any](tex texturing.Texture[T], err error, toImage func(texturing.Texture[T]) image.Image) nodes.StructOutput[image.Image] {
+	if err != nil {
+		out := nodes.NewStructOutput[image.Image](image.NewRGBA(image.Rect(0, 0, 1, 1)))
+		out.LogError(err)
+		return out
+	}
+	return nodes.NewStructOutput(toImage(tex))
+}
+
+type ColorNode = nodes.Struct[ColorNodeData]
+
+type ColorNodeData struct {
+	Mesh       nodes.Output[modeling.Mesh]
+	Attribute  nodes.Output[string] `description:"Vector3 attribute to bake, defaults to the color attribute"`
+	Resolution nodes.Output[int]    `description:"Width and height of the texture"`
+	Padding    nodes.Output[int]    `description:"Number of texels each UV island is grown outward by"`
+}
+
+func (cnd ColorNodeData) Description() string {
+	return "Bakes a per-vertex color attribute into a texture using the mesh's UVs"
+}
+
+func (cnd ColorNodeData) Out() nodes.StructOutput[image.Image] {
+	if cnd.Mesh == nil {
+		return nodes.NewStructOutput[image.Image](image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	}
+
+	tex, err := Float3Attribute(
+		cnd.Mesh.Value(),
+		nodes.TryGetOutputValue(cnd.Attribute, modeling.ColorAttribute),
+		nodeParameters(cnd.Resolution, cnd.Padding),
+	)
+	return imageOutput(tex, err, ColorImage)
+}
+
+type GrayscaleNode = nodes.Struct[GrayscaleNodeData]
+
+type GrayscaleNodeData struct {
+	Mesh       nodes.Output[modeling.Mesh]
+	Attribute  nodes.Output[string] `description:"Float1 attribute to bake, with values ranging from 0 to 1"`
+	Resolution nodes.Output[int]    `description:"Width and height of the texture"`
+	Padding    nodes.Output[int]    `description:"Number of texels each UV island is grown outward by"`
+}
+
+func (gnd GrayscaleNodeData) Description() string {
+	return "Bakes a per-vertex float1 attribute, like ambient occlusion, into a grayscale texture using the mesh's UVs"
+}
+
+func (gnd GrayscaleNodeData) Out() nodes.StructOutput[image.Image] {
+	if gnd.Mesh == nil || gnd.Attribute == nil {
+		return nodes.NewStructOutput[image.Image](image.NewGray(image.Rect(0, 0, 1, 1)))
+	}
+
+	tex, err := Float1Attribute(
+		gnd.Mesh.Value(),
+		gnd.Attribute.Value(),
+		nodeParameters(gnd.Resolution, gnd.Padding),
+	)
+	return imageOutput(tex, err, GrayscaleImage)
+}
+
+type ObjectSpaceNormalsNode = nodes.Struct[ObjectSpaceNormalsNodeData]
+
+type ObjectSpaceNormalsNodeData struct {
+	Mesh       nodes.Output[modeling.Mesh]
+	Resolution nodes.Output[int] `description:"Width and height of the texture"`
+	Padding    nodes.Output[int] `description:"Number of texels each UV island is grown outward by"`
+}
+
+func (osnd ObjectSpaceNormalsNodeData) Description() string {
+	return "Bakes the mesh's vertex normals into an object space normal map using the mesh's UVs"
+}
+
+func (osnd ObjectSpaceNormalsNodeData) Out() nodes.StructOutput[image.Image] {
+	if osnd.Mesh == nil {
+		return nodes.NewStructOutput[image.Image](image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	}
+
+	tex, err := ObjectSpaceNormals(osnd.Mesh.Value(), nodeParameters(osnd.Resolution, osnd.Padding))
+	return imageOutput(tex, err, NormalImage)
+}
+
+type HighPolyNode = nodes.Struct[HighPolyNodeData]
+
+type HighPolyNodeData struct {
+	LowPoly      nodes.Output[modeling.Mesh] `description:"Mesh with UVs and normals the detail is baked onto"`
+	HighPoly     nodes.Output[modeling.Mesh] `description:"Mesh containing the detail to capture"`
+	Resolution   nodes.Output[int]           `description:"Width and height of the textures"`
+	Padding      nodes.Output[int]           `description:"Number of texels each UV island is grown outward by"`
+	CageDistance nodes.Output[float64]       `description:"How far out along the low poly mesh's normals rays are cast from"`
+}
+
+func (hpnd HighPolyNodeData) Description() string {
+	return "Captures the surface detail of a high poly mesh into normal and displacement textures laid out using a low poly mesh's UVs"
+}
+
+func (hpnd HighPolyNodeData) detail(ctx context.Context) (Detail, error) {
+	return HighPolyContext(ctx, hpnd.LowPoly.Value(), hpnd.HighPoly.Value(), HighPolyParameters{
+		Parameters:   nodeParameters(hpnd.Resolution, hpnd.Padding),
+		CageDistance: nodes.TryGetOutputValue(hpnd.CageDistance, 0.),
+	})
+}
+
+// Normals is a tangent space normal map, suitable for glTF normal textures
+func (hpnd HighPolyNodeData) Normals(ctx context.Context) nodes.StructOutput[image.Image] {
+	if hpnd.LowPoly == nil || hpnd.HighPoly == nil {
+		return nodes.NewStructOutput[image.Image](image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	}
+
+	detail, err := hpnd.detail(ctx)
+	return imageOutput(detail.Normals, err, NormalImage)
+}
+
+// Displacement is the distance between the two surfaces, normalized so the
+// full range of the image is used
+func (hpnd HighPolyNodeData) Displacement(ctx context.Context) nodes.StructOutput[image.Image] {
+	if hpnd.LowPoly == nil || hpnd.HighPoly == nil {
+		return nodes.NewStructOutput[image.Image](image.NewGray(image.Rect(0, 0, 1, 1)))
+	}
+
+	detail, err := hpnd.detail(ctx)
+	return imageOutput(detail.Displacement, err, NormalizedImage)
+}
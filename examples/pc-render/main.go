@@ -289,15 +289,11 @@ func colorPassthrough(c color.Color) color.Color { return c }
 func loadMesh() (*modeling.Mesh, error) {
 	return ply.Load("./test-models/stanford-bunny.ply")
 
-	doc, buffs, err := gltf.ExperimentalLoad("C:/Users/elida/Downloads/example.gltf")
-	if err != nil {
-		return nil, err
-	}
-
-	models, err := gltf.ExperimentalDecodeModels(doc, buffs)
+	scene, err := gltf.Load("C:/Users/elida/Downloads/example.gltf")
 	if err != nil {
 		return nil, err
 	}
+	models := scene.Models
 
 	finalMesh := modeling.EmptyMesh(models[0].Mesh.Topology())
 	for _, v := range models {
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/formats/ply"
+	"github.com/EliCDavis/polyform/formats/potree"
+	"github.com/urfave/cli/v2"
+)
+
+var FromPlyCommand = &cli.Command{
+	Name:  "from-ply",
+	Usage: "Convert a PLY point cloud into a potree dataset",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "in",
+			Usage:    "PLY file to convert",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Value: "potree",
+			Usage: "Folder to write metadata.json, hierarchy.bin and octree.bin to",
+		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "Name of the dataset",
+		},
+		&cli.Float64Flag{
+			Name:  "scale",
+			Value: 0.001,
+			Usage: "Precision to store positions with",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		cloud, err := ply.Load(ctx.String("in"))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(ctx.App.Writer, "Writing pointcloud with %d points to %s\n", cloud.PrimitiveCount(), ctx.String("out"))
+		return potree.Save(ctx.String("out"), cloud.ToPointCloud(), &potree.WriterOptions{
+			Name:  ctx.String("name"),
+			Scale: ctx.Float64("scale"),
+		})
+	},
+}
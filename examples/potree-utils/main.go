@@ -25,6 +25,7 @@ func main() {
 				},
 			},
 			ToPlyCommand,
+			FromPlyCommand,
 		},
 	}
 
@@ -11,16 +11,16 @@ func ImageDataToPointCloud(images []colmap.Image) modeling.Mesh {
 
 	positionData := make([]vector3.Float64, len(images))
 	rotationData := make([]vector4.Float64, len(images))
-	idData := make([]float64, len(images))
-	cameraIdData := make([]float64, len(images))
-	pointCountData := make([]float64, len(images))
+	idData := make([]int, len(images))
+	cameraIdData := make([]int, len(images))
+	pointCountData := make([]int, len(images))
 
 	for i, p := range images {
 		positionData[i] = p.Translation
 		rotationData[i] = p.Rotation
-		idData[i] = float64(p.Id)
-		cameraIdData[i] = float64(p.CameraId)
-		pointCountData[i] = float64(len(p.Points))
+		idData[i] = int(p.Id)
+		cameraIdData[i] = int(p.CameraId)
+		pointCountData[i] = len(p.Points)
 	}
 
 	return modeling.NewPointCloud(
@@ -31,12 +31,12 @@ func ImageDataToPointCloud(images []colmap.Image) modeling.Mesh {
 			modeling.PositionAttribute: positionData,
 		},
 		nil,
-		map[string][]float64{
-			"id":          idData,
-			"camera id":   cameraIdData,
-			"point count": pointCountData,
-		},
-	)
+		nil,
+	).SetInt1Data(map[string][]int{
+		"id":          idData,
+		"camera id":   cameraIdData,
+		"point count": pointCountData,
+	})
 }
 
 func LoadImageData(filename string) (modeling.Mesh, error) {
@@ -34,20 +34,20 @@ func TestImagesToPointcloud(t *testing.T) {
 	assert.Equal(t, 1, indexData.Len())
 	assert.Equal(t, 0, indexData.At(0))
 
-	assert.True(t, pointcloud.HasFloat1Attribute("id"))
-	idData := pointcloud.Float1Attribute("id")
+	assert.True(t, pointcloud.HasInt1Attribute("id"))
+	idData := pointcloud.Int1Attribute("id")
 	assert.Equal(t, 1, idData.Len())
-	assert.Equal(t, 1., idData.At(0))
+	assert.Equal(t, 1, idData.At(0))
 
-	assert.True(t, pointcloud.HasFloat1Attribute("point count"))
-	pointCountData := pointcloud.Float1Attribute("point count")
+	assert.True(t, pointcloud.HasInt1Attribute("point count"))
+	pointCountData := pointcloud.Int1Attribute("point count")
 	assert.Equal(t, 1, pointCountData.Len())
-	assert.Equal(t, 10., pointCountData.At(0))
+	assert.Equal(t, 10, pointCountData.At(0))
 
-	assert.True(t, pointcloud.HasFloat1Attribute("camera id"))
-	cameraIdData := pointcloud.Float1Attribute("camera id")
+	assert.True(t, pointcloud.HasInt1Attribute("camera id"))
+	cameraIdData := pointcloud.Int1Attribute("camera id")
 	assert.Equal(t, 1, cameraIdData.Len())
-	assert.Equal(t, 2., cameraIdData.At(0))
+	assert.Equal(t, 2, cameraIdData.At(0))
 
 	assert.True(t, pointcloud.HasFloat3Attribute(modeling.PositionAttribute))
 	positionData := pointcloud.Float3Attribute(modeling.PositionAttribute)
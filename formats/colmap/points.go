@@ -12,15 +12,15 @@ func PointDataToPointCloud(points []colmap.Point3D) modeling.Mesh {
 	positionData := make([]vector3.Float64, len(points))
 	colorData := make([]vector3.Float64, len(points))
 	errorData := make([]float64, len(points))
-	idData := make([]float64, len(points))
-	lenTrackData := make([]float64, len(points))
+	idData := make([]int, len(points))
+	lenTrackData := make([]int, len(points))
 
 	for i, p := range points {
 		positionData[i] = p.Position
 		colorData[i] = vector3.FromColor(p.Color)
 		errorData[i] = p.Error
-		idData[i] = float64(p.ID)
-		lenTrackData[i] = float64(len(p.Tracks))
+		idData[i] = int(p.ID)
+		lenTrackData[i] = len(p.Tracks)
 	}
 
 	return modeling.NewPointCloud(
@@ -31,11 +31,12 @@ func PointDataToPointCloud(points []colmap.Point3D) modeling.Mesh {
 		},
 		nil,
 		map[string][]float64{
-			"error":       errorData,
-			"id":          idData,
-			"track count": lenTrackData,
+			"error": errorData,
 		},
-	)
+	).SetInt1Data(map[string][]int{
+		"id":          idData,
+		"track count": lenTrackData,
+	})
 }
 
 func ReadSparsePointData(in io.Reader) (modeling.Mesh, error) {
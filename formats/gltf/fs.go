@@ -54,3 +54,27 @@ func Save(modelPath string, scene PolyformScene) error {
 		panic(fmt.Errorf("don't know how to save file with extension: %s", ext))
 	}
 }
+
+// LoadFile reads the glTF or GLB document at the path specified along with
+// all buffers it references. External resources are resolved relative to
+// the document's directory.
+func LoadFile(modelPath string) (*Gltf, [][]byte, error) {
+	f, err := os.Open(modelPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return Read(bufio.NewReader(f), &ReaderOptions{BasePath: filepath.Dir(modelPath)})
+}
+
+// Load reads the glTF or GLB file at the path specified and decodes it into a
+// scene
+func Load(modelPath string) (*PolyformScene, error) {
+	options := &ReaderOptions{BasePath: filepath.Dir(modelPath)}
+	doc, buffers, err := LoadFile(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeScene(doc, buffers, options)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/EliCDavis/polyform/math/trs"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/vector/vector3"
 )
 
 type PolyformScene struct {
@@ -31,6 +32,12 @@ type PolyformModel struct {
 	Mesh     *modeling.Mesh
 	Material *PolyformMaterial
 
+	// Materials used by each of the mesh's material slots, keyed by slot
+	// name. When the mesh has primitive groups, a GLTF primitive is written
+	// for every group, with slots missing from here falling back to
+	// Material.
+	Materials map[string]*PolyformMaterial
+
 	// TRS contains the transformation (translation, rotation, scale) for this model
 	// This is optional and it will be used if the models are deduplicated and collapsed into a list of instances.
 	TRS *trs.TRS
@@ -48,6 +55,21 @@ type PolyformModel struct {
 	// Limitations on using GpuInstances still apply.
 	Skeleton   *animation.Skeleton
 	Animations []animation.Sequence
+
+	// MorphTargets are named sets of per-vertex displacements, blended on
+	// top of the mesh by their weights. Their weights can be animated with
+	// sequences using the animation.WeightsChannel
+	MorphTargets []PolyformMorphTarget
+}
+
+// PolyformMorphTarget is a set of displacements applied to every vertex of a
+// mesh. Either displacement array may be left empty, otherwise it must
+// contain an entry for every vertex in the mesh.
+type PolyformMorphTarget struct {
+	Name      string
+	Weight    float64 // Default weight applied to the target
+	Positions []vector3.Float64
+	Normals   []vector3.Float64
 }
 
 type PolyformMaterial struct {
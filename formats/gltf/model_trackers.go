@@ -1,6 +1,9 @@
 package gltf
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/EliCDavis/polyform/math/trs"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/modeling/animation"
@@ -30,8 +33,22 @@ func (mt materialIndices) findExistingMaterialID(mat *PolyformMaterial) (*int, b
 
 // materialEntry tracks a unique material and its corresponding GLTF material index
 type meshEntry struct {
-	polyMesh      *modeling.Mesh
-	materialIndex int // -1 is a valid value for absence of material
+	polyMesh       *modeling.Mesh
+	materialIndex  int    // -1 is a valid value for absence of material
+	groupMaterials string // Material indices of each primitive group, empty for meshes without groups
+}
+
+// groupMaterialsKey builds a comparable key out of the material indices
+// assigned to each of a mesh's primitive groups
+func groupMaterialsKey(indices []*int) string {
+	key := make([]string, len(indices))
+	for i, index := range indices {
+		key[i] = "-1"
+		if index != nil {
+			key[i] = strconv.Itoa(*index)
+		}
+	}
+	return strings.Join(key, ",")
 }
 
 // materialIndices handle deduplication of GLTF materials
@@ -43,6 +60,7 @@ type textureIndices map[*PolyformTexture]int
 type writtenMeshData struct {
 	attribute map[string]GltfId
 	indices   *GltfId
+	groups    []GltfId // Indices of each primitive group, for meshes with groups
 }
 
 type attributeIndices map[*modeling.Mesh]writtenMeshData
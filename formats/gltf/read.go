@@ -1,15 +1,20 @@
 package gltf
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/EliCDavis/polyform/math/mat"
 	"github.com/EliCDavis/polyform/math/quaternion"
 	"github.com/EliCDavis/polyform/math/trs"
 	"github.com/EliCDavis/polyform/modeling"
@@ -18,6 +23,24 @@ import (
 	"github.com/EliCDavis/vector/vector4"
 )
 
+const (
+	glbMagic     = 0x46546C67
+	glbJsonChunk = 0x4E4F534A
+	glbBinChunk  = 0x004E4942
+)
+
+var ErrUnresolvableURI = errors.New("unable to resolve uri")
+
+// ReaderOptions controls how external resources referenced by a glTF
+// document get resolved
+type ReaderOptions struct {
+	// Directory relative URIs (external .bin buffers and images) are resolved
+	// against. If left empty, any buffer referencing an external file will
+	// result in an error, and images referencing external files will only
+	// have their URI populated.
+	BasePath string
+}
+
 func decodeTopology(mode *PrimitiveMode) modeling.Topology {
 	if mode == nil {
 		return modeling.TriangleTopology
@@ -41,30 +64,124 @@ func decodeTopology(mode *PrimitiveMode) modeling.Topology {
 	}
 }
 
+// Converts triangle strips and fans into a plain list of triangles
+func decodeTriangleIndices(mode *PrimitiveMode, indices []int) []int {
+	if mode == nil {
+		return indices
+	}
+
+	switch *mode {
+	case PrimitiveMode_TRIANGLE_STRIP:
+		tris := make([]int, 0, max(len(indices)-2, 0)*3)
+		for i := 2; i < len(indices); i++ {
+			if i%2 == 0 {
+				tris = append(tris, indices[i-2], indices[i-1], indices[i])
+			} else {
+				tris = append(tris, indices[i-1], indices[i-2], indices[i])
+			}
+		}
+		return tris
+
+	case PrimitiveMode_TRIANGLE_FAN:
+		tris := make([]int, 0, max(len(indices)-2, 0)*3)
+		for i := 2; i < len(indices); i++ {
+			tris = append(tris, indices[i-1], indices[i], indices[0])
+		}
+		return tris
+	}
+
+	return indices
+}
+
+func (at AccessorType) componentCount() int {
+	switch at {
+	case AccessorType_SCALAR:
+		return 1
+
+	case AccessorType_VEC2:
+		return 2
+
+	case AccessorType_VEC3:
+		return 3
+
+	case AccessorType_VEC4, AccessorType_MAT2:
+		return 4
+
+	case AccessorType_MAT3:
+		return 9
+
+	case AccessorType_MAT4:
+		return 16
+	}
+	return 0
+}
+
+func accessorBuffer(doc *Gltf, accessor Accessor, buffers [][]byte) ([]byte, int, error) {
+	if accessor.BufferView == nil {
+		return nil, 0, nil
+	}
+
+	if *accessor.BufferView < 0 || *accessor.BufferView >= len(doc.BufferViews) {
+		return nil, 0, fmt.Errorf("buffer view %d out of range", *accessor.BufferView)
+	}
+	bufferView := doc.BufferViews[*accessor.BufferView]
+
+	if bufferView.Buffer < 0 || bufferView.Buffer >= len(buffers) {
+		return nil, 0, fmt.Errorf("buffer %d out of range", bufferView.Buffer)
+	}
+
+	start := bufferView.ByteOffset + accessor.ByteOffset
+	end := bufferView.ByteOffset + bufferView.ByteLength
+	buffer := buffers[bufferView.Buffer]
+	if start > end || end > len(buffer) {
+		return nil, 0, fmt.Errorf("buffer view %d exceeds the length of buffer %d", *accessor.BufferView, bufferView.Buffer)
+	}
+
+	stride := accessor.ComponentType.Size() * accessor.Type.componentCount()
+	if bufferView.ByteStride != nil && *bufferView.ByteStride > 0 {
+		stride = *bufferView.ByteStride
+	}
+
+	return buffer[start:end], stride, nil
+}
+
 func decodeIndices(doc *Gltf, id *GltfId, buffers [][]byte) ([]int, error) {
+	if *id < 0 || *id >= len(doc.Accessors) {
+		return nil, fmt.Errorf("indices accessor %d out of range", *id)
+	}
+
 	accessor := doc.Accessors[*id]
 	if accessor.Type != AccessorType_SCALAR {
 		return nil, fmt.Errorf("unexpected accessor type for indices: %s", accessor.Type)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	start := bufferView.ByteOffset + accessor.ByteOffset
-	end := bufferView.ByteOffset + bufferView.ByteLength
-	buffer := buffers[bufferView.Buffer][start:end]
+	buffer, _, err := accessorBuffer(doc, accessor, buffers)
+	if err != nil {
+		return nil, err
+	}
 
 	indices := make([]int, accessor.Count)
 	switch accessor.ComponentType {
 	case AccessorComponentType_UNSIGNED_INT:
+		if len(buffer) < accessor.Count*4 {
+			return nil, fmt.Errorf("indices accessor %d exceeds its buffer view", *id)
+		}
 		for i := range indices {
 			indices[i] = int(binary.LittleEndian.Uint32(buffer[i*4:]))
 		}
 
 	case AccessorComponentType_UNSIGNED_SHORT:
+		if len(buffer) < accessor.Count*2 {
+			return nil, fmt.Errorf("indices accessor %d exceeds its buffer view", *id)
+		}
 		for i := range indices {
 			indices[i] = int(binary.LittleEndian.Uint16(buffer[i*2:]))
 		}
 
 	case AccessorComponentType_UNSIGNED_BYTE:
+		if len(buffer) < accessor.Count {
+			return nil, fmt.Errorf("indices accessor %d exceeds its buffer view", *id)
+		}
 		for i := range indices {
 			indices[i] = int(buffer[i])
 		}
@@ -77,213 +194,363 @@ func decodeIndices(doc *Gltf, id *GltfId, buffers [][]byte) ([]int, error) {
 	return indices, nil
 }
 
-func decodePrimitiveAttributeName(name string) string {
-	switch name {
-	case POSITION:
-		return modeling.PositionAttribute
-
-	case COLOR_0:
-		return modeling.ColorAttribute
+func decodeComponent(buffer []byte, componentType AccessorComponentType, normalized bool) float64 {
+	switch componentType {
+	case AccessorComponentType_FLOAT:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer)))
 
-	case JOINTS_0:
-		return modeling.JointAttribute
+	case AccessorComponentType_UNSIGNED_INT:
+		return float64(binary.LittleEndian.Uint32(buffer))
 
-	case WEIGHTS_0:
-		return modeling.WeightAttribute
+	case AccessorComponentType_UNSIGNED_SHORT:
+		v := float64(binary.LittleEndian.Uint16(buffer))
+		if normalized {
+			return v / math.MaxUint16
+		}
+		return v
 
-	case TEXCOORD_0:
-		return modeling.TexCoordAttribute
+	case AccessorComponentType_SHORT:
+		v := float64(int16(binary.LittleEndian.Uint16(buffer)))
+		if normalized {
+			return math.Max(v/math.MaxInt16, -1)
+		}
+		return v
 
-	case NORMAL:
-		return modeling.NormalAttribute
+	case AccessorComponentType_UNSIGNED_BYTE:
+		v := float64(buffer[0])
+		if normalized {
+			return v / math.MaxUint8
+		}
+		return v
 
-	default:
-		return name
+	case AccessorComponentType_BYTE:
+		v := float64(int8(buffer[0]))
+		if normalized {
+			return math.Max(v/math.MaxInt8, -1)
+		}
+		return v
 	}
+	panic(fmt.Errorf("unimplemented accessor component type: %d", componentType))
 }
 
-func decodeVector2Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector2.Float64, error) {
+// decodeAccessor reads all elements of an accessor into a flat array of
+// components, converting from whatever component type is stored in the
+// buffer.
+func decodeAccessor(doc *Gltf, id GltfId, buffers [][]byte) ([]float64, int, error) {
+	if id < 0 || id >= len(doc.Accessors) {
+		return nil, 0, fmt.Errorf("accessor %d out of range", id)
+	}
 	accessor := doc.Accessors[id]
-	if accessor.Type != AccessorType_VEC2 {
-		return nil, fmt.Errorf("unexpected accessor type for vec2: %s", accessor.Type)
+
+	components := accessor.Type.componentCount()
+	if components == 0 {
+		return nil, 0, fmt.Errorf("unimplemented accessor type: %s", accessor.Type)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	start := bufferView.ByteOffset + accessor.ByteOffset
-	end := bufferView.ByteOffset + bufferView.ByteLength
-	buffer := buffers[bufferView.Buffer][start:end]
+	switch accessor.ComponentType {
+	case AccessorComponentType_FLOAT,
+		AccessorComponentType_UNSIGNED_INT,
+		AccessorComponentType_UNSIGNED_SHORT,
+		AccessorComponentType_SHORT,
+		AccessorComponentType_UNSIGNED_BYTE,
+		AccessorComponentType_BYTE:
+	default:
+		return nil, 0, fmt.Errorf("unimplemented accessor component type: %d", accessor.ComponentType)
+	}
 
-	stride := accessor.ComponentType.Size() * 2
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
+	data := make([]float64, accessor.Count*components)
+
+	buffer, stride, err := accessorBuffer(doc, accessor, buffers)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	vectors := make([]vector2.Float64, accessor.Count)
+	// Accessors without a buffer view are to be initialized with zeros
+	if buffer == nil {
+		return data, components, nil
+	}
 
-	switch accessor.ComponentType {
+	componentSize := accessor.ComponentType.Size()
+	elementSize := componentSize * components
+	if accessor.Count > 0 && ((accessor.Count-1)*stride)+elementSize > len(buffer) {
+		return nil, 0, fmt.Errorf("accessor %d exceeds its buffer view", id)
+	}
 
-	case AccessorComponentType_FLOAT:
-		for i := range accessor.Count {
-			offset := (i * stride)
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			vectors[i] = vector2.New(x, y)
+	for i := 0; i < accessor.Count; i++ {
+		offset := i * stride
+		for c := 0; c < components; c++ {
+			data[(i*components)+c] = decodeComponent(
+				buffer[offset+(c*componentSize):],
+				accessor.ComponentType,
+				accessor.Normalized,
+			)
 		}
+	}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := 1.
-		if accessor.Normalized {
-			div = 255.
-		}
+	return data, components, nil
+}
 
-		for i := range accessor.Count {
-			offset := (i * stride)
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			vectors[i] = vector2.New(x, y)
-		}
+func decodeFloat1Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]float64, error) {
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if components != 1 {
+		return nil, fmt.Errorf("unexpected accessor type for scalar: %s", doc.Accessors[id].Type)
+	}
+	return data, nil
+}
 
-	default:
-		return nil, fmt.Errorf("unimplemented accessor component type: %d", accessor.ComponentType)
+func decodeVector2Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector2.Float64, error) {
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if components != 2 {
+		return nil, fmt.Errorf("unexpected accessor type for vec2: %s", doc.Accessors[id].Type)
 	}
 
+	vectors := make([]vector2.Float64, len(data)/2)
+	for i := range vectors {
+		vectors[i] = vector2.New(data[i*2], data[(i*2)+1])
+	}
 	return vectors, nil
 }
 
 func decodeVector3Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector3.Float64, error) {
-	accessor := doc.Accessors[id]
-	if accessor.Type != AccessorType_VEC3 {
-		return nil, fmt.Errorf("unexpected accessor type for vec3: %s", accessor.Type)
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if components != 3 {
+		return nil, fmt.Errorf("unexpected accessor type for vec3: %s", doc.Accessors[id].Type)
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	start := bufferView.ByteOffset + accessor.ByteOffset
-	end := bufferView.ByteOffset + bufferView.ByteLength
-	buffer := buffers[bufferView.Buffer][start:end]
+	vectors := make([]vector3.Float64, len(data)/3)
+	for i := range vectors {
+		vectors[i] = vector3.New(data[i*3], data[(i*3)+1], data[(i*3)+2])
+	}
+	return vectors, nil
+}
 
-	stride := accessor.ComponentType.Size() * 3
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
+func decodeVector4Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector4.Float64, error) {
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Accessors[id].Type != AccessorType_VEC4 {
+		return nil, fmt.Errorf("unexpected accessor type for vec4: %s", doc.Accessors[id].Type)
 	}
 
-	vectors := make([]vector3.Float64, accessor.Count)
+	vectors := make([]vector4.Float64, len(data)/components)
+	for i := range vectors {
+		vectors[i] = vector4.New(data[i*4], data[(i*4)+1], data[(i*4)+2], data[(i*4)+3])
+	}
+	return vectors, nil
+}
 
-	switch accessor.ComponentType {
+// Column major 4x4 matrices
+func decodeMatrix4Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]mat.Matrix4x4, error) {
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Accessors[id].Type != AccessorType_MAT4 {
+		return nil, fmt.Errorf("unexpected accessor type for mat4: %s", doc.Accessors[id].Type)
+	}
 
-	case AccessorComponentType_FLOAT:
-		for i := range accessor.Count {
-			offset := (i * stride)
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
-			vectors[i] = vector3.New(x, y, z)
-		}
+	matrices := make([]mat.Matrix4x4, len(data)/components)
+	for i := range matrices {
+		var m [16]float64
+		copy(m[:], data[i*16:(i+1)*16])
+		matrices[i] = columnMajorToMatrix(m)
+	}
+	return matrices, nil
+}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := 1.
-		if accessor.Normalized {
-			div = 255.
-		}
+func columnMajorToMatrix(m [16]float64) mat.Matrix4x4 {
+	return mat.Matrix4x4{
+		X00: m[0], X01: m[4], X02: m[8], X03: m[12],
+		X10: m[1], X11: m[5], X12: m[9], X13: m[13],
+		X20: m[2], X21: m[6], X22: m[10], X23: m[14],
+		X30: m[3], X31: m[7], X32: m[11], X33: m[15],
+	}
+}
 
-		for i := range accessor.Count {
-			offset := (i * stride)
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			z := float64(buffer[offset+2]) / div
-			vectors[i] = vector3.New(x, y, z)
-		}
+func decodePrimitiveAttributeName(name string) string {
+	switch name {
+	case POSITION:
+		return modeling.PositionAttribute
+
+	case COLOR_0:
+		return modeling.ColorAttribute
+
+	case JOINTS_0:
+		return modeling.JointAttribute
+
+	case WEIGHTS_0:
+		return modeling.WeightAttribute
+
+	case TEXCOORD_0:
+		return modeling.TexCoordAttribute
+
+	case NORMAL:
+		return modeling.NormalAttribute
 
 	default:
-		return nil, fmt.Errorf("unimplemented accessor component type: %d", accessor.ComponentType)
+		return name
 	}
-
-	return vectors, nil
 }
 
-func decodeVector4Accessor(doc *Gltf, id GltfId, buffers [][]byte) ([]vector4.Float64, error) {
-	accessor := doc.Accessors[id]
-	if accessor.Type != AccessorType_VEC4 {
-		return nil, fmt.Errorf("unexpected accessor type for vec4: %s", accessor.Type)
+// integerAttribute reports whether or not the primitive attribute holds
+// integer data, like joint indices or ids, rather than quantized floats.
+// Attributes defined by the spec other than joints are always floats, which
+// leaves non-normalized integer data in custom attributes.
+func integerAttribute(name string, accessor Accessor) bool {
+	if accessor.ComponentType == AccessorComponentType_FLOAT || accessor.Normalized {
+		return false
 	}
 
-	bufferView := doc.BufferViews[*accessor.BufferView]
-	start := bufferView.ByteOffset + accessor.ByteOffset
-	bufferEnd := bufferView.ByteOffset + bufferView.ByteLength
-	buffer := buffers[bufferView.Buffer][start:bufferEnd]
+	if strings.HasPrefix(name, "JOINTS_") {
+		return true
+	}
 
-	stride := accessor.ComponentType.Size() * 4
-	if bufferView.ByteStride != nil {
-		stride = *bufferView.ByteStride
+	if name == POSITION || name == NORMAL || name == TANGENT {
+		return false
 	}
 
-	vectors := make([]vector4.Float64, accessor.Count)
-	switch accessor.ComponentType {
-	case AccessorComponentType_FLOAT:
-		for i := range vectors {
-			offset := (i * stride)
-			x := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset:])))
-			y := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+4:])))
-			z := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+8:])))
-			w := float64(math.Float32frombits(binary.LittleEndian.Uint32(buffer[offset+12:])))
-			vectors[i] = vector4.New(x, y, z, w)
+	for _, prefix := range []string{"TEXCOORD_", "COLOR_", "WEIGHTS_"} {
+		if strings.HasPrefix(name, prefix) {
+			return false
 		}
+	}
+	return true
+}
 
-	case AccessorComponentType_UNSIGNED_BYTE:
-		div := 1.
-		if accessor.Normalized {
-			div = 255.
+// decodeIntAttribute sets the integer attribute on the mesh from the
+// accessor's data
+func decodeIntAttribute(doc *Gltf, id GltfId, buffers [][]byte, mesh modeling.Mesh, attributeName string) (modeling.Mesh, error) {
+	data, components, err := decodeAccessor(doc, id, buffers)
+	if err != nil {
+		return mesh, err
+	}
+
+	switch components {
+	case 1:
+		values := make([]int, len(data))
+		for i, v := range data {
+			values[i] = int(v)
 		}
+		return mesh.SetInt1Attribute(attributeName, values), nil
 
-		for i := range vectors {
-			offset := (i * stride)
-			x := float64(buffer[offset]) / div
-			y := float64(buffer[offset+1]) / div
-			z := float64(buffer[offset+2]) / div
-			w := float64(buffer[offset+3]) / div
-			vectors[i] = vector4.New(x, y, z, w)
+	case 2:
+		values := make([]vector2.Int, len(data)/2)
+		for i := range values {
+			values[i] = vector2.New(int(data[i*2]), int(data[(i*2)+1]))
 		}
+		return mesh.SetInt2Attribute(attributeName, values), nil
 
-	default:
-		return nil, fmt.Errorf("unimplemented accessor component type: %d", accessor.ComponentType)
+	case 3:
+		values := make([]vector3.Int, len(data)/3)
+		for i := range values {
+			values[i] = vector3.New(int(data[i*3]), int(data[(i*3)+1]), int(data[(i*3)+2]))
+		}
+		return mesh.SetInt3Attribute(attributeName, values), nil
 	}
 
-	return vectors, nil
+	if doc.Accessors[id].Type != AccessorType_VEC4 {
+		return mesh, fmt.Errorf("unsupported accessor type for integer data: %s", doc.Accessors[id].Type)
+	}
+
+	values := make([]vector4.Int, len(data)/4)
+	for i := range values {
+		values[i] = vector4.New(int(data[i*4]), int(data[(i*4)+1]), int(data[(i*4)+2]), int(data[(i*4)+3]))
+	}
+	return mesh.SetInt4Attribute(attributeName, values), nil
 }
 
-func decodePrimitive(doc *Gltf, buffers [][]byte, n Node, m Mesh, p Primitive) (*PolyformModel, error) {
-	indices, err := decodeIndices(doc, p.Indices, buffers)
-	if err != nil {
-		return nil, err
+func decodePrimitiveMesh(doc *Gltf, buffers [][]byte, p Primitive) (*modeling.Mesh, error) {
+	var indices []int
+	if p.Indices != nil {
+		var err error
+		indices, err = decodeIndices(doc, p.Indices, buffers)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Non-indexed geometry, every vertex is referenced in order
+		position, ok := p.Attributes[POSITION]
+		if !ok || position < 0 || position >= len(doc.Accessors) {
+			return nil, fmt.Errorf("non-indexed primitive is missing a %s attribute", POSITION)
+		}
+		indices = make([]int, doc.Accessors[position].Count)
+		for i := range indices {
+			indices[i] = i
+		}
 	}
-	mesh := modeling.NewMesh(decodeTopology(p.Mode), indices)
+
+	mesh := modeling.NewMesh(decodeTopology(p.Mode), decodeTriangleIndices(p.Mode, indices))
 
 	for attr, gltfId := range p.Attributes {
-		acessor := doc.Accessors[gltfId]
+		if gltfId < 0 || gltfId >= len(doc.Accessors) {
+			return nil, fmt.Errorf("attribute %s: accessor %d out of range", attr, gltfId)
+		}
+
+		accessor := doc.Accessors[gltfId]
 		attributeName := decodePrimitiveAttributeName(attr)
-		switch acessor.Type {
+		if integerAttribute(attr, accessor) {
+			var err error
+			mesh, err = decodeIntAttribute(doc, gltfId, buffers, mesh, attributeName)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", attr, err)
+			}
+			continue
+		}
+
+		switch accessor.Type {
+		case AccessorType_SCALAR:
+			v1, err := decodeFloat1Accessor(doc, gltfId, buffers)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", attr, err)
+			}
+			mesh = mesh.SetFloat1Attribute(attributeName, v1)
+
 		case AccessorType_VEC2:
 			v2, err := decodeVector2Accessor(doc, gltfId, buffers)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("attribute %s: %w", attr, err)
 			}
 			mesh = mesh.SetFloat2Attribute(attributeName, v2)
 
 		case AccessorType_VEC3:
 			v3, err := decodeVector3Accessor(doc, gltfId, buffers)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("attribute %s: %w", attr, err)
 			}
 			mesh = mesh.SetFloat3Attribute(attributeName, v3)
 
 		case AccessorType_VEC4:
 			v4, err := decodeVector4Accessor(doc, gltfId, buffers)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("attribute %s: %w", attr, err)
 			}
 			mesh = mesh.SetFloat4Attribute(attributeName, v4)
+
+		default:
+			return nil, fmt.Errorf("attribute %s: unsupported accessor type %s", attr, accessor.Type)
 		}
 	}
 
+	return &mesh, nil
+}
+
+// decodeNodeTransform builds the local transform of a node, either from its
+// matrix or its individual TRS properties
+func decodeNodeTransform(n Node) trs.TRS {
+	if n.Matrix != nil {
+		return trs.FromMatrix(columnMajorToMatrix(*n.Matrix))
+	}
+
 	transform := trs.Identity()
 	if n.Translation != nil {
 		data := *n.Translation
@@ -303,73 +570,160 @@ func decodePrimitive(doc *Gltf, buffers [][]byte, n Node, m Mesh, p Primitive) (
 		transform = transform.SetRotation(p)
 	}
 
-	return &PolyformModel{
-		Name: n.Name,
-		Mesh: &mesh,
-		TRS:  &transform,
-	}, nil
+	return transform
 }
 
+// Deprecated: Use DecodeScene, which resolves the node hierarchy, materials
+// and textures
 func ExperimentalDecodeModels(doc *Gltf, buffers [][]byte) ([]PolyformModel, error) {
-	models := make([]PolyformModel, 0)
+	scene, err := DecodeScene(doc, buffers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return scene.Models, nil
+}
 
-	for nodeIndex, node := range doc.Nodes {
-		if node.Mesh == nil {
-			continue
+// Deprecated: Use LoadFile, which also supports GLB files
+func ExperimentalLoad(gltfPath string) (*Gltf, [][]byte, error) {
+	return LoadFile(gltfPath)
+}
+
+func decodeDataURI(uri string) ([]byte, string, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		return nil, "", fmt.Errorf("not a data uri")
+	}
+
+	header, data, found := strings.Cut(uri[5:], ",")
+	if !found {
+		return nil, "", fmt.Errorf("malformed data uri")
+	}
+
+	mime, isBase64 := strings.CutSuffix(header, ";base64")
+	if !isBase64 {
+		return nil, "", fmt.Errorf("unimplemented data uri encoding: %s", header)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	return decoded, mime, err
+}
+
+func resolveURI(uri string, options *ReaderOptions) ([]byte, error) {
+	if strings.HasPrefix(uri, "data:") {
+		data, _, err := decodeDataURI(uri)
+		return data, err
+	}
+
+	if options == nil || options.BasePath == "" {
+		return nil, fmt.Errorf("%w: %q, no base path provided", ErrUnresolvableURI, uri)
+	}
+
+	return os.ReadFile(filepath.Join(options.BasePath, filepath.FromSlash(uri)))
+}
+
+func readGLB(data []byte) (*Gltf, []byte, error) {
+	if len(data) < 20 {
+		return nil, nil, fmt.Errorf("glb too short to contain a header")
+	}
+
+	version := binary.LittleEndian.Uint32(data[4:])
+	if version != 2 {
+		return nil, nil, fmt.Errorf("unsupported glb version: %d", version)
+	}
+
+	length := int(binary.LittleEndian.Uint32(data[8:]))
+	if length > len(data) {
+		return nil, nil, fmt.Errorf("glb header reports length %d but only %d bytes are available", length, len(data))
+	}
+
+	var jsonChunk, binChunk []byte
+	offset := 12
+	for offset+8 <= length {
+		chunkLength := int(binary.LittleEndian.Uint32(data[offset:]))
+		chunkType := binary.LittleEndian.Uint32(data[offset+4:])
+		start := offset + 8
+		end := start + chunkLength
+		if end > length {
+			return nil, nil, fmt.Errorf("glb chunk exceeds length of file")
 		}
 
-		mesh := doc.Meshes[*node.Mesh]
+		switch chunkType {
+		case glbJsonChunk:
+			if jsonChunk == nil {
+				jsonChunk = data[start:end]
+			}
 
-		for primitiveIndex, p := range mesh.Primitives {
-			model, err := decodePrimitive(doc, buffers, node, mesh, p)
-			if err != nil {
-				return nil, fmt.Errorf("Node %d Meshes[%d].primitives[%d]: %w", nodeIndex, *node.Mesh, primitiveIndex, err)
+		case glbBinChunk:
+			if binChunk == nil {
+				binChunk = data[start:end]
 			}
-			models = append(models, *model)
 		}
 
+		offset = end
 	}
 
-	return models, nil
-}
+	if jsonChunk == nil {
+		return nil, nil, fmt.Errorf("glb missing json chunk")
+	}
 
-func ExperimentalLoad(gltfPath string) (*Gltf, [][]byte, error) {
-	gltfContents, err := os.ReadFile(gltfPath)
-	if err != nil {
+	g := &Gltf{}
+	if err := json.Unmarshal(jsonChunk, g); err != nil {
 		return nil, nil, err
 	}
 
-	g := &Gltf{}
-	err = json.Unmarshal(gltfContents, g)
+	return g, binChunk, nil
+}
+
+// Read parses either a glTF JSON document or a binary GLB container from the
+// reader, and loads all buffers it references
+func Read(in io.Reader, options *ReaderOptions) (*Gltf, [][]byte, error) {
+	data, err := io.ReadAll(in)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	allBuffers := make([][]byte, 0, len(g.Buffers))
+	var g *Gltf
+	var binChunk []byte
+	if len(data) >= 4 && binary.LittleEndian.Uint32(data) == glbMagic {
+		g, binChunk, err = readGLB(data)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		g = &Gltf{}
+		if err = json.Unmarshal(data, g); err != nil {
+			return nil, nil, err
+		}
+	}
 
+	allBuffers := make([][]byte, 0, len(g.Buffers))
 	for bufIndex, buf := range g.Buffers {
-		if strings.Index(buf.URI, "data:") == 0 {
-			stringBuf := buf.URI[5:]
-
-			base64Str := "application/octet-stream;base64,"
-			if strings.Index(stringBuf, base64Str) == 0 {
-				buf64, err := base64.StdEncoding.DecodeString(stringBuf[len(base64Str):])
-				if err != nil {
-					return g, allBuffers, err
-				}
+		// The GLB-stored buffer is the first buffer, with an undefined uri
+		if bufIndex == 0 && buf.URI == "" && binChunk != nil {
+			allBuffers = append(allBuffers, binChunk)
+			continue
+		}
 
-				allBuffers = append(allBuffers, buf64)
-			} else {
-				return g, allBuffers, fmt.Errorf("unimplemented buffer encoding on buffer %d", bufIndex)
-			}
-		} else {
-			buf, err := os.ReadFile(filepath.Join(filepath.Dir(gltfPath), buf.URI))
-			if err != nil {
-				return g, allBuffers, err
-			}
-			allBuffers = append(allBuffers, buf)
+		contents, err := resolveURI(buf.URI, options)
+		if err != nil {
+			return g, allBuffers, fmt.Errorf("buffer %d: %w", bufIndex, err)
 		}
+		allBuffers = append(allBuffers, contents)
 	}
 
 	return g, allBuffers, nil
 }
+
+// ReadScene parses a glTF or GLB document from the reader and decodes it into
+// a scene
+func ReadScene(in io.Reader, options *ReaderOptions) (*PolyformScene, error) {
+	doc, buffers, err := Read(in, options)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeScene(doc, buffers, options)
+}
+
+func decodeImageData(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
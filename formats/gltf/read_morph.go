@@ -0,0 +1,103 @@
+package gltf
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/modeling/animation"
+)
+
+func (d *sceneDecoder) decodeMorphTargets(node Node, mesh Mesh, primitive Primitive) ([]PolyformMorphTarget, error) {
+	// Node weights take priority over the mesh's defaults
+	weights := mesh.Weights
+	if len(node.Weights) > 0 {
+		weights = node.Weights
+	}
+
+	var names []any
+	if mesh.Extras != nil {
+		names, _ = mesh.Extras["targetNames"].([]any)
+	}
+
+	targets := make([]PolyformMorphTarget, len(primitive.Targets))
+	for i, attributes := range primitive.Targets {
+		target := PolyformMorphTarget{}
+
+		if i < len(names) {
+			target.Name, _ = names[i].(string)
+		}
+
+		if i < len(weights) {
+			target.Weight = weights[i]
+		}
+
+		if accessor, ok := attributes[POSITION]; ok {
+			positions, err := decodeVector3Accessor(d.doc, accessor, d.buffers)
+			if err != nil {
+				return nil, fmt.Errorf("morph target %d positions: %w", i, err)
+			}
+			target.Positions = positions
+		}
+
+		if accessor, ok := attributes[NORMAL]; ok {
+			normals, err := decodeVector3Accessor(d.doc, accessor, d.buffers)
+			if err != nil {
+				return nil, fmt.Errorf("morph target %d normals: %w", i, err)
+			}
+			target.Normals = normals
+		}
+
+		targets[i] = target
+	}
+
+	return targets, nil
+}
+
+// decodeWeightAnimations collects every animation channel that targets the
+// morph target weights of the node provided
+func (d *sceneDecoder) decodeWeightAnimations(nodeID GltfId, targetCount int) ([]animation.Sequence, error) {
+	sequences := make([]animation.Sequence, 0)
+
+	for animationIndex, anim := range d.doc.Animations {
+		for channelIndex, channel := range anim.Channels {
+			if channel.Target.Node != nodeID || channel.Target.Path != AnimationChannelTargetPath_WEIGHTS {
+				continue
+			}
+
+			if channel.Sampler < 0 || channel.Sampler >= len(anim.Samplers) {
+				return nil, fmt.Errorf("animation %d channel %d: sampler %d out of range", animationIndex, channelIndex, channel.Sampler)
+			}
+			sampler := anim.Samplers[channel.Sampler]
+
+			interpolation, err := decodeInterpolation(sampler.Interpolation)
+			if err != nil {
+				return nil, fmt.Errorf("animation %d channel %d: %w", animationIndex, channelIndex, err)
+			}
+
+			times, err := decodeFloat1Accessor(d.doc, sampler.Input, d.buffers)
+			if err != nil {
+				return nil, fmt.Errorf("animation %d channel %d input: %w", animationIndex, channelIndex, err)
+			}
+
+			values, err := decodeFloat1Accessor(d.doc, sampler.Output, d.buffers)
+			if err != nil {
+				return nil, fmt.Errorf("animation %d channel %d output: %w", animationIndex, channelIndex, err)
+			}
+
+			// Group the flat list of weights into a slice per keyframe value
+			// (and tangent, for cubic splines)
+			grouped := make([][]float64, 0, len(values)/max(targetCount, 1))
+			for i := 0; i+targetCount <= len(values) && targetCount > 0; i += targetCount {
+				grouped = append(grouped, values[i:i+targetCount])
+			}
+
+			frames, err := keyframeValues(times, grouped, interpolation)
+			if err != nil {
+				return nil, fmt.Errorf("animation %d channel %d: %w", animationIndex, channelIndex, err)
+			}
+
+			sequences = append(sequences, animation.NewWeightsSequence(interpolation, frames))
+		}
+	}
+
+	return sequences, nil
+}
@@ -0,0 +1,765 @@
+package gltf
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+)
+
+// sceneDecoder keeps track of everything decoded so far, so that resources
+// referenced multiple times within the document resolve to the same pointer.
+// This mirrors the writer, which dedups meshes, materials and textures by
+// pointer.
+type sceneDecoder struct {
+	doc     *Gltf
+	buffers [][]byte
+	options *ReaderOptions
+
+	meshes    map[[2]int]*modeling.Mesh
+	materials map[int]*PolyformMaterial
+	textures  map[int]*PolyformTexture
+	samplers  map[int]*Sampler
+	images    map[int]decodedImage
+	skins     map[int]*decodedSkin
+	parents   []int
+
+	// Skinned meshes have their joint indices remapped, keyed by mesh,
+	// primitive and skin
+	skinnedMeshes map[[3]int]*modeling.Mesh
+}
+
+type decodedImage struct {
+	uri string
+	img image.Image
+}
+
+// DecodeScene interprets the glTF document, producing a model for every mesh
+// primitive found in the document's scene. Each model's TRS is the world
+// transform of the node that instanced it.
+func DecodeScene(doc *Gltf, buffers [][]byte, options *ReaderOptions) (*PolyformScene, error) {
+	decoder := &sceneDecoder{
+		doc:       doc,
+		buffers:   buffers,
+		options:   options,
+		meshes:    make(map[[2]int]*modeling.Mesh),
+		materials: make(map[int]*PolyformMaterial),
+		textures:  make(map[int]*PolyformTexture),
+		samplers:  make(map[int]*Sampler),
+		images:    make(map[int]decodedImage),
+		skins:     make(map[int]*decodedSkin),
+
+		skinnedMeshes: make(map[[3]int]*modeling.Mesh),
+	}
+	return decoder.decode()
+}
+
+func (d *sceneDecoder) rootNodes() []GltfId {
+	if len(d.doc.Scenes) > 0 {
+		scene := d.doc.Scene
+		if scene < 0 || scene >= len(d.doc.Scenes) {
+			scene = 0
+		}
+		return d.doc.Scenes[scene].Nodes
+	}
+
+	// No scenes defined, treat every node without a parent as a root
+	hasParent := make([]bool, len(d.doc.Nodes))
+	for _, node := range d.doc.Nodes {
+		for _, child := range node.Children {
+			if child >= 0 && child < len(hasParent) {
+				hasParent[child] = true
+			}
+		}
+	}
+
+	roots := make([]GltfId, 0)
+	for i, parented := range hasParent {
+		if !parented {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
+
+func (d *sceneDecoder) decode() (*PolyformScene, error) {
+	scene := &PolyformScene{}
+
+	lights, err := d.decodeLights()
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make([]bool, len(d.doc.Nodes))
+	var visit func(nodeID GltfId, parent trs.TRS) error
+	visit = func(nodeID GltfId, parent trs.TRS) error {
+		if nodeID < 0 || nodeID >= len(d.doc.Nodes) {
+			return fmt.Errorf("node %d out of range", nodeID)
+		}
+		if visited[nodeID] {
+			return fmt.Errorf("node %d is referenced multiple times within the scene hierarchy", nodeID)
+		}
+		visited[nodeID] = true
+
+		node := d.doc.Nodes[nodeID]
+		world := parent.Multiply(decodeNodeTransform(node))
+
+		if node.Mesh != nil {
+			models, err := d.decodeMesh(nodeID, world)
+			if err != nil {
+				return fmt.Errorf("node %d: %w", nodeID, err)
+			}
+			scene.Models = append(scene.Models, models...)
+		}
+
+		if lightID, ok := nodeLight(node); ok {
+			if lightID < 0 || lightID >= len(lights) {
+				return fmt.Errorf("node %d: light %d out of range", nodeID, lightID)
+			}
+			light := lights[lightID]
+			light.Position = world.Position()
+			scene.Lights = append(scene.Lights, light)
+		}
+
+		for _, child := range node.Children {
+			if err := visit(child, world); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, root := range d.rootNodes() {
+		if err := visit(root, trs.Identity()); err != nil {
+			return nil, err
+		}
+	}
+
+	return scene, nil
+}
+
+func (d *sceneDecoder) decodeMesh(nodeID GltfId, transform trs.TRS) ([]PolyformModel, error) {
+	node := d.doc.Nodes[nodeID]
+	meshID := *node.Mesh
+	skinID := node.Skin
+	nodeName := node.Name
+	if meshID < 0 || meshID >= len(d.doc.Meshes) {
+		return nil, fmt.Errorf("mesh %d out of range", meshID)
+	}
+
+	var skin *decodedSkin
+	if skinID != nil {
+		var err error
+		skin, err = d.decodeSkin(*skinID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Skinned meshes are placed by their joints, so the transform of the
+		// node holding them is ignored
+		transform = trs.Identity()
+	}
+
+	mesh := d.doc.Meshes[meshID]
+	name := nodeName
+	if name == "" {
+		name = mesh.Name
+	}
+
+	models := make([]PolyformModel, 0, len(mesh.Primitives))
+	for primitiveIndex, primitive := range mesh.Primitives {
+		key := [2]int{meshID, primitiveIndex}
+		m, ok := d.meshes[key]
+		if !ok {
+			var err error
+			m, err = decodePrimitiveMesh(d.doc, d.buffers, primitive)
+			if err != nil {
+				return nil, fmt.Errorf("mesh %d primitive %d: %w", meshID, primitiveIndex, err)
+			}
+			d.meshes[key] = m
+		}
+
+		if skin != nil {
+			skinnedKey := [3]int{meshID, primitiveIndex, *skinID}
+			skinned, ok := d.skinnedMeshes[skinnedKey]
+			if !ok {
+				remapped := remapJoints(*m, skin.jointRemap)
+				skinned = &remapped
+				d.skinnedMeshes[skinnedKey] = skinned
+			}
+			m = skinned
+		}
+
+		var material *PolyformMaterial
+		if primitive.Material != nil {
+			var err error
+			material, err = d.decodeMaterial(*primitive.Material)
+			if err != nil {
+				return nil, fmt.Errorf("mesh %d primitive %d: %w", meshID, primitiveIndex, err)
+			}
+		}
+
+		modelTRS := transform
+		model := PolyformModel{
+			Name:     name,
+			Mesh:     m,
+			Material: material,
+			TRS:      &modelTRS,
+		}
+
+		if skin != nil {
+			model.Skeleton = skin.skeleton
+			model.Animations = skin.animations
+		}
+
+		if len(primitive.Targets) > 0 {
+			targets, err := d.decodeMorphTargets(node, mesh, primitive)
+			if err != nil {
+				return nil, fmt.Errorf("mesh %d primitive %d: %w", meshID, primitiveIndex, err)
+			}
+			model.MorphTargets = targets
+
+			weightAnimations, err := d.decodeWeightAnimations(nodeID, len(targets))
+			if err != nil {
+				return nil, fmt.Errorf("node %d: %w", nodeID, err)
+			}
+			if len(weightAnimations) > 0 {
+				model.Animations = append(slices.Clone(model.Animations), weightAnimations...)
+			}
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+// Lights ======================================================================
+
+func nodeLight(node Node) (int, bool) {
+	ext, ok := node.Extensions["KHR_lights_punctual"].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	light, ok := ext["light"].(float64)
+	return int(light), ok
+}
+
+func (d *sceneDecoder) decodeLights() ([]KHR_LightsPunctual, error) {
+	ext, ok := d.doc.Extensions["KHR_lights_punctual"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	rawLights, _ := ext["lights"].([]any)
+	lights := make([]KHR_LightsPunctual, len(rawLights))
+	for i, rawLight := range rawLights {
+		data, ok := rawLight.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("light %d: unexpected light definition", i)
+		}
+
+		light := KHR_LightsPunctual{
+			Type:      KHR_LightsPunctualType(stringValue(data, "type")),
+			Intensity: floatPtrValue(data, "intensity"),
+			Range:     floatPtrValue(data, "range"),
+		}
+
+		if name, ok := data["name"].(string); ok {
+			light.Name = &name
+		}
+
+		if c, ok := floatArrayValue(data, "color", 3); ok {
+			light.Color = floatArrToColor(c)
+		}
+
+		lights[i] = light
+	}
+
+	return lights, nil
+}
+
+// Materials ===================================================================
+
+func floatArrToColor(values []float64) color.Color {
+	channel := func(v float64) uint16 {
+		return uint16(math.Round(math.Max(0, math.Min(1, v)) * math.MaxUint16))
+	}
+
+	c := color.RGBA64{A: math.MaxUint16}
+	if len(values) > 0 {
+		c.R = channel(values[0])
+	}
+	if len(values) > 1 {
+		c.G = channel(values[1])
+	}
+	if len(values) > 2 {
+		c.B = channel(values[2])
+	}
+	if len(values) > 3 {
+		c.A = channel(values[3])
+	}
+	return c
+}
+
+func (d *sceneDecoder) decodeMaterial(materialID GltfId) (*PolyformMaterial, error) {
+	if material, ok := d.materials[materialID]; ok {
+		return material, nil
+	}
+
+	if materialID < 0 || materialID >= len(d.doc.Materials) {
+		return nil, fmt.Errorf("material %d out of range", materialID)
+	}
+	gltfMat := d.doc.Materials[materialID]
+
+	material := &PolyformMaterial{
+		Name:        gltfMat.Name,
+		Extras:      gltfMat.Extras,
+		AlphaMode:   gltfMat.AlphaMode,
+		AlphaCutoff: gltfMat.AlphaCutoff,
+	}
+
+	if gltfMat.EmissiveFactor != nil {
+		material.EmissiveFactor = floatArrToColor(gltfMat.EmissiveFactor[:])
+	}
+
+	if pbr := gltfMat.PbrMetallicRoughness; pbr != nil {
+		polyPBR := &PolyformPbrMetallicRoughness{
+			MetallicFactor:  pbr.MetallicFactor,
+			RoughnessFactor: pbr.RoughnessFactor,
+		}
+
+		if pbr.BaseColorFactor != nil {
+			polyPBR.BaseColorFactor = floatArrToColor(pbr.BaseColorFactor[:])
+		}
+
+		var err error
+		if polyPBR.BaseColorTexture, err = d.decodeTextureInfo(pbr.BaseColorTexture); err != nil {
+			return nil, fmt.Errorf("material %d base color texture: %w", materialID, err)
+		}
+
+		if polyPBR.MetallicRoughnessTexture, err = d.decodeTextureInfo(pbr.MetallicRoughnessTexture); err != nil {
+			return nil, fmt.Errorf("material %d metallic roughness texture: %w", materialID, err)
+		}
+
+		material.PbrMetallicRoughness = polyPBR
+	}
+
+	if gltfMat.NormalTexture != nil {
+		tex, err := d.decodeTextureInfo(&gltfMat.NormalTexture.TextureInfo)
+		if err != nil {
+			return nil, fmt.Errorf("material %d normal texture: %w", materialID, err)
+		}
+		material.NormalTexture = &PolyformNormal{
+			PolyformTexture: tex,
+			Scale:           gltfMat.NormalTexture.Scale,
+		}
+	}
+
+	if gltfMat.OcclusionTexture != nil {
+		tex, err := d.decodeTextureInfo(&gltfMat.OcclusionTexture.TextureInfo)
+		if err != nil {
+			return nil, fmt.Errorf("material %d occlusion texture: %w", materialID, err)
+		}
+		material.OcclusionTexture = &PolyformOcclusion{
+			PolyformTexture: tex,
+			Strength:        gltfMat.OcclusionTexture.Strength,
+		}
+	}
+
+	// Keep extension ordering stable between reads
+	extensionIDs := make([]string, 0, len(gltfMat.Extensions))
+	for id := range gltfMat.Extensions {
+		extensionIDs = append(extensionIDs, id)
+	}
+	slices.Sort(extensionIDs)
+
+	for _, id := range extensionIDs {
+		data, ok := gltfMat.Extensions[id].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		ext, err := d.decodeMaterialExtension(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("material %d extension %s: %w", materialID, id, err)
+		}
+
+		if ext != nil {
+			material.Extensions = append(material.Extensions, ext)
+		}
+	}
+
+	d.materials[materialID] = material
+	return material, nil
+}
+
+// decodeMaterialExtension builds the polyform representation of the material
+// extensions the writer supports. Unknown extensions are dropped.
+func (d *sceneDecoder) decodeMaterialExtension(id string, data map[string]any) (MaterialExtension, error) {
+	var err error
+	texture := func(key string) *PolyformTexture {
+		if err != nil {
+			return nil
+		}
+		var tex *PolyformTexture
+		tex, err = d.decodeTextureInfoData(data[key])
+		return tex
+	}
+
+	var ext MaterialExtension
+	switch id {
+	case PolyformPbrSpecularGlossiness{}.ExtensionID():
+		sg := PolyformPbrSpecularGlossiness{
+			GlossinessFactor:          floatPtrValue(data, "glossinessFactor"),
+			DiffuseTexture:            texture("diffuseTexture"),
+			SpecularGlossinessTexture: texture("specularGlossinessTexture"),
+		}
+		if c, ok := floatArrayValue(data, "diffuseFactor", 4); ok {
+			sg.DiffuseFactor = floatArrToColor(c)
+		}
+		if c, ok := floatArrayValue(data, "specularFactor", 3); ok {
+			sg.SpecularFactor = floatArrToColor(c)
+		}
+		ext = sg
+
+	case PolyformTransmission{}.ExtensionID():
+		ext = PolyformTransmission{
+			Factor:  floatValue(data, "transmissionFactor", 0),
+			Texture: texture("transmissionTexture"),
+		}
+
+	case PolyformVolume{}.ExtensionID():
+		volume := PolyformVolume{
+			ThicknessFactor:     floatValue(data, "thicknessFactor", 0),
+			ThicknessTexture:    texture("thicknessTexture"),
+			AttenuationDistance: floatPtrValue(data, "attenuationDistance"),
+		}
+		if c, ok := floatArrayValue(data, "attenuationColor", 3); ok {
+			volume.AttenuationColor = floatArrToColor(c)
+		}
+		ext = volume
+
+	case PolyformIndexOfRefraction{}.ExtensionID():
+		ext = PolyformIndexOfRefraction{
+			IOR: floatPtrValue(data, "ior"),
+		}
+
+	case PolyformSpecular{}.ExtensionID():
+		specular := PolyformSpecular{
+			Factor:       floatPtrValue(data, "specularFactor"),
+			Texture:      texture("specularTexture"),
+			ColorTexture: texture("specularColorTexture"),
+		}
+		if c, ok := floatArrayValue(data, "specularColorFactor", 3); ok {
+			specular.ColorFactor = floatArrToColor(c)
+		}
+		ext = specular
+
+	case PolyformUnlit{}.ExtensionID():
+		ext = PolyformUnlit{}
+
+	case PolyformClearcoat{}.ExtensionID():
+		clearcoat := PolyformClearcoat{
+			ClearcoatFactor:           floatValue(data, "clearcoatFactor", 0),
+			ClearcoatTexture:          texture("clearcoatTexture"),
+			ClearcoatRoughnessFactor:  floatValue(data, "clearcoatRoughnessFactor", 0),
+			ClearcoatRoughnessTexture: texture("clearcoatRoughnessTexture"),
+		}
+		if normal := texture("clearcoatNormalTexture"); normal != nil {
+			clearcoat.ClearcoatNormalTexture = &PolyformNormal{
+				PolyformTexture: normal,
+				Scale:           floatPtrValue(textureInfoMap(data["clearcoatNormalTexture"]), "scale"),
+			}
+		}
+		ext = clearcoat
+
+	case PolyformEmissiveStrength{}.ExtensionID():
+		ext = PolyformEmissiveStrength{
+			EmissiveStrength: floatPtrValue(data, "emissiveStrength"),
+		}
+
+	case PolyformIridescence{}.ExtensionID():
+		ext = PolyformIridescence{
+			IridescenceFactor:           floatValue(data, "iridescenceFactor", 0),
+			IridescenceTexture:          texture("iridescenceTexture"),
+			IridescenceIor:              floatPtrValue(data, "iridescenceIor"),
+			IridescenceThicknessMinimum: floatPtrValue(data, "iridescenceThicknessMinimum"),
+			IridescenceThicknessMaximum: floatPtrValue(data, "iridescenceThicknessMaximum"),
+			IridescenceThicknessTexture: texture("iridescenceThicknessTexture"),
+		}
+
+	case PolyformSheen{}.ExtensionID():
+		sheen := PolyformSheen{
+			SheenColorTexture:     texture("sheenColorTexture"),
+			SheenRoughnessFactor:  floatValue(data, "sheenRoughnessFactor", 0),
+			SheenRoughnessTexture: texture("sheenRoughnessTexture"),
+		}
+		if c, ok := floatArrayValue(data, "sheenColorFactor", 3); ok {
+			sheen.SheenColorFactor = floatArrToColor(c)
+		}
+		ext = sheen
+
+	case PolyformAnisotropy{}.ExtensionID():
+		ext = PolyformAnisotropy{
+			AnisotropyStrength: floatValue(data, "anisotropyStrength", 0),
+			AnisotropyRotation: floatValue(data, "anisotropyRotation", 0),
+			AnisotropyTexture:  texture("anisotropyTexture"),
+		}
+
+	case PolyformDispersion{}.ExtensionID():
+		ext = PolyformDispersion{
+			Dispersion: floatValue(data, "dispersion", 0),
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+// Textures ====================================================================
+
+func textureInfoMap(raw any) map[string]any {
+	data, _ := raw.(map[string]any)
+	return data
+}
+
+// decodeTextureInfoData interprets texture info found within an extension,
+// which has been left in its generic JSON form
+func (d *sceneDecoder) decodeTextureInfoData(raw any) (*PolyformTexture, error) {
+	data := textureInfoMap(raw)
+	if data == nil {
+		return nil, nil
+	}
+
+	index, ok := data["index"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("texture info missing index")
+	}
+
+	info := &TextureInfo{
+		Index:      int(index),
+		TexCoord:   int(floatValue(data, "texCoord", 0)),
+		Extensions: textureInfoMap(data["extensions"]),
+	}
+	return d.decodeTextureInfo(info)
+}
+
+func (d *sceneDecoder) decodeTextureInfo(info *TextureInfo) (*PolyformTexture, error) {
+	if info == nil {
+		return nil, nil
+	}
+
+	base, err := d.decodeTexture(info.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	infoExtensions := d.decodeTextureInfoExtensions(info.Extensions)
+	if len(infoExtensions) == 0 {
+		return base, nil
+	}
+
+	// Texture info extensions are specific to this usage of the texture, so
+	// it can't be shared with others referencing the same texture
+	tex := *base
+	tex.Extensions = append(slices.Clone(base.Extensions), infoExtensions...)
+	return &tex, nil
+}
+
+func (d *sceneDecoder) decodeTextureInfoExtensions(extensions Extensions) []TextureExtension {
+	var results []TextureExtension
+
+	id := PolyformTextureTransform{}.ExtensionID()
+	if data, ok := extensions[id].(map[string]any); ok {
+		transform := PolyformTextureTransform{
+			Required: slices.Contains(d.doc.ExtensionsRequired, id),
+			Rotation: floatPtrValue(data, "rotation"),
+		}
+
+		if offset, ok := floatArrayValue(data, "offset", 2); ok {
+			v := vector2.New(offset[0], offset[1])
+			transform.Offset = &v
+		}
+
+		if scale, ok := floatArrayValue(data, "scale", 2); ok {
+			v := vector2.New(scale[0], scale[1])
+			transform.Scale = &v
+		}
+
+		if texCoord, ok := data["texCoord"].(float64); ok {
+			t := int(texCoord)
+			transform.TexCoord = &t
+		}
+
+		results = append(results, transform)
+	}
+
+	return results
+}
+
+func (d *sceneDecoder) decodeTexture(textureID GltfId) (*PolyformTexture, error) {
+	if tex, ok := d.textures[textureID]; ok {
+		return tex, nil
+	}
+
+	if textureID < 0 || textureID >= len(d.doc.Textures) {
+		return nil, fmt.Errorf("texture %d out of range", textureID)
+	}
+	gltfTex := d.doc.Textures[textureID]
+
+	tex := &PolyformTexture{}
+
+	if gltfTex.Sampler != nil {
+		sampler, err := d.decodeSampler(*gltfTex.Sampler)
+		if err != nil {
+			return nil, fmt.Errorf("texture %d: %w", textureID, err)
+		}
+		tex.Sampler = sampler
+	}
+
+	if gltfTex.Source != nil {
+		img, err := d.decodeImage(*gltfTex.Source)
+		if err != nil {
+			return nil, fmt.Errorf("texture %d: %w", textureID, err)
+		}
+		tex.URI = img.uri
+		tex.Image = img.img
+	}
+
+	d.textures[textureID] = tex
+	return tex, nil
+}
+
+func (d *sceneDecoder) decodeSampler(samplerID GltfId) (*Sampler, error) {
+	if sampler, ok := d.samplers[samplerID]; ok {
+		return sampler, nil
+	}
+
+	if samplerID < 0 || samplerID >= len(d.doc.Samplers) {
+		return nil, fmt.Errorf("sampler %d out of range", samplerID)
+	}
+
+	sampler := d.doc.Samplers[samplerID]
+	d.samplers[samplerID] = &sampler
+	return &sampler, nil
+}
+
+// decodeImage resolves the image's contents. Images stored in buffers or data
+// URIs get decoded into memory, while images referencing external files keep
+// their URI, and are additionally loaded if the file can be found.
+func (d *sceneDecoder) decodeImage(imageID GltfId) (decodedImage, error) {
+	if img, ok := d.images[imageID]; ok {
+		return img, nil
+	}
+
+	if imageID < 0 || imageID >= len(d.doc.Images) {
+		return decodedImage{}, fmt.Errorf("image %d out of range", imageID)
+	}
+	gltfImg := d.doc.Images[imageID]
+
+	var result decodedImage
+	switch {
+	case gltfImg.BufferView != nil:
+		data, err := d.bufferViewData(*gltfImg.BufferView)
+		if err != nil {
+			return result, fmt.Errorf("image %d: %w", imageID, err)
+		}
+
+		result.img, err = decodeImageData(data)
+		if err != nil {
+			return result, fmt.Errorf("image %d: %w", imageID, err)
+		}
+
+	case strings.HasPrefix(gltfImg.URI, "data:"):
+		data, _, err := decodeDataURI(gltfImg.URI)
+		if err != nil {
+			return result, fmt.Errorf("image %d: %w", imageID, err)
+		}
+
+		result.img, err = decodeImageData(data)
+		if err != nil {
+			return result, fmt.Errorf("image %d: %w", imageID, err)
+		}
+
+	default:
+		result.uri = gltfImg.URI
+		if d.options != nil && d.options.BasePath != "" && gltfImg.URI != "" {
+			data, err := os.ReadFile(filepath.Join(d.options.BasePath, filepath.FromSlash(gltfImg.URI)))
+			if err == nil {
+				result.img, _ = decodeImageData(data)
+			}
+		}
+	}
+
+	d.images[imageID] = result
+	return result, nil
+}
+
+func (d *sceneDecoder) bufferViewData(bufferViewID GltfId) ([]byte, error) {
+	if bufferViewID < 0 || bufferViewID >= len(d.doc.BufferViews) {
+		return nil, fmt.Errorf("buffer view %d out of range", bufferViewID)
+	}
+	view := d.doc.BufferViews[bufferViewID]
+
+	if view.Buffer < 0 || view.Buffer >= len(d.buffers) {
+		return nil, fmt.Errorf("buffer %d out of range", view.Buffer)
+	}
+	buffer := d.buffers[view.Buffer]
+
+	end := view.ByteOffset + view.ByteLength
+	if view.ByteOffset < 0 || end > len(buffer) {
+		return nil, fmt.Errorf("buffer view %d exceeds the length of buffer %d", bufferViewID, view.Buffer)
+	}
+	return buffer[view.ByteOffset:end], nil
+}
+
+// Generic JSON helpers ========================================================
+
+func stringValue(data map[string]any, key string) string {
+	s, _ := data[key].(string)
+	return s
+}
+
+func floatValue(data map[string]any, key string, fallback float64) float64 {
+	if v, ok := data[key].(float64); ok {
+		return v
+	}
+	return fallback
+}
+
+func floatPtrValue(data map[string]any, key string) *float64 {
+	if v, ok := data[key].(float64); ok {
+		return &v
+	}
+	return nil
+}
+
+func floatArrayValue(data map[string]any, key string, length int) ([]float64, bool) {
+	raw, ok := data[key].([]any)
+	if !ok || len(raw) < length {
+		return nil, false
+	}
+
+	values := make([]float64, length)
+	for i := range values {
+		v, ok := raw[i].(float64)
+		if !ok {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
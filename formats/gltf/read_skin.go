@@ -0,0 +1,355 @@
+package gltf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EliCDavis/polyform/math/mat"
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+type decodedSkin struct {
+	skeleton   *animation.Skeleton
+	animations []animation.Sequence
+
+	// Index into the skeleton for each entry in the skin's joints array
+	jointRemap []int
+}
+
+func (d *sceneDecoder) nodeParents() []int {
+	if d.parents != nil {
+		return d.parents
+	}
+
+	d.parents = make([]int, len(d.doc.Nodes))
+	for i := range d.parents {
+		d.parents[i] = -1
+	}
+
+	for i, node := range d.doc.Nodes {
+		for _, child := range node.Children {
+			if child >= 0 && child < len(d.parents) {
+				d.parents[child] = i
+			}
+		}
+	}
+	return d.parents
+}
+
+func (d *sceneDecoder) decodeSkin(skinID GltfId) (*decodedSkin, error) {
+	if skin, ok := d.skins[skinID]; ok {
+		return skin, nil
+	}
+
+	if skinID < 0 || skinID >= len(d.doc.Skins) {
+		return nil, fmt.Errorf("skin %d out of range", skinID)
+	}
+	skin := d.doc.Skins[skinID]
+
+	if len(skin.Joints) == 0 {
+		return nil, fmt.Errorf("skin %d has no joints", skinID)
+	}
+
+	// Where each joint sits when the mesh is bound to it. Skins without
+	// inverse bind matrices bind every joint at the origin.
+	bindMatrices := make([]mat.Matrix4x4, len(skin.Joints))
+	for i := range bindMatrices {
+		bindMatrices[i] = mat.Identity()
+	}
+
+	if skin.InverseBindMatrices != nil {
+		inverseBindMatrices, err := decodeMatrix4Accessor(d.doc, *skin.InverseBindMatrices, d.buffers)
+		if err != nil {
+			return nil, fmt.Errorf("skin %d inverse bind matrices: %w", skinID, err)
+		}
+
+		if len(inverseBindMatrices) < len(skin.Joints) {
+			return nil, fmt.Errorf("skin %d has %d joints but only %d inverse bind matrices", skinID, len(skin.Joints), len(inverseBindMatrices))
+		}
+
+		for i := range skin.Joints {
+			bindMatrices[i] = inverseBindMatrices[i].Inverse()
+		}
+	}
+
+	jointIndex := make(map[int]int, len(skin.Joints))
+	for i, joint := range skin.Joints {
+		if joint < 0 || joint >= len(d.doc.Nodes) {
+			return nil, fmt.Errorf("skin %d: joint node %d out of range", skinID, joint)
+		}
+		jointIndex[joint] = i
+	}
+
+	// A joint's parent is its closest ancestor that also belongs to the skin
+	parents := d.nodeParents()
+	children := make([][]int, len(skin.Joints))
+	roots := make([]int, 0, 1)
+	for i, joint := range skin.Joints {
+		parent := parents[joint]
+		for parent != -1 {
+			if _, ok := jointIndex[parent]; ok {
+				break
+			}
+			parent = parents[parent]
+		}
+
+		if parent == -1 {
+			roots = append(roots, i)
+		} else {
+			children[jointIndex[parent]] = append(children[jointIndex[parent]], i)
+		}
+	}
+
+	var buildJoint func(index int, name string) animation.Joint
+	buildJoint = func(index int, name string) animation.Joint {
+		usedNames := make(map[string]bool)
+		childJoints := make([]animation.Joint, len(children[index]))
+		for i, child := range children[index] {
+			childName := uniqueJointName(d.jointName(skin.Joints[child]), usedNames)
+			childJoints[i] = buildJoint(child, childName)
+		}
+
+		bind := bindMatrices[index]
+		return animation.NewJoint(
+			name,
+			1,
+			vector3.New(bind.X03, bind.X13, bind.X23),
+			vector3.New(bind.X01, bind.X11, bind.X21).Normalized(),
+			vector3.New(bind.X02, bind.X12, bind.X22).Normalized(),
+			childJoints...,
+		)
+	}
+
+	// Skeletons require a single root, so multiple root joints get grouped
+	// under a new joint at the origin
+	var root animation.Joint
+	if len(roots) == 1 {
+		root = buildJoint(roots[0], d.jointName(skin.Joints[roots[0]]))
+	} else {
+		usedNames := map[string]bool{}
+		rootChildren := make([]animation.Joint, len(roots))
+		for i, r := range roots {
+			rootChildren[i] = buildJoint(r, uniqueJointName(d.jointName(skin.Joints[r]), usedNames))
+		}
+		root = animation.NewJoint(
+			"Root",
+			1,
+			vector3.Zero[float64](),
+			vector3.Up[float64](),
+			vector3.Forward[float64](),
+			rootChildren...,
+		)
+	}
+
+	skeleton := animation.NewSkeleton(root)
+
+	// Recover where each of the skin's joints ended up within the skeleton by
+	// walking both hierarchies in the same order
+	jointRemap := make([]int, len(skin.Joints))
+	var walk func(skinJoint, skeletonJoint int)
+	walk = func(skinJoint, skeletonJoint int) {
+		jointRemap[skinJoint] = skeletonJoint
+		for i, child := range skeleton.Children(skeletonJoint) {
+			walk(children[skinJoint][i], child)
+		}
+	}
+
+	if len(roots) == 1 {
+		walk(roots[0], 0)
+	} else {
+		for i, child := range skeleton.Children(0) {
+			walk(roots[i], child)
+		}
+	}
+
+	decoded := &decodedSkin{
+		skeleton:   &skeleton,
+		jointRemap: jointRemap,
+	}
+
+	nodeToSkeleton := make(map[int]int, len(skin.Joints))
+	for node, i := range jointIndex {
+		nodeToSkeleton[node] = jointRemap[i]
+	}
+
+	animations, err := d.decodeSkinAnimations(skeleton, nodeToSkeleton)
+	if err != nil {
+		return nil, fmt.Errorf("skin %d: %w", skinID, err)
+	}
+	decoded.animations = animations
+
+	d.skins[skinID] = decoded
+	return decoded, nil
+}
+
+// Joint names can't be empty or contain '/', as the skeleton uses them to
+// build paths
+func (d *sceneDecoder) jointName(nodeID GltfId) string {
+	name := strings.ReplaceAll(d.doc.Nodes[nodeID].Name, "/", "_")
+	if name == "" {
+		return fmt.Sprintf("Joint %d", nodeID)
+	}
+	return name
+}
+
+// Siblings within a skeleton are required to have unique names
+func uniqueJointName(name string, used map[string]bool) string {
+	unique := name
+	for i := 1; used[unique]; i++ {
+		unique = fmt.Sprintf("%s (%d)", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+func decodeInterpolation(interpolation AnimationSamplerInterpolation) (animation.Interpolation, error) {
+	switch interpolation {
+	case AnimationSamplerInterpolation_LINEAR, "":
+		return animation.LinearInterpolation, nil
+
+	case AnimationSamplerInterpolation_STEP:
+		return animation.StepInterpolation, nil
+
+	case AnimationSamplerInterpolation_CUBICSPLINE:
+		return animation.CubicSplineInterpolation, nil
+	}
+	return animation.LinearInterpolation, fmt.Errorf("unrecognized interpolation %q", interpolation)
+}
+
+// keyframeValues pairs up each keyframe time with its value. Cubic splines
+// store an in-tangent, value, and out-tangent for every keyframe.
+func keyframeValues[T any](times []float64, values []T, interpolation animation.Interpolation) ([]animation.Frame[T], error) {
+	stride := 1
+	if interpolation == animation.CubicSplineInterpolation {
+		stride = 3
+	}
+
+	if len(values) < len(times)*stride {
+		return nil, fmt.Errorf("%d keyframes but only %d values", len(times), len(values))
+	}
+
+	frames := make([]animation.Frame[T], len(times))
+	for i, t := range times {
+		if stride == 3 {
+			frames[i] = animation.NewCubicSplineFrame(t, values[i*3], values[(i*3)+1], values[(i*3)+2])
+		} else {
+			frames[i] = animation.NewFrame(t, values[i])
+		}
+	}
+	return frames, nil
+}
+
+func (d *sceneDecoder) decodeSequence(jointPath string, channel AnimationChannel, sampler AnimationSampler) (animation.Sequence, error) {
+	interpolation, err := decodeInterpolation(sampler.Interpolation)
+	if err != nil {
+		return animation.Sequence{}, err
+	}
+
+	times, err := decodeFloat1Accessor(d.doc, sampler.Input, d.buffers)
+	if err != nil {
+		return animation.Sequence{}, fmt.Errorf("input: %w", err)
+	}
+
+	if channel.Target.Path == AnimationChannelTargetPath_ROTATION {
+		values, err := decodeVector4Accessor(d.doc, sampler.Output, d.buffers)
+		if err != nil {
+			return animation.Sequence{}, fmt.Errorf("output: %w", err)
+		}
+
+		rotations := make([]quaternion.Quaternion, len(values))
+		for i, v := range values {
+			rotations[i] = quaternion.New(vector3.New(v.X(), v.Y(), v.Z()), v.W())
+		}
+
+		frames, err := keyframeValues(times, rotations, interpolation)
+		if err != nil {
+			return animation.Sequence{}, err
+		}
+		return animation.NewRotationSequence(jointPath, interpolation, frames), nil
+	}
+
+	values, err := decodeVector3Accessor(d.doc, sampler.Output, d.buffers)
+	if err != nil {
+		return animation.Sequence{}, fmt.Errorf("output: %w", err)
+	}
+
+	frames, err := keyframeValues(times, values, interpolation)
+	if err != nil {
+		return animation.Sequence{}, err
+	}
+
+	if channel.Target.Path == AnimationChannelTargetPath_SCALE {
+		return animation.NewScaleSequence(jointPath, interpolation, frames), nil
+	}
+	return animation.NewTranslationSequence(jointPath, interpolation, frames), nil
+}
+
+// decodeSkinAnimations collects every animation channel that targets one of
+// the skeleton's joints
+func (d *sceneDecoder) decodeSkinAnimations(skeleton animation.Skeleton, nodeToSkeleton map[int]int) ([]animation.Sequence, error) {
+	sequences := make([]animation.Sequence, 0)
+
+	for animationIndex, anim := range d.doc.Animations {
+		for channelIndex, channel := range anim.Channels {
+			joint, ok := nodeToSkeleton[channel.Target.Node]
+			if !ok {
+				continue
+			}
+
+			// Morph target weights aren't tied to a joint
+			if channel.Target.Path == AnimationChannelTargetPath_WEIGHTS {
+				continue
+			}
+
+			if channel.Sampler < 0 || channel.Sampler >= len(anim.Samplers) {
+				return nil, fmt.Errorf("animation %d channel %d: sampler %d out of range", animationIndex, channelIndex, channel.Sampler)
+			}
+
+			sequence, err := d.decodeSequence(skeleton.Path(joint), channel, anim.Samplers[channel.Sampler])
+			if err != nil {
+				return nil, fmt.Errorf("animation %d channel %d: %w", animationIndex, channelIndex, err)
+			}
+			sequences = append(sequences, sequence)
+		}
+	}
+
+	return sequences, nil
+}
+
+// remapJoints rewrites the JOINTS_0 attribute so it indexes into the
+// skeleton's joints rather than the glTF skin's joint array
+func remapJoints(m modeling.Mesh, remap []int) modeling.Mesh {
+	lookup := func(i int) int {
+		if i < 0 || i >= len(remap) {
+			return i
+		}
+		return remap[i]
+	}
+
+	if m.HasInt4Attribute(modeling.JointAttribute) {
+		return m.ModifyInt4Attribute(modeling.JointAttribute, func(i int, j vector4.Int) vector4.Int {
+			return vector4.New(lookup(j.X()), lookup(j.Y()), lookup(j.Z()), lookup(j.W()))
+		})
+	}
+
+	if !m.HasFloat4Attribute(modeling.JointAttribute) {
+		return m
+	}
+
+	joints := m.Float4Attribute(modeling.JointAttribute)
+	remapped := make([]vector4.Float64, joints.Len())
+	for i := range remapped {
+		j := joints.At(i)
+		remapped[i] = vector4.New(
+			float64(lookup(int(j.X()))),
+			float64(lookup(int(j.Y()))),
+			float64(lookup(int(j.Z()))),
+			float64(lookup(int(j.W()))),
+		)
+	}
+	return m.SetFloat4Attribute(modeling.JointAttribute, remapped)
+}
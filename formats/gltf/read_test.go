@@ -1,7 +1,652 @@
 package gltf_test
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
 
-func TestRead(t *testing.T) {
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
+func texturedTri() modeling.Mesh {
+	return modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(
+			modeling.PositionAttribute, []vector3.Float64{
+				vector3.New(0., 0., 0.),
+				vector3.New(0., 1., 0.),
+				vector3.New(1., 0., 0.),
+			},
+		).
+		SetFloat3Attribute(
+			modeling.NormalAttribute, []vector3.Float64{
+				vector3.New(0., 0., 1.),
+				vector3.New(0., 0., 1.),
+				vector3.New(0., 0., 1.),
+			},
+		).
+		SetFloat2Attribute(
+			modeling.TexCoordAttribute, []vector2.Float64{
+				vector2.New(0., 0.),
+				vector2.New(0., 1.),
+				vector2.New(1., 0.),
+			},
+		)
+}
+
+func readTestScene() gltf.PolyformScene {
+	tri := texturedTri()
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	roughness := 0.25
+	metallic := 0.75
+	cutoff := 0.5
+	mode := gltf.MaterialAlphaMode_MASK
+	ior := 1.4
+	rotation := 0.5
+	offset := vector2.New(0.25, 0.5)
+
+	texture := &gltf.PolyformTexture{
+		Image: img,
+		Sampler: &gltf.Sampler{
+			MagFilter: gltf.SamplerMagFilter_NEAREST,
+			WrapS:     gltf.SamplerWrap_CLAMP_TO_EDGE,
+		},
+	}
+
+	material := &gltf.PolyformMaterial{
+		Name:        "Material",
+		AlphaMode:   &mode,
+		AlphaCutoff: &cutoff,
+		PbrMetallicRoughness: &gltf.PolyformPbrMetallicRoughness{
+			BaseColorFactor:  color.RGBA{R: 255, G: 128, B: 0, A: 255},
+			BaseColorTexture: texture,
+			MetallicFactor:   &metallic,
+			RoughnessFactor:  &roughness,
+			MetallicRoughnessTexture: &gltf.PolyformTexture{
+				Image: img,
+				Extensions: []gltf.TextureExtension{
+					gltf.PolyformTextureTransform{
+						Offset:   &offset,
+						Rotation: &rotation,
+					},
+				},
+			},
+		},
+		EmissiveFactor: color.RGBA{G: 255, A: 255},
+		Extensions: []gltf.MaterialExtension{
+			gltf.PolyformIndexOfRefraction{IOR: &ior},
+			gltf.PolyformTransmission{Factor: 0.5, Texture: texture},
+		},
+	}
+
+	transform := trs.New(
+		vector3.New(1., 2., 3.),
+		quaternion.FromTheta(0.5, vector3.New(0., 1., 0.)),
+		vector3.New(2., 2., 2.),
+	)
+
+	return gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{
+				Name:     "tri",
+				Mesh:     &tri,
+				Material: material,
+				TRS:      &transform,
+			},
+		},
+	}
+}
+
+func assertReadTestScene(t *testing.T, scene *gltf.PolyformScene) {
+	t.Helper()
+
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+	assert.Equal(t, "tri", model.Name)
+
+	// Mesh
+	tri := texturedTri()
+	require.NotNil(t, model.Mesh)
+	assert.Equal(t, modeling.TriangleTopology, model.Mesh.Topology())
+	assert.Equal(t, 3, model.Mesh.AttributeLength())
+	for _, attr := range []string{modeling.PositionAttribute, modeling.NormalAttribute} {
+		expected := tri.Float3Attribute(attr)
+		actual := model.Mesh.Float3Attribute(attr)
+		for i := 0; i < expected.Len(); i++ {
+			assert.InDelta(t, 0., expected.At(i).Distance(actual.At(i)), 1e-6)
+		}
+	}
+	assert.Equal(t, vector2.New(1., 0.), model.Mesh.Float2Attribute(modeling.TexCoordAttribute).At(2))
+
+	// Transform
+	require.NotNil(t, model.TRS)
+	assert.InDelta(t, 0., model.TRS.Position().Distance(vector3.New(1., 2., 3.)), 1e-6)
+	assert.InDelta(t, 0., model.TRS.Scale().Distance(vector3.New(2., 2., 2.)), 1e-6)
+
+	// Material
+	material := model.Material
+	require.NotNil(t, material)
+	assert.Equal(t, "Material", material.Name)
+	assert.Equal(t, gltf.MaterialAlphaMode_MASK, *material.AlphaMode)
+	assert.Equal(t, 0.5, *material.AlphaCutoff)
+
+	r, g, b, a := material.EmissiveFactor.RGBA()
+	assert.Equal(t, []uint32{0, 0xffff, 0, 0xffff}, []uint32{r, g, b, a})
+
+	pbr := material.PbrMetallicRoughness
+	require.NotNil(t, pbr)
+	assert.Equal(t, 0.75, *pbr.MetallicFactor)
+	assert.Equal(t, 0.25, *pbr.RoughnessFactor)
+	r, g, b, a = pbr.BaseColorFactor.RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	assert.InDelta(t, 0x8080, g, 0x80)
+	assert.Equal(t, uint32(0), b)
+	assert.Equal(t, uint32(0xffff), a)
+
+	// Textures
+	require.NotNil(t, pbr.BaseColorTexture)
+	require.NotNil(t, pbr.BaseColorTexture.Image)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), pbr.BaseColorTexture.Image.Bounds())
+	r, _, _, _ = pbr.BaseColorTexture.Image.At(1, 1).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	require.NotNil(t, pbr.BaseColorTexture.Sampler)
+	assert.Equal(t, gltf.SamplerMagFilter_NEAREST, pbr.BaseColorTexture.Sampler.MagFilter)
+	assert.Equal(t, gltf.SamplerWrap_CLAMP_TO_EDGE, pbr.BaseColorTexture.Sampler.WrapS)
+
+	require.NotNil(t, pbr.MetallicRoughnessTexture)
+	require.Len(t, pbr.MetallicRoughnessTexture.Extensions, 1)
+	transform := pbr.MetallicRoughnessTexture.Extensions[0].(gltf.PolyformTextureTransform)
+	assert.Equal(t, vector2.New(0.25, 0.5), *transform.Offset)
+	assert.Equal(t, 0.5, *transform.Rotation)
+	assert.Nil(t, transform.Scale)
+
+	// Extensions
+	require.Len(t, material.Extensions, 2)
+	ior := material.Extensions[0].(gltf.PolyformIndexOfRefraction)
+	assert.Equal(t, 1.4, *ior.IOR)
+	transmission := material.Extensions[1].(gltf.PolyformTransmission)
+	assert.Equal(t, 0.5, transmission.Factor)
+
+	// The same texture referenced twice resolves to the same object
+	assert.Same(t, pbr.BaseColorTexture, transmission.Texture)
+}
+
+func TestReadScene_Text(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(readTestScene(), &buf))
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assertReadTestScene(t, scene)
+}
+
+func TestReadScene_Binary(t *testing.T) {
+	// ARRANGE ================================================================
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteBinary(readTestScene(), &buf))
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assertReadTestScene(t, scene)
+}
+
+func TestReadScene_RoundTripDedupesResources(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := texturedTri()
+	material := &gltf.PolyformMaterial{Name: "shared"}
+	a := trs.Position(vector3.New(1., 0., 0.))
+	b := trs.Position(vector3.New(-1., 0., 0.))
+	original := gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{Name: "a", Mesh: &tri, Material: material, TRS: &a},
+			{Name: "b", Mesh: &tri, Material: material, TRS: &b},
+		},
+	}
+
+	first := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(original, &first))
+	scene, err := gltf.ReadScene(bytes.NewReader(first.Bytes()), nil)
+	require.NoError(t, err)
+
+	// ACT ====================================================================
+	second := bytes.Buffer{}
+	err = gltf.WriteText(*scene, &second)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 2)
+	assert.Same(t, scene.Models[0].Mesh, scene.Models[1].Mesh)
+	assert.Same(t, scene.Models[0].Material, scene.Models[1].Material)
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestReadScene_NodeHierarchy(t *testing.T) {
+	// ARRANGE ================================================================
+	// A single triangle, instanced by a child node whose parent is translated
+	// and scaled. The buffer contains 3 float32 VEC3 positions.
+	doc := `{
+		"asset": {"version": "2.0"},
+		"scene": 0,
+		"scenes": [{"nodes": [0]}],
+		"nodes": [
+			{"name": "parent", "children": [1], "translation": [10, 0, 0], "scale": [2, 2, 2]},
+			{"name": "child", "mesh": 0, "matrix": [1,0,0,0, 0,1,0,0, 0,0,1,0, 0,1,0,1]}
+		],
+		"meshes": [{"name": "tri", "primitives": [{"attributes": {"POSITION": 0}}]}],
+		"accessors": [{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}],
+		"bufferViews": [{"buffer": 0, "byteLength": 36}],
+		"buffers": [{"byteLength": 36, "uri": "data:application/octet-stream;base64,AAAAAAAAAAAAAAAAAAAAAAAAgD8AAAAAAACAPwAAAAAAAAAA"}]
+	}`
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(strings.NewReader(doc), nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+	assert.Equal(t, "child", model.Name)
+
+	// Non-indexed geometry gets sequential indices
+	assert.Equal(t, 1, model.Mesh.PrimitiveCount())
+	assert.Equal(t, vector3.New(0., 1., 0.), model.Mesh.Float3Attribute(modeling.PositionAttribute).At(1))
+
+	assert.InDelta(t, 0., model.TRS.Position().Distance(vector3.New(10., 2., 0.)), 1e-9)
+	assert.InDelta(t, 0., model.TRS.Scale().Distance(vector3.New(2., 2., 2.)), 1e-9)
+}
+
+func TestReadScene_HiddenAndMirroredParents(t *testing.T) {
+	// ARRANGE ================================================================
+	// The same triangle instanced under a parent scaled down to nothing and
+	// under a parent mirrored across X
+	doc := `{
+		"asset": {"version": "2.0"},
+		"scene": 0,
+		"scenes": [{"nodes": [0, 2]}],
+		"nodes": [
+			{"name": "hidden", "children": [1], "scale": [0, 0, 0]},
+			{"name": "hiddenChild", "mesh": 0, "translation": [1, 0, 0]},
+			{"name": "mirror", "children": [3], "scale": [-1, 1, 1]},
+			{"name": "mirroredChild", "mesh": 0, "translation": [1, 0, 0], "rotation": [0, 0.3826834, 0, 0.9238795]}
+		],
+		"meshes": [{"name": "tri", "primitives": [{"attributes": {"POSITION": 0}}]}],
+		"accessors": [{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}],
+		"bufferViews": [{"buffer": 0, "byteLength": 36}],
+		"buffers": [{"byteLength": 36, "uri": "data:application/octet-stream;base64,AAAAAAAAAAAAAAAAAAAAAAAAgD8AAAAAAACAPwAAAAAAAAAA"}]
+	}`
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(strings.NewReader(doc), nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 2)
+
+	hidden := scene.Models[0]
+	assert.Equal(t, "hiddenChild", hidden.Name)
+	assert.Equal(t, vector3.Zero[float64](), hidden.TRS.Scale())
+	assert.Equal(t, vector3.Zero[float64](), hidden.TRS.Transform(vector3.New(0., 1., 0.)))
+
+	mirrored := scene.Models[1]
+	assert.Equal(t, "mirroredChild", mirrored.Name)
+	local := trs.New(
+		vector3.New(1., 0., 0.),
+		quaternion.New(vector3.New(0., 0.3826834, 0.), 0.9238795),
+		vector3.One[float64](),
+	)
+	mirror := trs.Scale(vector3.New(-1., 1., 1.))
+	for _, p := range []vector3.Float64{vector3.New(1., 0., 0.), vector3.New(0., 1., 0.), vector3.New(0., 0., 1.)} {
+		expected := mirror.Transform(local.Transform(p))
+		assert.InDelta(t, 0., mirrored.TRS.Transform(p).Distance(expected), 1e-6)
+	}
+}
+
+func TestRead_MissingExternalBuffer(t *testing.T) {
+	// ARRANGE ================================================================
+	doc := `{
+		"asset": {"version": "2.0"},
+		"buffers": [{"byteLength": 36, "uri": "data.bin"}]
+	}`
+
+	// ACT ====================================================================
+	_, _, err := gltf.Read(strings.NewReader(doc), nil)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, gltf.ErrUnresolvableURI)
+}
+
+func TestReadScene_SkinAndAnimation(t *testing.T) {
+	// ARRANGE ================================================================
+	skeleton := animation.NewSkeleton(animation.NewJoint(
+		"Hip",
+		1,
+		vector3.New(0., 1., 0.),
+		vector3.Up[float64](),
+		vector3.Forward[float64](),
+		animation.NewJoint(
+			"Head",
+			1,
+			vector3.New(0., 2., 0.),
+			vector3.Up[float64](),
+			vector3.Forward[float64](),
+		),
+		animation.NewJoint(
+			"Tail",
+			1,
+			vector3.New(0., 1., -1.),
+			vector3.Up[float64](),
+			vector3.Forward[float64](),
+			animation.NewJoint(
+				"Tip",
+				1,
+				vector3.New(0., 1., -2.),
+				vector3.Up[float64](),
+				vector3.Forward[float64](),
+			),
+		),
+	))
+
+	tri := texturedTri().
+		SetInt4Attribute(modeling.JointAttribute, []vector4.Int{
+			vector4.New(0, 1, 0, 0),
+			vector4.New(2, 3, 0, 0),
+			vector4.New(3, 0, 0, 0),
+		}).
+		SetFloat4Attribute(modeling.WeightAttribute, []vector4.Float64{
+			vector4.New(0.5, 0.5, 0., 0.),
+			vector4.New(0.25, 0.75, 0., 0.),
+			vector4.New(1., 0., 0., 0.),
+		})
+
+	wag := animation.NewSequence("Hip/Tail/Tip", []animation.Frame[vector3.Float64]{
+		animation.NewFrame(0., vector3.New(0., 0., -1.)),
+		animation.NewFrame(0.5, vector3.New(0.5, 0., -1.)),
+		animation.NewFrame(1., vector3.New(0., 0., -1.)),
+	})
+
+	up := vector3.Up[float64]()
+	nod := animation.NewRotationSequence("Hip/Head", animation.StepInterpolation, []animation.Frame[quaternion.Quaternion]{
+		animation.NewFrame(0., quaternion.FromTheta(0, up)),
+		animation.NewFrame(1., quaternion.FromTheta(0.5, up)),
+	})
+
+	tangent := vector3.New(0.5, 0., 0.)
+	grow := animation.NewScaleSequence("Hip/Tail", animation.CubicSplineInterpolation, []animation.Frame[vector3.Float64]{
+		animation.NewCubicSplineFrame(0., tangent, vector3.New(1., 1., 1.), tangent),
+		animation.NewCubicSplineFrame(2., tangent, vector3.New(2., 1., 1.), tangent),
+	})
+
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteBinary(gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{
+				Name:       "Rigged",
+				Mesh:       &tri,
+				Skeleton:   &skeleton,
+				Animations: []animation.Sequence{wag, nod, grow},
+			},
+		},
+	}, &buf))
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+
+	require.NotNil(t, model.Skeleton)
+	assert.Equal(t, skeleton.JointCount(), model.Skeleton.JointCount())
+	for i := 0; i < skeleton.JointCount(); i++ {
+		index := model.Skeleton.Lookup(skeleton.Path(i))
+		assert.InDelta(t, 0., skeleton.WorldPosition(i).Distance(model.Skeleton.WorldPosition(index)), 1e-6)
+	}
+	assert.Equal(t, "Tip", model.Skeleton.Name(model.Skeleton.Lookup("Hip/Tail/Tip")))
+
+	joints := model.Mesh.Int4Attribute(modeling.JointAttribute)
+	assert.Equal(t, vector4.New(2, 3, 0, 0), joints.At(1))
+	assert.Equal(t, vector4.New(0.25, 0.75, 0., 0.), model.Mesh.Float4Attribute(modeling.WeightAttribute).At(1))
+
+	require.Len(t, model.Animations, 3)
+	assert.Equal(t, "Hip/Tail/Tip", model.Animations[0].Joint())
+	assert.Equal(t, animation.TranslationChannel, model.Animations[0].Channel())
+	assert.Equal(t, animation.LinearInterpolation, model.Animations[0].Interpolation())
+	require.Len(t, model.Animations[0].Frames(), 3)
+	assert.Equal(t, 0.5, model.Animations[0].Frames()[1].Time())
+	assert.Equal(t, vector3.New(0.5, 0., -1.), model.Animations[0].Frames()[1].Val())
+
+	rotation := model.Animations[1]
+	assert.Equal(t, "Hip/Head", rotation.Joint())
+	assert.Equal(t, animation.RotationChannel, rotation.Channel())
+	assert.Equal(t, animation.StepInterpolation, rotation.Interpolation())
+	require.Len(t, rotation.RotationFrames(), 2)
+	assert.InDelta(t, 1., rotation.SampleRotation(0.9).Dot(quaternion.Identity()), 1e-6)
+	assert.InDelta(t, 1., rotation.SampleRotation(1).Dot(quaternion.FromTheta(0.5, up)), 1e-6)
+
+	scale := model.Animations[2]
+	assert.Equal(t, "Hip/Tail", scale.Joint())
+	assert.Equal(t, animation.ScaleChannel, scale.Channel())
+	assert.Equal(t, animation.CubicSplineInterpolation, scale.Interpolation())
+	require.Len(t, scale.Frames(), 2)
+	assert.Equal(t, tangent, scale.Frames()[1].InTangent())
+	assert.InDelta(t, 0., grow.SampleVector3(1).Distance(scale.SampleVector3(1)), 1e-6)
+}
+
+func TestReadScene_SkinDefaults(t *testing.T) {
+	// ARRANGE ================================================================
+	// A skin without inverse bind matrices, whose joints and mesh node are
+	// all translated away from the origin
+	doc := `{
+		"asset": {"version": "2.0"},
+		"scene": 0,
+		"scenes": [{"nodes": [0, 1]}],
+		"nodes": [
+			{"name": "Rigged", "mesh": 0, "skin": 0, "translation": [5, 0, 0]},
+			{"name": "Hip", "children": [2], "translation": [0, 1, 0]},
+			{"name": "Head", "translation": [0, 1, 0]}
+		],
+		"skins": [{"joints": [1, 2]}],
+		"meshes": [{"name": "tri", "primitives": [{"attributes": {"POSITION": 0}}]}],
+		"accessors": [{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3"}],
+		"bufferViews": [{"buffer": 0, "byteLength": 36}],
+		"buffers": [{"byteLength": 36, "uri": "data:application/octet-stream;base64,AAAAAAAAAAAAAAAAAAAAAAAAgD8AAAAAAACAPwAAAAAAAAAA"}]
+	}`
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(strings.NewReader(doc), nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+
+	assert.Equal(t, trs.Identity(), *model.TRS)
+
+	require.NotNil(t, model.Skeleton)
+	require.Equal(t, 2, model.Skeleton.JointCount())
+	for i := 0; i < model.Skeleton.JointCount(); i++ {
+		assert.Equal(t, vector3.Zero[float64](), model.Skeleton.WorldPosition(i))
+	}
+}
+
+func TestReadScene_MorphTargets(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := texturedTri()
+	up := []vector3.Float64{
+		vector3.New(0., 1., 0.),
+		vector3.New(0., 1., 0.),
+		vector3.New(0., 1., 0.),
+	}
+	tilt := []vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(0., 0., 1.),
+		vector3.New(0., 0., 0.),
+	}
+
+	blink := animation.NewWeightsSequence(animation.LinearInterpolation, []animation.Frame[[]float64]{
+		animation.NewFrame(0., []float64{0, 0}),
+		animation.NewFrame(0.5, []float64{1, 0.25}),
+		animation.NewFrame(1., []float64{0, 0}),
+	})
+
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{
+				Name: "Face",
+				Mesh: &tri,
+				MorphTargets: []gltf.PolyformMorphTarget{
+					{Name: "Raise", Weight: 0.5, Positions: up},
+					{Name: "Tilt", Positions: tilt, Normals: tilt},
+				},
+				Animations: []animation.Sequence{blink},
+			},
+		},
+	}, &buf))
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, scene.Models, 1)
+	model := scene.Models[0]
+
+	require.Len(t, model.MorphTargets, 2)
+	assert.Equal(t, "Raise", model.MorphTargets[0].Name)
+	assert.Equal(t, 0.5, model.MorphTargets[0].Weight)
+	assert.Equal(t, up, model.MorphTargets[0].Positions)
+	assert.Nil(t, model.MorphTargets[0].Normals)
+
+	assert.Equal(t, "Tilt", model.MorphTargets[1].Name)
+	assert.Equal(t, 0., model.MorphTargets[1].Weight)
+	assert.Equal(t, tilt, model.MorphTargets[1].Positions)
+	assert.Equal(t, tilt, model.MorphTargets[1].Normals)
+
+	require.Len(t, model.Animations, 1)
+	weights := model.Animations[0]
+	assert.Equal(t, animation.WeightsChannel, weights.Channel())
+	require.Len(t, weights.WeightFrames(), 3)
+	assert.Equal(t, []float64{1, 0.25}, weights.WeightFrames()[1].Val())
+	assert.InDeltaSlice(t, []float64{0.5, 0.125}, weights.SampleWeights(0.25), 1e-6)
+}
+
+func TestWrite_MorphTargetsRequireMatchingVertexCount(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := texturedTri()
+
+	// ACT ====================================================================
+	err := gltf.WriteText(gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{
+				Name: "Face",
+				Mesh: &tri,
+				MorphTargets: []gltf.PolyformMorphTarget{
+					{Name: "Broken", Positions: []vector3.Float64{vector3.New(0., 1., 0.)}},
+				},
+			},
+		},
+	}, &bytes.Buffer{})
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, gltf.ErrInvalidInput)
+}
+
+func TestReadScene_PrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := texturedTri()
+	mesh := tri.SetMaterial("red").Append(tri.SetMaterial("blue")).Append(tri)
+
+	red := &gltf.PolyformMaterial{
+		Name:                 "red",
+		PbrMetallicRoughness: &gltf.PolyformPbrMetallicRoughness{BaseColorFactor: color.RGBA{R: 255, A: 255}},
+	}
+	fallback := &gltf.PolyformMaterial{Name: "fallback"}
+
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(gltf.PolyformScene{
+		Models: []gltf.PolyformModel{
+			{
+				Name:      "Grouped",
+				Mesh:      &mesh,
+				Material:  fallback,
+				Materials: map[string]*gltf.PolyformMaterial{"red": red},
+			},
+		},
+	}, &buf))
+
+	doc := buf.String()
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(doc, `"POSITION": 1`), "primitives should share vertex data")
+	require.Len(t, scene.Models, 3)
+
+	assert.Equal(t, 1, scene.Models[0].Mesh.PrimitiveCount())
+	assert.Equal(t, "red", scene.Models[0].Material.Name)
+
+	assert.Equal(t, 1, scene.Models[1].Mesh.PrimitiveCount())
+	assert.Equal(t, "fallback", scene.Models[1].Material.Name)
+
+	assert.Equal(t, 1, scene.Models[2].Mesh.PrimitiveCount())
+	assert.Equal(t, "fallback", scene.Models[2].Material.Name)
+}
+
+func TestReadScene_IntAttributes(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := texturedTri().
+		SetInt1Attribute("_ID", []int{1, 60_000, 3}).
+		SetInt2Attribute("_CELL", []vector2.Int{
+			vector2.New(-1, 2),
+			vector2.New(3, -4),
+			vector2.New(5, 6),
+		})
+
+	buf := bytes.Buffer{}
+	require.NoError(t, gltf.WriteText(gltf.PolyformScene{
+		Models: []gltf.PolyformModel{{Name: "Ids", Mesh: &tri}},
+	}, &buf))
+
+	doc := &gltf.Gltf{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), doc))
+
+	// ACT ====================================================================
+	scene, err := gltf.ReadScene(&buf, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	primitive := doc.Meshes[0].Primitives[0]
+	assert.Equal(t, gltf.AccessorComponentType_UNSIGNED_SHORT, doc.Accessors[primitive.Attributes["_ID"]].ComponentType)
+	assert.Equal(t, gltf.AccessorComponentType_BYTE, doc.Accessors[primitive.Attributes["_CELL"]].ComponentType)
+
+	require.Len(t, scene.Models, 1)
+	mesh := scene.Models[0].Mesh
+	assert.Equal(t, 60_000, mesh.Int1Attribute("_ID").At(1))
+	assert.Equal(t, vector2.New(3, -4), mesh.Int2Attribute("_CELL").At(1))
+	assert.False(t, mesh.HasFloat2Attribute("_CELL"))
 }
@@ -68,8 +68,9 @@ func flattenSkeletonToNodes(offset int, skeleton animation.Skeleton, out *bytes.
 	nodes := make([]Node, 0)
 
 	for i := 0; i < skeleton.JointCount(); i++ {
-		children := skeleton.Children(i)
-		for i, c := range children {
+		skeletonChildren := skeleton.Children(i)
+		children := make([]int, len(skeletonChildren))
+		for i, c := range skeletonChildren {
 			children[i] = c + offset
 		}
 
@@ -101,6 +102,7 @@ func flattenSkeletonToNodes(offset int, skeleton animation.Skeleton, out *bytes.
 			// 	relativeMatrix.X33,
 			// },
 			Children: children,
+			Name:     skeleton.Name(i),
 		}
 
 		// mat := skeleton.InverseBindMatrix(i)
@@ -305,6 +305,106 @@ func (w *Writer) WriteVector2(accessorComponentType AccessorComponentType, data
 	w.bytesWritten += datasize
 }
 
+// intComponentType picks the smallest accessor component type able to hold
+// every value within the range provided. glTF reserves 32 bit integers for
+// indices, so anything that fits nowhere else falls back to floats, which
+// stay exact up to 2^24.
+func intComponentType(min, max int) AccessorComponentType {
+	if min >= 0 {
+		switch {
+		case max <= math.MaxUint8:
+			return AccessorComponentType_UNSIGNED_BYTE
+
+		case max <= math.MaxUint16:
+			return AccessorComponentType_UNSIGNED_SHORT
+		}
+	}
+
+	switch {
+	case min >= math.MinInt8 && max <= math.MaxInt8:
+		return AccessorComponentType_BYTE
+
+	case min >= math.MinInt16 && max <= math.MaxInt16:
+		return AccessorComponentType_SHORT
+	}
+
+	return AccessorComponentType_FLOAT
+}
+
+// WriteInts writes a vertex attribute accessor out of integer data, where
+// data contains count elements of the accessor type's components laid out
+// one after the other. The smallest component type able to hold the data is
+// used, with elements padded out to the 4 byte alignment vertex attributes
+// require.
+func (w *Writer) WriteInts(accessorType AccessorType, data []int) {
+	components := accessorType.componentCount()
+	count := len(data) / components
+
+	minArr := make([]float64, components)
+	maxArr := make([]float64, components)
+	lowest, highest := 0, 0
+	for i, v := range data {
+		c := i % components
+		if i < components || float64(v) < minArr[c] {
+			minArr[c] = float64(v)
+		}
+		if i < components || float64(v) > maxArr[c] {
+			maxArr[c] = float64(v)
+		}
+		lowest = min(lowest, v)
+		highest = max(highest, v)
+	}
+
+	componentType := intComponentType(lowest, highest)
+	elementSize := components * componentType.Size()
+	stride := elementSize
+	if stride%4 != 0 {
+		stride += 4 - (stride % 4)
+	}
+
+	w.Align(4)
+	for i := range count {
+		for c := range components {
+			v := data[(i*components)+c]
+			switch componentType {
+			case AccessorComponentType_UNSIGNED_BYTE, AccessorComponentType_BYTE:
+				w.bitW.Byte(uint8(v))
+
+			case AccessorComponentType_UNSIGNED_SHORT, AccessorComponentType_SHORT:
+				w.bitW.UInt16(uint16(v))
+
+			default:
+				w.bitW.Float32(float32(v))
+			}
+		}
+		for range stride - elementSize {
+			w.bitW.Byte(0)
+		}
+	}
+
+	w.accessors = append(w.accessors, Accessor{
+		BufferView:    ptrI(len(w.bufferViews)),
+		ComponentType: componentType,
+		Type:          accessorType,
+		Count:         count,
+		Min:           minArr,
+		Max:           maxArr,
+	})
+
+	bufferView := BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: count * stride,
+		Target:     ARRAY_BUFFER,
+	}
+	if stride != elementSize {
+		bufferView.ByteStride = ptrI(stride)
+	}
+	w.bufferViews = append(w.bufferViews, bufferView)
+
+	w.bytesWritten += count * stride
+}
+
 func (w *Writer) WriteIndices(indices *iter.ArrayIterator[int], attributeSize int) {
 	indiceSize := indices.Len()
 
@@ -442,6 +542,40 @@ func (w *Writer) AddScene(scene PolyformScene) error {
 	return nil
 }
 
+// addAnimatedNode binds the group's skeleton to the node, and writes out all
+// of the group's animations
+func (w *Writer) addAnimatedNode(group modelInstanceGroup, nodeIndex int) error {
+	skinNode := nodeIndex
+	if group.skeleton != nil {
+		var skinIndex *int
+		skinIndex, skinNode = w.AddSkin(*group.skeleton)
+		w.nodes[nodeIndex].Skin = skinIndex
+	}
+
+	jointAnimations := make([]animation.Sequence, 0, len(group.animations))
+	morphAnimations := make([]animation.Sequence, 0)
+	for _, sequence := range group.animations {
+		if sequence.Channel() == animation.WeightsChannel {
+			morphAnimations = append(morphAnimations, sequence)
+		} else {
+			jointAnimations = append(jointAnimations, sequence)
+		}
+	}
+
+	if len(jointAnimations) > 0 {
+		if group.skeleton == nil {
+			return fmt.Errorf("%w: model %q animates joints but has no skeleton", ErrInvalidInput, group.instances[0].name)
+		}
+		w.AddAnimations(jointAnimations, *group.skeleton, skinNode)
+	}
+
+	if len(morphAnimations) > 0 {
+		w.AddMorphTargetAnimations(morphAnimations, nodeIndex)
+	}
+
+	return nil
+}
+
 // serializeInstances processes the instance groups and serializes them as GLTF nodes
 // Returns an error if animation models with multiple instances are found when GPU instancing is disabled
 func (w *Writer) serializeInstances(group modelInstanceGroup, useGpuInstancing bool) error {
@@ -494,16 +628,7 @@ func (w *Writer) serializeInstances(group modelInstanceGroup, useGpuInstancing b
 		w.scene = append(w.scene, nodeIndex)
 
 		if group.isAnimated() {
-			skinNode := nodeIndex
-			if group.skeleton != nil {
-				var skinIndex *int
-				skinIndex, skinNode = w.AddSkin(*group.skeleton)
-				w.nodes[nodeIndex].Skin = skinIndex
-			}
-
-			if len(group.animations) > 0 {
-				w.AddAnimations(group.animations, *group.skeleton, skinNode)
-			}
+			return w.addAnimatedNode(group, nodeIndex)
 		}
 
 		return nil
@@ -544,15 +669,8 @@ func (w *Writer) serializeInstances(group modelInstanceGroup, useGpuInstancing b
 
 		// If this triggers - there always only one instance
 		if group.isAnimated() {
-			skinNode := nodeIndex
-			if group.skeleton != nil {
-				var skinIndex *int
-				skinIndex, skinNode = w.AddSkin(*group.skeleton)
-				w.nodes[nodeIndex].Skin = skinIndex
-			}
-
-			if len(group.animations) > 0 {
-				w.AddAnimations(group.animations, *group.skeleton, skinNode)
+			if err := w.addAnimatedNode(group, nodeIndex); err != nil {
+				return err
 			}
 		}
 	}
@@ -579,30 +697,71 @@ func (w *Writer) AddModel(model PolyformModel) (_ int, err error) {
 		}
 	}
 
-	uniqueMesh := meshEntry{model.Mesh, -1}
+	// Meshes with primitive groups are written as a primitive per group, each
+	// with the material assigned to the group's slot
+	var groups []modeling.PrimitiveGroup
+	var groupMatIndices []*int
+	if groupedTopology(model.Mesh.Topology()) && model.Mesh.HasPrimitiveGroups() {
+		groups = model.Mesh.PrimitiveGroups()
+		groupMatIndices = make([]*int, len(groups))
+		for i, group := range groups {
+			groupMatIndices[i] = matIndex
+
+			mat := model.Materials[group.Material]
+			if mat == nil {
+				continue
+			}
+
+			groupMatIndices[i], err = w.AddMaterial(mat)
+			if err != nil {
+				return -1, fmt.Errorf("failed to add material %q for slot %q from model %q: %w",
+					mat.Name, group.Material, model.Name, err)
+			}
+		}
+	}
+
+	// Morph targets are stored on the glTF mesh, so meshes with them can't be
+	// shared with other models
+	morphed := len(model.MorphTargets) > 0
+	if morphed {
+		if err := validateMorphTargets(model); err != nil {
+			return -1, err
+		}
+	}
+
+	uniqueMesh := meshEntry{polyMesh: model.Mesh, materialIndex: -1}
 	if matIndex != nil {
 		uniqueMesh.materialIndex = *matIndex
 	}
+	if groups != nil {
+		uniqueMesh.groupMaterials = groupMaterialsKey(groupMatIndices)
+	}
 
 	// Check if mesh already exists
-	if existingIndex, exists := w.meshIndices[uniqueMesh]; exists {
+	if existingIndex, exists := w.meshIndices[uniqueMesh]; exists && !morphed {
 		return existingIndex, nil
 	}
 
 	// Create new mesh
 	meshIndex := len(w.meshes)
-	w.meshIndices[uniqueMesh] = meshIndex
+	if !morphed {
+		w.meshIndices[uniqueMesh] = meshIndex
+	}
 
 	// Create the mesh - process geometry, materials etc
 
 	var primitiveAttributes map[string]int
 	var indicesIndex int
+	var groupIndices []int
 
 	writtenData, alreadyWrittenMesh := w.writtenMeshData[model.Mesh]
 
 	if alreadyWrittenMesh {
 		primitiveAttributes = writtenData.attribute
-		indicesIndex = *writtenData.indices
+		groupIndices = writtenData.groups
+		if writtenData.indices != nil {
+			indicesIndex = *writtenData.indices
+		}
 	} else {
 		primitiveAttributes = make(map[string]int)
 		for _, val := range model.Mesh.Float4Attributes() {
@@ -620,13 +779,52 @@ func (w *Writer) AddModel(model PolyformModel) (_ int, err error) {
 			w.WriteVector2(attributeType(val), model.Mesh.Float2Attribute(val))
 		}
 
-		indicesIndex = len(w.accessors)
-		w.WriteIndices(model.Mesh.Indices(), model.Mesh.AttributeLength())
+		for _, val := range model.Mesh.Int4Attributes() {
+			data := make([]int, 0, model.Mesh.AttributeLength()*4)
+			model.Mesh.ScanInt4Attribute(val, func(i int, v vector4.Int) {
+				data = append(data, v.X(), v.Y(), v.Z(), v.W())
+			})
+			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
+			w.WriteInts(AccessorType_VEC4, data)
+		}
+
+		for _, val := range model.Mesh.Int3Attributes() {
+			data := make([]int, 0, model.Mesh.AttributeLength()*3)
+			model.Mesh.ScanInt3Attribute(val, func(i int, v vector3.Int) {
+				data = append(data, v.X(), v.Y(), v.Z())
+			})
+			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
+			w.WriteInts(AccessorType_VEC3, data)
+		}
 
-		w.writtenMeshData[model.Mesh] = writtenMeshData{
-			attribute: primitiveAttributes,
-			indices:   &indicesIndex,
+		for _, val := range model.Mesh.Int2Attributes() {
+			data := make([]int, 0, model.Mesh.AttributeLength()*2)
+			model.Mesh.ScanInt2Attribute(val, func(i int, v vector2.Int) {
+				data = append(data, v.X(), v.Y())
+			})
+			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
+			w.WriteInts(AccessorType_VEC2, data)
+		}
+
+		for _, val := range model.Mesh.Int1Attributes() {
+			primitiveAttributes[polyformToGLTFAttribute(val)] = len(w.accessors)
+			w.WriteInts(AccessorType_SCALAR, iter.ReadFull(model.Mesh.Int1Attribute(val)))
 		}
+
+		written := writtenMeshData{attribute: primitiveAttributes}
+		if groups != nil {
+			groupIndices = make([]int, len(groups))
+			for i, group := range groups {
+				groupIndices[i] = len(w.accessors)
+				w.WriteIndices(iter.Array(primitiveGroupIndices(*model.Mesh, group)), model.Mesh.AttributeLength())
+			}
+			written.groups = groupIndices
+		} else {
+			indicesIndex = len(w.accessors)
+			w.WriteIndices(model.Mesh.Indices(), model.Mesh.AttributeLength())
+			written.indices = &indicesIndex
+		}
+		w.writtenMeshData[model.Mesh] = written
 	}
 
 	var mode *PrimitiveMode = nil
@@ -635,7 +833,7 @@ func (w *Writer) AddModel(model PolyformModel) (_ int, err error) {
 		mode = &p
 	}
 
-	w.meshes = append(w.meshes, Mesh{
+	mesh := Mesh{
 		ChildOfRootProperty: ChildOfRootProperty{Name: model.Name},
 		Primitives: []Primitive{
 			{
@@ -645,11 +843,96 @@ func (w *Writer) AddModel(model PolyformModel) (_ int, err error) {
 				Mode:       mode,
 			},
 		},
-	})
+	}
+
+	if groups != nil {
+		mesh.Primitives = make([]Primitive, len(groups))
+		for i := range groups {
+			mesh.Primitives[i] = Primitive{
+				Indices:    &groupIndices[i],
+				Attributes: primitiveAttributes,
+				Material:   groupMatIndices[i],
+				Mode:       mode,
+			}
+		}
+	}
+
+	if morphed {
+		names := make([]string, len(model.MorphTargets))
+		mesh.Weights = make([]float64, len(model.MorphTargets))
+		targets := make([]map[string]GltfId, len(model.MorphTargets))
+		for i, target := range model.MorphTargets {
+			names[i] = target.Name
+			mesh.Weights[i] = target.Weight
+			targets[i] = w.writeMorphTarget(target)
+		}
+
+		// Every primitive shares the same vertices, and so the same targets
+		for i := range mesh.Primitives {
+			mesh.Primitives[i].Targets = targets
+		}
+
+		// Not part of the spec, but the convention most tooling follows for
+		// naming morph targets
+		mesh.Extras = Extra{"targetNames": names}
+	}
+
+	w.meshes = append(w.meshes, mesh)
 
 	return meshIndex, nil
 }
 
+// groupedTopology reports whether meshes of the topology can be split into a
+// GLTF primitive per primitive group
+func groupedTopology(topology modeling.Topology) bool {
+	return topology == modeling.TriangleTopology || topology == modeling.PointTopology
+}
+
+// primitiveGroupIndices gathers the indices of every primitive in the group
+func primitiveGroupIndices(m modeling.Mesh, group modeling.PrimitiveGroup) []int {
+	size := m.Topology().IndexSize()
+	indices := m.Indices()
+	out := make([]int, 0, len(group.Primitives)*size)
+	for _, p := range group.Primitives {
+		for i := range size {
+			out = append(out, indices.At(p*size+i))
+		}
+	}
+	return out
+}
+
+func validateMorphTargets(model PolyformModel) error {
+	vertexCount := model.Mesh.AttributeLength()
+	for _, target := range model.MorphTargets {
+		if len(target.Positions) > 0 && len(target.Positions) != vertexCount {
+			return fmt.Errorf("%w: morph target %q in model %q has %d position displacements, but the mesh has %d vertices",
+				ErrInvalidInput, target.Name, model.Name, len(target.Positions), vertexCount)
+		}
+
+		if len(target.Normals) > 0 && len(target.Normals) != vertexCount {
+			return fmt.Errorf("%w: morph target %q in model %q has %d normal displacements, but the mesh has %d vertices",
+				ErrInvalidInput, target.Name, model.Name, len(target.Normals), vertexCount)
+		}
+	}
+	return nil
+}
+
+func (w *Writer) writeMorphTarget(target PolyformMorphTarget) map[string]GltfId {
+	attributes := make(map[string]GltfId)
+
+	if len(target.Positions) > 0 {
+		attributes[POSITION] = len(w.accessors)
+		w.WriteVector3(AccessorComponentType_FLOAT, iter.Array(target.Positions))
+	}
+
+	if len(target.Normals) > 0 {
+		attributes[NORMAL] = len(w.accessors)
+		w.WriteVector3(AccessorComponentType_FLOAT, iter.Array(target.Normals))
+	}
+
+	return attributes
+}
+
 func (w *Writer) AddTexture(polyTex *PolyformTexture) *TextureInfo {
 	texExt, texInfoExt := polyTex.prepareExtensions(w)
 
@@ -836,13 +1119,14 @@ func (w *Writer) AddMaterial(mat *PolyformMaterial) (*int, error) {
 }
 
 func (w *Writer) AddSkin(skeleton animation.Skeleton) (*int, int) {
-	skeletonNodes := flattenSkeletonToNodes(1, skeleton, w.buf)
-	w.scene = append(w.scene, len(w.nodes))
+	skeletonRoot := len(w.nodes)
+	skeletonNodes := flattenSkeletonToNodes(skeletonRoot, skeleton, w.buf)
+	w.scene = append(w.scene, skeletonRoot)
 	w.nodes = append(w.nodes, skeletonNodes...)
 
 	jointIndices := make([]int, len(skeletonNodes))
 	for i := 0; i < len(skeletonNodes); i++ {
-		jointIndices[i] = i + 1 // +1 because we're offsetting from mesh node
+		jointIndices[i] = i + skeletonRoot
 	}
 
 	w.accessors = append(w.accessors, Accessor{
@@ -864,106 +1148,211 @@ func (w *Writer) AddSkin(skeleton animation.Skeleton) (*int, int) {
 	})
 	w.bytesWritten += inverseBindMAtrixLen
 
-	w.skins = []Skin{
-		{
-			Joints:              jointIndices,
-			InverseBindMatrices: len(w.accessors) - 1,
-		},
+	w.skins = append(w.skins, Skin{
+		Joints:              jointIndices,
+		InverseBindMatrices: ptrI(len(w.accessors) - 1),
+	})
+	return ptrI(len(w.skins) - 1), skeletonRoot
+}
+
+func animationInterpolation(interpolation animation.Interpolation) AnimationSamplerInterpolation {
+	switch interpolation {
+	case animation.StepInterpolation:
+		return AnimationSamplerInterpolation_STEP
+
+	case animation.CubicSplineInterpolation:
+		return AnimationSamplerInterpolation_CUBICSPLINE
 	}
-	return ptrI(len(w.skins) - 1), w.scene[len(w.scene)-1]
+	return AnimationSamplerInterpolation_LINEAR
 }
 
-func (w *Writer) AddAnimations(animations []animation.Sequence, skeleton animation.Skeleton, skeletonNode int) {
-	for i, animation := range animations {
+func animationChannelPath(channel animation.Channel) AnimationChannelTargetPath {
+	switch channel {
+	case animation.RotationChannel:
+		return AnimationChannelTargetPath_ROTATION
 
-		min := vector3.New(math.MaxFloat64, math.MaxFloat64, math.MaxFloat64)
-		max := vector3.New(-math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64)
+	case animation.ScaleChannel:
+		return AnimationChannelTargetPath_SCALE
 
-		for _, frame := range animation.Frames() {
-			v := frame.Val()
-			min = vector3.Min(min, v)
-			max = vector3.Max(max, v)
-			w.WriteVector3AsFloat32(v)
+	case animation.WeightsChannel:
+		return AnimationChannelTargetPath_WEIGHTS
+	}
+	return AnimationChannelTargetPath_TRANSLATION
+}
+
+// sequenceOutput flattens the keyframe values of the sequence, interleaving
+// the in and out tangents for cubic splines as glTF expects
+func sequenceOutput(sequence animation.Sequence) ([][]float64, AccessorType) {
+	cubic := sequence.Interpolation() == animation.CubicSplineInterpolation
+	values := make([][]float64, 0, sequence.Len())
+
+	// Weights are written as scalars, with all weights of a keyframe stored
+	// back to back
+	if sequence.Channel() == animation.WeightsChannel {
+		appendScalars := func(weights []float64) {
+			for _, weight := range weights {
+				values = append(values, []float64{weight})
+			}
 		}
 
-		datasize := len(animation.Frames()) * 3 * 4
+		for _, frame := range sequence.WeightFrames() {
+			if cubic {
+				appendScalars(frame.InTangent())
+			}
+			appendScalars(frame.Val())
+			if cubic {
+				appendScalars(frame.OutTangent())
+			}
+		}
+		return values, AccessorType_SCALAR
+	}
 
-		animationDataBufferView := BufferView{
-			Buffer:     0,
-			ByteOffset: w.bytesWritten,
-			ByteLength: datasize,
+	if sequence.Channel() == animation.RotationChannel {
+		for _, frame := range sequence.RotationFrames() {
+			if cubic {
+				in := frame.InTangent().ToArr()
+				values = append(values, in[:])
+			}
+			val := frame.Val().ToArr()
+			values = append(values, val[:])
+			if cubic {
+				out := frame.OutTangent().ToArr()
+				values = append(values, out[:])
+			}
 		}
-		animationDataBufferViewIndex := len(w.bufferViews)
+		return values, AccessorType_VEC4
+	}
 
-		animationDataAccessor := Accessor{
-			BufferView:    ptrI(animationDataBufferViewIndex),
-			ComponentType: AccessorComponentType_FLOAT,
-			Type:          AccessorType_VEC3,
-			Count:         len(animation.Frames()),
-			Min:           []float64{min.X(), min.Y(), min.Z()},
-			Max:           []float64{max.X(), max.Y(), max.Z()},
+	for _, frame := range sequence.Frames() {
+		if cubic {
+			in := frame.InTangent().ToFixedArr()
+			values = append(values, in[:])
 		}
-		animationDataAccessorIndex := len(w.accessors)
+		val := frame.Val().ToFixedArr()
+		values = append(values, val[:])
+		if cubic {
+			out := frame.OutTangent().ToFixedArr()
+			values = append(values, out[:])
+		}
+	}
+	return values, AccessorType_VEC3
+}
+
+// AddAnimations writes out sequences animating the joints of the skeleton,
+// whose root joint is stored in the node provided
+func (w *Writer) AddAnimations(animations []animation.Sequence, skeleton animation.Skeleton, skeletonNode int) {
+	for _, sequence := range animations {
+		w.addSequence(sequence, skeleton.Lookup(sequence.Joint())+skeletonNode)
+	}
+}
 
-		w.accessors = append(w.accessors, animationDataAccessor)
-		w.bufferViews = append(w.bufferViews, animationDataBufferView)
+// AddMorphTargetAnimations writes out sequences animating the morph target
+// weights of the mesh instanced by the node provided
+func (w *Writer) AddMorphTargetAnimations(animations []animation.Sequence, node int) {
+	for _, sequence := range animations {
+		w.addSequence(sequence, node)
+	}
+}
 
-		w.bytesWritten += datasize
+func (w *Writer) addSequence(sequence animation.Sequence, node int) {
+	// Keyframe Data ==========================================================
 
-		// Time Data ============================================================
+	values, accessorType := sequenceOutput(sequence)
+	components := accessorType.componentCount()
 
-		minTime := math.MaxFloat64
-		maxTime := -math.MaxFloat64
+	min := make([]float64, components)
+	max := make([]float64, components)
+	for i := range min {
+		min[i] = math.MaxFloat64
+		max[i] = -math.MaxFloat64
+	}
 
-		for _, frame := range animation.Frames() {
-			minTime = math.Min(minTime, frame.Time())
-			maxTime = math.Max(maxTime, frame.Time())
-			w.bitW.Float32(float32(frame.Time()))
+	for _, value := range values {
+		for c, v := range value {
+			min[c] = math.Min(min[c], v)
+			max[c] = math.Max(max[c], v)
+			w.bitW.Float32(float32(v))
 		}
+	}
 
-		datasize = len(animation.Frames()) * 4
+	datasize := len(values) * components * 4
 
-		timeBufferView := BufferView{
-			Buffer:     0,
-			ByteOffset: w.bytesWritten,
-			ByteLength: datasize,
-		}
-		timeBufferViewIndex := len(w.bufferViews)
+	animationDataBufferView := BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: datasize,
+	}
+	animationDataBufferViewIndex := len(w.bufferViews)
 
-		timeAccessor := Accessor{
-			BufferView:    ptrI(timeBufferViewIndex),
-			ComponentType: AccessorComponentType_FLOAT,
-			Type:          AccessorType_SCALAR,
-			Count:         len(animation.Frames()),
-			Min:           []float64{minTime},
-			Max:           []float64{maxTime},
-		}
+	animationDataAccessor := Accessor{
+		BufferView:    ptrI(animationDataBufferViewIndex),
+		ComponentType: AccessorComponentType_FLOAT,
+		Type:          accessorType,
+		Count:         len(values),
+		Min:           min,
+		Max:           max,
+	}
+	animationDataAccessorIndex := len(w.accessors)
 
-		timeAccessorIndex := len(w.accessors)
-		w.accessors = append(w.accessors, timeAccessor)
-		w.bufferViews = append(w.bufferViews, timeBufferView)
+	w.accessors = append(w.accessors, animationDataAccessor)
+	w.bufferViews = append(w.bufferViews, animationDataBufferView)
 
-		w.bytesWritten += datasize
+	w.bytesWritten += datasize
 
-		w.animations = append(w.animations, Animation{
-			Samplers: []AnimationSampler{
-				{
-					Interpolation: AnimationSamplerInterpolation_LINEAR,
-					Input:         timeAccessorIndex,
-					Output:        animationDataAccessorIndex,
-				},
+	// Time Data ==============================================================
+
+	minTime := math.MaxFloat64
+	maxTime := -math.MaxFloat64
+
+	for i := 0; i < sequence.Len(); i++ {
+		time := sequence.Time(i)
+		minTime = math.Min(minTime, time)
+		maxTime = math.Max(maxTime, time)
+		w.bitW.Float32(float32(time))
+	}
+
+	datasize = sequence.Len() * 4
+
+	timeBufferView := BufferView{
+		Buffer:     0,
+		ByteOffset: w.bytesWritten,
+		ByteLength: datasize,
+	}
+	timeBufferViewIndex := len(w.bufferViews)
+
+	timeAccessor := Accessor{
+		BufferView:    ptrI(timeBufferViewIndex),
+		ComponentType: AccessorComponentType_FLOAT,
+		Type:          AccessorType_SCALAR,
+		Count:         sequence.Len(),
+		Min:           []float64{minTime},
+		Max:           []float64{maxTime},
+	}
+
+	timeAccessorIndex := len(w.accessors)
+	w.accessors = append(w.accessors, timeAccessor)
+	w.bufferViews = append(w.bufferViews, timeBufferView)
+
+	w.bytesWritten += datasize
+
+	w.animations = append(w.animations, Animation{
+		Samplers: []AnimationSampler{
+			{
+				Interpolation: animationInterpolation(sequence.Interpolation()),
+				Input:         timeAccessorIndex,
+				Output:        animationDataAccessorIndex,
 			},
-			Channels: []AnimationChannel{
-				{
-					Target: AnimationChannelTarget{
-						Path: AnimationChannelTargetPath_TRANSLATION,
-						Node: skeleton.Lookup(animation.Joint()) + skeletonNode,
-					},
-					Sampler: i,
+		},
+		Channels: []AnimationChannel{
+			{
+				Target: AnimationChannelTarget{
+					Path: animationChannelPath(sequence.Channel()),
+					Node: node,
 				},
+				Sampler: 0,
 			},
-		})
-	}
+		},
+	})
 }
 
 func (w *Writer) AddLight(light KHR_LightsPunctual) {
@@ -165,6 +165,13 @@ func (omr objMeshReading) toEntry() Entry {
 	if len(omr.uvs) > 0 {
 		mesh = mesh.SetFloat2Attribute(modeling.TexCoordAttribute, omr.uvs)
 	}
+
+	// Carry the material over to the mesh itself, so that it survives
+	// flattening the scene into a single mesh
+	if omr.material != nil {
+		mesh = mesh.SetMaterial(omr.material.Name)
+	}
+
 	return Entry{
 		Mesh:     mesh,
 		Material: omr.material,
@@ -265,3 +265,33 @@ f  1/1/1 2/2/2 3/3/3
 		t.Error("mesh materials don't reference same underlying material")
 	}
 }
+
+func Test_ReadOBJ_MaterialsBecomePrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	objString := `
+v 0 0 0
+v 0 1 0
+v 1 1 0
+v 1 0 0
+
+usemtl red
+f 1 2 3
+usemtl blue
+f 1 3 4
+usemtl red
+f 2 3 4
+`
+
+	// ACT ====================================================================
+	scene, _, err := obj.ReadMesh(strings.NewReader(objString))
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	mesh := scene.ToMesh()
+
+	assert.Equal(t, 3, mesh.PrimitiveCount())
+	assert.Equal(t, []modeling.PrimitiveGroup{
+		{Material: "red", Primitives: []int{0, 2}},
+		{Material: "blue", Primitives: []int{1}},
+	}, mesh.PrimitiveGroups())
+}
@@ -107,7 +107,7 @@ type Scene struct {
 func (s Scene) containsMaterials() bool {
 	for _, o := range s.Objects {
 		for _, e := range o.Entries {
-			if e.Material != nil {
+			if e.Material != nil || e.Mesh.HasPrimitiveGroups() {
 				return true
 			}
 		}
@@ -15,9 +15,34 @@ func WriteMaterials(scene Scene, out io.Writer) error {
 
 	defaultWritten := false
 	written := make(map[*Material]struct{})
+	writtenSlots := make(map[string]struct{})
 
 	for _, o := range scene.Objects {
 		for _, e := range o.Entries {
+			if e.Material == nil && e.Mesh.HasPrimitiveGroups() {
+				for _, slot := range e.Mesh.MaterialSlots() {
+					if slot == "" {
+						if !defaultWritten {
+							if err := DefaultMaterial().write(out); err != nil {
+								return fmt.Errorf("failed to write default material: %w", err)
+							}
+							defaultWritten = true
+						}
+						continue
+					}
+
+					if _, ok := writtenSlots[slot]; ok {
+						continue
+					}
+
+					if err := slotMaterial(slot).write(out); err != nil {
+						return fmt.Errorf("failed to write material %q on object %q: %w", slot, o.Name, err)
+					}
+					writtenSlots[slot] = struct{}{}
+				}
+				continue
+			}
+
 			if e.Material == nil {
 				if !defaultWritten {
 					if err := DefaultMaterial().write(out); err != nil {
@@ -57,6 +82,32 @@ func writeUsingMaterial(mat *Material, out *txt.Writer) {
 	}
 }
 
+// slotMaterial is the material written for one of a mesh's material slots
+// when the entry containing it has no material of its own
+func slotMaterial(slot string) *Material {
+	if slot == "" {
+		return nil
+	}
+	mat := DefaultMaterial()
+	mat.Name = slot
+	return &mat
+}
+
+// writePrimitiveGroups writes the faces of each of the mesh's primitive
+// groups, switching to the group's material before each one
+func writePrimitiveGroups(m modeling.Mesh, faceWriter func(tris *iter.ArrayIterator[int], out *txt.Writer, offset int), out *txt.Writer, offset int) {
+	indices := m.Indices()
+	for _, group := range m.PrimitiveGroups() {
+		writeUsingMaterial(slotMaterial(group.Material), out)
+
+		tris := make([]int, 0, len(group.Primitives)*3)
+		for _, p := range group.Primitives {
+			tris = append(tris, indices.At(p*3), indices.At(p*3+1), indices.At(p*3+2))
+		}
+		faceWriter(iter.Array(tris), out, offset)
+	}
+}
+
 func writeFaceVerts(tris *iter.ArrayIterator[int], out *txt.Writer, offset int) {
 	shift := 1 + offset
 	for triIndex := 0; triIndex < tris.Len(); triIndex += 3 {
@@ -230,8 +281,11 @@ func Write(scene Scene, materialFile string, out io.Writer) error {
 				}
 			}
 
-			indices := m.Indices()
-			faceWriter(indices, writer, indexOffset)
+			if entry.Material == nil && m.HasPrimitiveGroups() {
+				writePrimitiveGroups(m, faceWriter, writer, indexOffset)
+			} else {
+				faceWriter(m.Indices(), writer, indexOffset)
+			}
 			if err := writer.Error(); err != nil {
 				return fmt.Errorf("failed to write faces: %w", err)
 			}
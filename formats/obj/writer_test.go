@@ -355,3 +355,48 @@ d 1
 
 `, buf.String())
 }
+
+func TestWriteObjWithPrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	m := modeling.NewTriangleMesh([]int{0, 1, 2, 0, 2, 3, 1, 2, 3}).
+		SetFloat3Data(map[string][]vector3.Float64{
+			modeling.PositionAttribute: {
+				vector3.New(0., 0, 0),
+				vector3.New(0., 1, 0),
+				vector3.New(1., 1, 0),
+				vector3.New(1., 0, 0),
+			},
+		}).
+		SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "red", Primitives: []int{0, 2}},
+			{Material: "blue", Primitives: []int{1}},
+		})
+	buf := bytes.Buffer{}
+	matBuf := bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := obj.WriteMesh(m, "", &buf)
+	matErr := obj.WriteMaterials(obj.Scene{
+		Objects: []obj.Object{{Entries: []obj.Entry{{Mesh: m}}}},
+	}, &matBuf)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.NoError(t, matErr)
+
+	assert.Equal(t,
+		`# Created with github.com/EliCDavis/polyform
+v 0 0 0
+v 0 1 0
+v 1 1 0
+v 1 0 0
+usemtl red
+f 1 2 3
+f 2 3 4
+usemtl blue
+f 1 3 4
+`, buf.String())
+
+	assert.Contains(t, matBuf.String(), "newmtl red\n")
+	assert.Contains(t, matBuf.String(), "newmtl blue\n")
+}
@@ -42,6 +42,15 @@ func (spt ScalarPropertyType) Size() int {
 	}
 }
 
+// Integer reports whether or not the type holds whole numbers
+func (spt ScalarPropertyType) Integer() bool {
+	switch spt {
+	case Char, UChar, Short, UShort, Int, UInt:
+		return true
+	}
+	return false
+}
+
 type ScalarProperty struct {
 	PropertyName string             `json:"name"` // Name of the property
 	Type         ScalarPropertyType `json:"type"` // Property type
@@ -313,6 +313,22 @@ var defaultReader MeshReader = MeshReader{
 	},
 }
 
+// unspecifiedPropertyReader reads properties no other reader claimed, with
+// integer typed properties kept as integer attributes
+func unspecifiedPropertyReader(prop Property) PropertyReader {
+	if scalar, ok := prop.(ScalarProperty); ok && scalar.Type.Integer() {
+		return Int1PropertyReader{
+			ModelAttribute: prop.Name(),
+			PlyProperty:    prop.Name(),
+		}
+	}
+
+	return Vector1PropertyReader{
+		ModelAttribute: prop.Name(),
+		PlyProperty:    prop.Name(),
+	}
+}
+
 func ReadMesh(reader io.Reader) (*modeling.Mesh, error) {
 	return defaultReader.Read(reader)
 }
@@ -429,10 +445,7 @@ func (mr MeshReader) Read(reader io.Reader) (*modeling.Mesh, error) {
 				}
 
 				if !claimed {
-					reader := Vector1PropertyReader{
-						ModelAttribute: prop.Name(),
-						PlyProperty:    prop.Name(),
-					}.buildAscii(*vertexElement)
+					reader := unspecifiedPropertyReader(prop).buildAscii(*vertexElement)
 					builtReaders = append(builtReaders, reader)
 					asciiReaders = append(asciiReaders, reader)
 				}
@@ -495,10 +508,7 @@ func (mr MeshReader) Read(reader io.Reader) (*modeling.Mesh, error) {
 				}
 
 				if !claimed {
-					reader := Vector1PropertyReader{
-						ModelAttribute: prop.Name(),
-						PlyProperty:    prop.Name(),
-					}.buildBinary(*vertexElement, endian)
+					reader := unspecifiedPropertyReader(prop).buildBinary(*vertexElement, endian)
 					builtReaders = append(builtReaders, reader)
 					binReaders = append(binReaders, reader)
 				}
@@ -0,0 +1,139 @@
+package ply
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// Int1PropertyReader reads a single PLY property into an integer attribute,
+// keeping ids and classifications exact rather than converting them to
+// floats
+type Int1PropertyReader struct {
+	ModelAttribute string
+	PlyProperty    string
+}
+
+func (i1pr Int1PropertyReader) buildBinary(element Element, endian binary.ByteOrder) binaryPropertyReader {
+	totalSize := 0
+	for _, prop := range element.Properties {
+		scalar := prop.(ScalarProperty)
+
+		if scalar.PropertyName == i1pr.PlyProperty {
+			return &builtInt1PropertyReader{
+				arr:            make([]int, element.Count),
+				offset:         totalSize,
+				modelAttribute: i1pr.ModelAttribute,
+				scalarType:     scalar.Type,
+				endian:         endian,
+				plyProperty:    i1pr.PlyProperty,
+			}
+		}
+
+		totalSize += scalar.Size()
+	}
+
+	return nil
+}
+
+func (i1pr Int1PropertyReader) buildAscii(element Element) asciiPropertyReader {
+	for i, prop := range element.Properties {
+		if prop.Name() == i1pr.PlyProperty {
+			return &builtAsciiInt1PropertyReader{
+				arr:            make([]int, element.Count),
+				offset:         i,
+				modelAttribute: i1pr.ModelAttribute,
+				plyProperty:    i1pr.PlyProperty,
+			}
+		}
+	}
+
+	return nil
+}
+
+type builtAsciiInt1PropertyReader struct {
+	arr            []int
+	modelAttribute string
+	offset         int
+	plyProperty    string
+}
+
+func (bai1pr builtAsciiInt1PropertyReader) Read(buf []string, i int64) error {
+	v, err := strconv.ParseInt(buf[bai1pr.offset], 10, 64)
+	if err == nil {
+		bai1pr.arr[i] = int(v)
+		return nil
+	}
+
+	// Fall back to truncating properties stored as floats
+	f, err := strconv.ParseFloat(buf[bai1pr.offset], 64)
+	if err != nil {
+		return err
+	}
+	bai1pr.arr[i] = int(f)
+	return nil
+}
+
+func (bai1pr *builtAsciiInt1PropertyReader) UpdateMesh(m modeling.Mesh) modeling.Mesh {
+	return m.SetInt1Attribute(bai1pr.modelAttribute, bai1pr.arr)
+}
+
+func (bai1pr builtAsciiInt1PropertyReader) ClaimsProperty(prop Property) bool {
+	return prop.Name() == bai1pr.plyProperty
+}
+
+type builtInt1PropertyReader struct {
+	arr            []int
+	scalarType     ScalarPropertyType
+	endian         binary.ByteOrder
+	modelAttribute string
+	offset         int
+	plyProperty    string
+}
+
+func (bi1pr *builtInt1PropertyReader) Read(buf []byte, i int64) {
+	data := buf[bi1pr.offset:]
+
+	var v int
+	switch bi1pr.scalarType {
+	case Char:
+		v = int(int8(data[0]))
+
+	case UChar:
+		v = int(data[0])
+
+	case Short:
+		v = int(int16(bi1pr.endian.Uint16(data)))
+
+	case UShort:
+		v = int(bi1pr.endian.Uint16(data))
+
+	case Int:
+		v = int(int32(bi1pr.endian.Uint32(data)))
+
+	case UInt:
+		v = int(bi1pr.endian.Uint32(data))
+
+	case Float:
+		v = int(math.Float32frombits(bi1pr.endian.Uint32(data)))
+
+	case Double:
+		v = int(math.Float64frombits(bi1pr.endian.Uint64(data)))
+
+	default:
+		panic(fmt.Errorf("unimplemented %s", bi1pr.scalarType))
+	}
+
+	bi1pr.arr[i] = v
+}
+
+func (bi1pr *builtInt1PropertyReader) UpdateMesh(m modeling.Mesh) modeling.Mesh {
+	return m.SetInt1Attribute(bi1pr.modelAttribute, bi1pr.arr)
+}
+
+func (bi1pr builtInt1PropertyReader) ClaimsProperty(prop Property) bool {
+	return prop.Name() == bi1pr.plyProperty
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/EliCDavis/vector/vector3"
 	"github.com/EliCDavis/vector/vector4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWriteASCII(t *testing.T) {
@@ -580,3 +581,132 @@ func TestWriteBinary_TriWithUVData(t *testing.T) {
 	assert.Equal(t, float32(5.), tri.Uvs[4])
 	assert.Equal(t, float32(6.), tri.Uvs[5])
 }
+
+func TestWrite_IntAttributesRoundTrip(t *testing.T) {
+	for _, format := range []ply.Format{ply.ASCII, ply.BinaryLittleEndian, ply.BinaryBigEndian} {
+		t.Run(string(format), func(t *testing.T) {
+			// ARRANGE ========================================================
+			writer := ply.MeshWriter{
+				Format: format,
+				Properties: []ply.PropertyWriter{
+					ply.Vector3PropertyWriter{
+						ModelAttribute: modeling.PositionAttribute,
+						Type:           ply.Float,
+						PlyPropertyX:   "x",
+						PlyPropertyY:   "y",
+						PlyPropertyZ:   "z",
+					},
+					ply.Int1PropertyWriter{
+						ModelAttribute: modeling.ClassAttribute,
+						PlyProperty:    "class",
+						Type:           ply.Int,
+					},
+				},
+				WriteUnspecifiedProperties: true,
+			}
+
+			mesh := modeling.NewPointCloud(
+				nil,
+				map[string][]vector3.Float64{
+					modeling.PositionAttribute: {vector3.New(1., 2., 3.), vector3.New(4., 5., 6.)},
+				},
+				nil,
+				nil,
+			).
+				SetInt1Attribute(modeling.ClassAttribute, []int{-3, 2_000_000_000}).
+				SetInt1Attribute("label", []int{7, 255}).
+				SetInt1Attribute("id", []int{70_000, 123_456_789})
+
+			// ACT ============================================================
+			buf := &bytes.Buffer{}
+			err := writer.Write(mesh, "", buf)
+			header := buf.String()
+			meshBack, readErr := ply.ReadMesh(bytes.NewBuffer(buf.Bytes()))
+
+			// ASSERT =========================================================
+			assert.NoError(t, err)
+			assert.NoError(t, readErr)
+			assert.Contains(t, header, "property int class\n")
+			assert.Contains(t, header, "property uchar label\n")
+			assert.Contains(t, header, "property uint id\n")
+
+			assert.True(t, meshBack.HasInt1Attribute("class"))
+			assert.Equal(t, -3, meshBack.Int1Attribute("class").At(0))
+			assert.Equal(t, 2_000_000_000, meshBack.Int1Attribute("class").At(1))
+			assert.Equal(t, 255, meshBack.Int1Attribute("label").At(1))
+			assert.Equal(t, 123_456_789, meshBack.Int1Attribute("id").At(1))
+			assert.False(t, meshBack.HasFloat1Attribute("label"))
+		})
+	}
+}
+
+func TestWrite_IntVectorAttributesRoundTrip(t *testing.T) {
+	for _, format := range []ply.Format{ply.ASCII, ply.BinaryLittleEndian, ply.BinaryBigEndian} {
+		t.Run(string(format), func(t *testing.T) {
+			// ARRANGE ========================================================
+			writer := ply.MeshWriter{
+				Format: format,
+				Properties: []ply.PropertyWriter{
+					ply.Vector3PropertyWriter{
+						ModelAttribute: modeling.PositionAttribute,
+						Type:           ply.Float,
+						PlyPropertyX:   "x",
+						PlyPropertyY:   "y",
+						PlyPropertyZ:   "z",
+					},
+					ply.Int2PropertyWriter{
+						ModelAttribute: "pixel",
+						Type:           ply.Short,
+						PlyPropertyX:   "px",
+						PlyPropertyY:   "py",
+					},
+				},
+				WriteUnspecifiedProperties: true,
+			}
+
+			mesh := modeling.NewPointCloud(
+				nil,
+				map[string][]vector3.Float64{
+					modeling.PositionAttribute: {vector3.New(1., 2., 3.), vector3.New(4., 5., 6.)},
+				},
+				nil,
+				nil,
+			).
+				SetInt4Attribute(modeling.JointAttribute, []vector4.Int{
+					vector4.New(0, 1, 2, 3),
+					vector4.New(4, 5, 6, 300),
+				}).
+				SetInt3Attribute("cell", []vector3.Int{
+					vector3.New(-1, 0, 1),
+					vector3.New(70_000, -70_000, 0),
+				}).
+				SetInt2Attribute("pixel", []vector2.Int{
+					vector2.New(-5, 5),
+					vector2.New(1000, -1000),
+				})
+
+			// ACT ============================================================
+			buf := &bytes.Buffer{}
+			err := writer.Write(mesh, "", buf)
+			header := buf.String()
+			meshBack, readErr := ply.ReadMesh(bytes.NewBuffer(buf.Bytes()))
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			require.NoError(t, readErr)
+			assert.Contains(t, header, "property ushort Joint_0\n")
+			assert.Contains(t, header, "property ushort Joint_3\n")
+			assert.Contains(t, header, "property int cell_2\n")
+			assert.Contains(t, header, "property short px\n")
+
+			assert.Equal(t, 0, meshBack.Int1Attribute("Joint_0").At(0))
+			assert.Equal(t, 3, meshBack.Int1Attribute("Joint_3").At(0))
+			assert.Equal(t, 6, meshBack.Int1Attribute("Joint_2").At(1))
+			assert.Equal(t, 300, meshBack.Int1Attribute("Joint_3").At(1))
+			assert.Equal(t, -1, meshBack.Int1Attribute("cell_0").At(0))
+			assert.Equal(t, -70_000, meshBack.Int1Attribute("cell_1").At(1))
+			assert.Equal(t, -5, meshBack.Int1Attribute("px").At(0))
+			assert.Equal(t, -1000, meshBack.Int1Attribute("py").At(1))
+		})
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 	"github.com/EliCDavis/polyform/formats/txt"
 	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
 )
 
 // ============================================================================
@@ -43,6 +46,10 @@ func (mw MeshWriter) Write(mesh modeling.Mesh, texture string, writer io.Writer)
 	claimedV2 := make(map[string]bool)
 	claimedV3 := make(map[string]bool)
 	claimedV4 := make(map[string]bool)
+	claimedI1 := make(map[string]bool)
+	claimedI2 := make(map[string]bool)
+	claimedI3 := make(map[string]bool)
+	claimedI4 := make(map[string]bool)
 
 	for _, prop := range mw.Properties {
 		if !prop.MeshQualifies(mesh) {
@@ -66,6 +73,22 @@ func (mw MeshWriter) Write(mesh modeling.Mesh, texture string, writer io.Writer)
 			claimedV2[v.ModelAttribute] = true
 		case *Vector1PropertyWriter:
 			claimedV1[v.ModelAttribute] = true
+		case Int1PropertyWriter:
+			claimedI1[v.ModelAttribute] = true
+		case *Int1PropertyWriter:
+			claimedI1[v.ModelAttribute] = true
+		case Int2PropertyWriter:
+			claimedI2[v.ModelAttribute] = true
+		case *Int2PropertyWriter:
+			claimedI2[v.ModelAttribute] = true
+		case Int3PropertyWriter:
+			claimedI3[v.ModelAttribute] = true
+		case *Int3PropertyWriter:
+			claimedI3[v.ModelAttribute] = true
+		case Int4PropertyWriter:
+			claimedI4[v.ModelAttribute] = true
+		case *Int4PropertyWriter:
+			claimedI4[v.ModelAttribute] = true
 		default:
 			panic("what is this type")
 		}
@@ -118,6 +141,72 @@ func (mw MeshWriter) Write(mesh modeling.Mesh, texture string, writer io.Writer)
 				PlyProperty:    p,
 			})
 		}
+		for _, p := range mesh.Int4Attributes() {
+			if claimedI4[p] {
+				continue
+			}
+			lowest, highest := 0, 0
+			mesh.ScanInt4Attribute(p, func(i int, v vector4.Int) {
+				lowest = min(lowest, v.X(), v.Y(), v.Z(), v.W())
+				highest = max(highest, v.X(), v.Y(), v.Z(), v.W())
+			})
+			writers = append(writers, Int4PropertyWriter{
+				ModelAttribute: p,
+				Type:           smallestIntType(lowest, highest),
+				PlyPropertyX:   fmt.Sprintf("%s_0", p),
+				PlyPropertyY:   fmt.Sprintf("%s_1", p),
+				PlyPropertyZ:   fmt.Sprintf("%s_2", p),
+				PlyPropertyW:   fmt.Sprintf("%s_3", p),
+			})
+		}
+		for _, p := range mesh.Int3Attributes() {
+			if claimedI3[p] {
+				continue
+			}
+			lowest, highest := 0, 0
+			mesh.ScanInt3Attribute(p, func(i int, v vector3.Int) {
+				lowest = min(lowest, v.X(), v.Y(), v.Z())
+				highest = max(highest, v.X(), v.Y(), v.Z())
+			})
+			writers = append(writers, Int3PropertyWriter{
+				ModelAttribute: p,
+				Type:           smallestIntType(lowest, highest),
+				PlyPropertyX:   fmt.Sprintf("%s_0", p),
+				PlyPropertyY:   fmt.Sprintf("%s_1", p),
+				PlyPropertyZ:   fmt.Sprintf("%s_2", p),
+			})
+		}
+		for _, p := range mesh.Int2Attributes() {
+			if claimedI2[p] {
+				continue
+			}
+			lowest, highest := 0, 0
+			mesh.ScanInt2Attribute(p, func(i int, v vector2.Int) {
+				lowest = min(lowest, v.X(), v.Y())
+				highest = max(highest, v.X(), v.Y())
+			})
+			writers = append(writers, Int2PropertyWriter{
+				ModelAttribute: p,
+				Type:           smallestIntType(lowest, highest),
+				PlyPropertyX:   fmt.Sprintf("%s_0", p),
+				PlyPropertyY:   fmt.Sprintf("%s_1", p),
+			})
+		}
+		for _, p := range mesh.Int1Attributes() {
+			if claimedI1[p] {
+				continue
+			}
+			lowest, highest := 0, 0
+			mesh.ScanInt1Attribute(p, func(i, v int) {
+				lowest = min(lowest, v)
+				highest = max(highest, v)
+			})
+			writers = append(writers, Int1PropertyWriter{
+				ModelAttribute: p,
+				Type:           smallestIntType(lowest, highest),
+				PlyProperty:    p,
+			})
+		}
 	}
 
 	for _, prop := range writers {
@@ -0,0 +1,130 @@
+package ply
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// Int1PropertyWriter writes an integer attribute out as a single PLY
+// property. Values are written as is, without any of the normalization
+// applied to float attributes stored as uchar.
+type Int1PropertyWriter struct {
+	ModelAttribute string
+	PlyProperty    string
+	Type           ScalarPropertyType
+}
+
+func (ipw Int1PropertyWriter) MeshQualifies(mesh modeling.Mesh) bool {
+	return mesh.HasInt1Attribute(ipw.ModelAttribute)
+}
+
+func (ipw Int1PropertyWriter) Properties() []Property {
+	return []Property{
+		ScalarProperty{PropertyName: ipw.PlyProperty, Type: ipw.Type},
+	}
+}
+
+func (ipw Int1PropertyWriter) build(mesh modeling.Mesh, format Format) builtPropertyWriter {
+	if format == ASCII {
+		return &asciiInt1PropertyWriter{
+			arr: mesh.Int1Attribute(ipw.ModelAttribute),
+			buf: make([]byte, 0),
+		}
+	}
+
+	var endian binary.ByteOrder = binary.LittleEndian
+	if format == BinaryBigEndian {
+		endian = binary.BigEndian
+	}
+	return builtInt1PropertyWriter{
+		arr:    mesh.Int1Attribute(ipw.ModelAttribute),
+		format: ipw.Type,
+		buf:    make([]byte, ipw.Type.Size()),
+		endian: endian,
+	}
+}
+
+// smallestIntType picks the smallest PLY scalar type able to hold every
+// value within the range provided
+func smallestIntType(min, max int) ScalarPropertyType {
+	if min >= 0 {
+		switch {
+		case max <= math.MaxUint8:
+			return UChar
+
+		case max <= math.MaxUint16:
+			return UShort
+
+		case max <= math.MaxUint32:
+			return UInt
+		}
+	}
+
+	switch {
+	case min >= math.MinInt8 && max <= math.MaxInt8:
+		return Char
+
+	case min >= math.MinInt16 && max <= math.MaxInt16:
+		return Short
+
+	case min >= math.MinInt32 && max <= math.MaxInt32:
+		return Int
+	}
+
+	return Double
+}
+
+type builtInt1PropertyWriter struct {
+	arr    *iter.ArrayIterator[int]
+	format ScalarPropertyType
+	endian binary.ByteOrder
+	buf    []byte
+}
+
+func (bipw builtInt1PropertyWriter) Write(out io.Writer, i int) (err error) {
+	putInt(bipw.buf, bipw.format, bipw.endian, bipw.arr.At(i))
+	_, err = out.Write(bipw.buf)
+	return
+}
+
+// putInt encodes the integer at the start of the buffer as the scalar type
+// provided
+func putInt(buf []byte, format ScalarPropertyType, endian binary.ByteOrder, v int) {
+	switch format {
+	case Char, UChar:
+		buf[0] = byte(v)
+
+	case Short, UShort:
+		endian.PutUint16(buf, uint16(v))
+
+	case Int, UInt:
+		endian.PutUint32(buf, uint32(v))
+
+	case Float:
+		endian.PutUint32(buf, math.Float32bits(float32(v)))
+
+	case Double:
+		endian.PutUint64(buf, math.Float64bits(float64(v)))
+
+	default:
+		panic(fmt.Errorf("unimplemented %s", format))
+	}
+}
+
+type asciiInt1PropertyWriter struct {
+	arr *iter.ArrayIterator[int]
+	buf []byte
+}
+
+func (aipw *asciiInt1PropertyWriter) Write(out io.Writer, i int) (err error) {
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(aipw.arr.At(i)), 10)
+	_, err = out.Write(aipw.buf)
+	aipw.buf = aipw.buf[:0]
+	return
+}
@@ -0,0 +1,84 @@
+package ply
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+)
+
+// Int2PropertyWriter writes an integer vector2 attribute out as 2 PLY
+// properties, with values written as is like Int1PropertyWriter
+type Int2PropertyWriter struct {
+	ModelAttribute string
+	PlyPropertyX   string
+	PlyPropertyY   string
+	Type           ScalarPropertyType
+}
+
+func (ipw Int2PropertyWriter) MeshQualifies(mesh modeling.Mesh) bool {
+	return mesh.HasInt2Attribute(ipw.ModelAttribute)
+}
+
+func (ipw Int2PropertyWriter) Properties() []Property {
+	return []Property{
+		ScalarProperty{PropertyName: ipw.PlyPropertyX, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyY, Type: ipw.Type},
+	}
+}
+
+func (ipw Int2PropertyWriter) build(mesh modeling.Mesh, format Format) builtPropertyWriter {
+	if format == ASCII {
+		return &asciiInt2PropertyWriter{
+			arr: mesh.Int2Attribute(ipw.ModelAttribute),
+			buf: make([]byte, 0),
+		}
+	}
+
+	var endian binary.ByteOrder = binary.LittleEndian
+	if format == BinaryBigEndian {
+		endian = binary.BigEndian
+	}
+	return builtInt2PropertyWriter{
+		arr:    mesh.Int2Attribute(ipw.ModelAttribute),
+		format: ipw.Type,
+		buf:    make([]byte, ipw.Type.Size()*2),
+		endian: endian,
+	}
+}
+
+type builtInt2PropertyWriter struct {
+	arr    *iter.ArrayIterator[vector2.Int]
+	format ScalarPropertyType
+	endian binary.ByteOrder
+	buf    []byte
+}
+
+func (bipw builtInt2PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := bipw.arr.At(i)
+	size := bipw.format.Size()
+	putInt(bipw.buf, bipw.format, bipw.endian, v.X())
+	putInt(bipw.buf[size*1:], bipw.format, bipw.endian, v.Y())
+
+	_, err = out.Write(bipw.buf)
+	return
+}
+
+type asciiInt2PropertyWriter struct {
+	arr *iter.ArrayIterator[vector2.Int]
+	buf []byte
+}
+
+func (aipw *asciiInt2PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := aipw.arr.At(i)
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.X()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.Y()), 10)
+
+	_, err = out.Write(aipw.buf)
+	aipw.buf = aipw.buf[:0]
+	return
+}
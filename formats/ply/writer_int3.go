@@ -0,0 +1,89 @@
+package ply
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Int3PropertyWriter writes an integer vector3 attribute out as 3 PLY
+// properties, with values written as is like Int1PropertyWriter
+type Int3PropertyWriter struct {
+	ModelAttribute string
+	PlyPropertyX   string
+	PlyPropertyY   string
+	PlyPropertyZ   string
+	Type           ScalarPropertyType
+}
+
+func (ipw Int3PropertyWriter) MeshQualifies(mesh modeling.Mesh) bool {
+	return mesh.HasInt3Attribute(ipw.ModelAttribute)
+}
+
+func (ipw Int3PropertyWriter) Properties() []Property {
+	return []Property{
+		ScalarProperty{PropertyName: ipw.PlyPropertyX, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyY, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyZ, Type: ipw.Type},
+	}
+}
+
+func (ipw Int3PropertyWriter) build(mesh modeling.Mesh, format Format) builtPropertyWriter {
+	if format == ASCII {
+		return &asciiInt3PropertyWriter{
+			arr: mesh.Int3Attribute(ipw.ModelAttribute),
+			buf: make([]byte, 0),
+		}
+	}
+
+	var endian binary.ByteOrder = binary.LittleEndian
+	if format == BinaryBigEndian {
+		endian = binary.BigEndian
+	}
+	return builtInt3PropertyWriter{
+		arr:    mesh.Int3Attribute(ipw.ModelAttribute),
+		format: ipw.Type,
+		buf:    make([]byte, ipw.Type.Size()*3),
+		endian: endian,
+	}
+}
+
+type builtInt3PropertyWriter struct {
+	arr    *iter.ArrayIterator[vector3.Int]
+	format ScalarPropertyType
+	endian binary.ByteOrder
+	buf    []byte
+}
+
+func (bipw builtInt3PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := bipw.arr.At(i)
+	size := bipw.format.Size()
+	putInt(bipw.buf, bipw.format, bipw.endian, v.X())
+	putInt(bipw.buf[size*1:], bipw.format, bipw.endian, v.Y())
+	putInt(bipw.buf[size*2:], bipw.format, bipw.endian, v.Z())
+
+	_, err = out.Write(bipw.buf)
+	return
+}
+
+type asciiInt3PropertyWriter struct {
+	arr *iter.ArrayIterator[vector3.Int]
+	buf []byte
+}
+
+func (aipw *asciiInt3PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := aipw.arr.At(i)
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.X()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.Y()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.Z()), 10)
+
+	_, err = out.Write(aipw.buf)
+	aipw.buf = aipw.buf[:0]
+	return
+}
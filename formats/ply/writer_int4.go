@@ -0,0 +1,94 @@
+package ply
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// Int4PropertyWriter writes an integer vector4 attribute out as 4 PLY
+// properties, with values written as is like Int1PropertyWriter
+type Int4PropertyWriter struct {
+	ModelAttribute string
+	PlyPropertyX   string
+	PlyPropertyY   string
+	PlyPropertyZ   string
+	PlyPropertyW   string
+	Type           ScalarPropertyType
+}
+
+func (ipw Int4PropertyWriter) MeshQualifies(mesh modeling.Mesh) bool {
+	return mesh.HasInt4Attribute(ipw.ModelAttribute)
+}
+
+func (ipw Int4PropertyWriter) Properties() []Property {
+	return []Property{
+		ScalarProperty{PropertyName: ipw.PlyPropertyX, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyY, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyZ, Type: ipw.Type},
+		ScalarProperty{PropertyName: ipw.PlyPropertyW, Type: ipw.Type},
+	}
+}
+
+func (ipw Int4PropertyWriter) build(mesh modeling.Mesh, format Format) builtPropertyWriter {
+	if format == ASCII {
+		return &asciiInt4PropertyWriter{
+			arr: mesh.Int4Attribute(ipw.ModelAttribute),
+			buf: make([]byte, 0),
+		}
+	}
+
+	var endian binary.ByteOrder = binary.LittleEndian
+	if format == BinaryBigEndian {
+		endian = binary.BigEndian
+	}
+	return builtInt4PropertyWriter{
+		arr:    mesh.Int4Attribute(ipw.ModelAttribute),
+		format: ipw.Type,
+		buf:    make([]byte, ipw.Type.Size()*4),
+		endian: endian,
+	}
+}
+
+type builtInt4PropertyWriter struct {
+	arr    *iter.ArrayIterator[vector4.Int]
+	format ScalarPropertyType
+	endian binary.ByteOrder
+	buf    []byte
+}
+
+func (bipw builtInt4PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := bipw.arr.At(i)
+	size := bipw.format.Size()
+	putInt(bipw.buf, bipw.format, bipw.endian, v.X())
+	putInt(bipw.buf[size*1:], bipw.format, bipw.endian, v.Y())
+	putInt(bipw.buf[size*2:], bipw.format, bipw.endian, v.Z())
+	putInt(bipw.buf[size*3:], bipw.format, bipw.endian, v.W())
+
+	_, err = out.Write(bipw.buf)
+	return
+}
+
+type asciiInt4PropertyWriter struct {
+	arr *iter.ArrayIterator[vector4.Int]
+	buf []byte
+}
+
+func (aipw *asciiInt4PropertyWriter) Write(out io.Writer, i int) (err error) {
+	v := aipw.arr.At(i)
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.X()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.Y()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.Z()), 10)
+	aipw.buf = append(aipw.buf, ' ')
+	aipw.buf = strconv.AppendInt(aipw.buf, int64(v.W()), 10)
+
+	_, err = out.Write(aipw.buf)
+	aipw.buf = aipw.buf[:0]
+	return
+}
@@ -1,7 +1,9 @@
 package potree
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -42,6 +44,123 @@ func (at AttributeType) Size() int {
 	}
 }
 
+// encode writes the value to the start of the buffer using the attribute
+// type's little endian binary representation. Values are rounded to the
+// nearest integer for integer types.
+func (at AttributeType) encode(buf []byte, v float64) {
+	endian := binary.LittleEndian
+	switch at {
+	case Int8AttributeType:
+		buf[0] = byte(int8(math.Round(v)))
+
+	case UInt8AttributeType:
+		buf[0] = uint8(math.Round(v))
+
+	case Int16AttributeType:
+		endian.PutUint16(buf, uint16(int16(math.Round(v))))
+
+	case UInt16AttributeType:
+		endian.PutUint16(buf, uint16(math.Round(v)))
+
+	case Int32AttributeType:
+		endian.PutUint32(buf, uint32(int32(math.Round(v))))
+
+	case UInt32AttributeType:
+		endian.PutUint32(buf, uint32(math.Round(v)))
+
+	case Int64AttributeType:
+		endian.PutUint64(buf, uint64(int64(math.Round(v))))
+
+	case UInt64AttributeType:
+		endian.PutUint64(buf, uint64(math.Round(v)))
+
+	case FloatAttributeType:
+		endian.PutUint32(buf, math.Float32bits(float32(v)))
+
+	case DoubleAttributeType:
+		endian.PutUint64(buf, math.Float64bits(v))
+
+	default:
+		panic(fmt.Errorf("unimplemented encoding for attribute type: %s", at))
+	}
+}
+
+// Integer reports whether or not the attribute type holds whole numbers
+func (at AttributeType) Integer() bool {
+	switch at {
+	case Int8AttributeType, UInt8AttributeType,
+		Int16AttributeType, UInt16AttributeType,
+		Int32AttributeType, UInt32AttributeType,
+		Int64AttributeType, UInt64AttributeType:
+		return true
+	}
+	return false
+}
+
+// decodeInt reads an integer value from the start of the buffer using the
+// attribute type's little endian binary representation
+func (at AttributeType) decodeInt(buf []byte) int {
+	endian := binary.LittleEndian
+	switch at {
+	case Int8AttributeType:
+		return int(int8(buf[0]))
+
+	case UInt8AttributeType:
+		return int(buf[0])
+
+	case Int16AttributeType:
+		return int(int16(endian.Uint16(buf)))
+
+	case UInt16AttributeType:
+		return int(endian.Uint16(buf))
+
+	case Int32AttributeType:
+		return int(int32(endian.Uint32(buf)))
+
+	case UInt32AttributeType:
+		return int(endian.Uint32(buf))
+
+	case Int64AttributeType:
+		return int(int64(endian.Uint64(buf)))
+
+	case UInt64AttributeType:
+		return int(endian.Uint64(buf))
+
+	default:
+		panic(fmt.Errorf("unimplemented integer decoding for attribute type: %s", at))
+	}
+}
+
+// integerAttributeType picks the smallest attribute type able to hold every
+// value within the range provided
+func integerAttributeType(min, max int) AttributeType {
+	if min >= 0 {
+		switch {
+		case max <= math.MaxUint8:
+			return UInt8AttributeType
+
+		case max <= math.MaxUint16:
+			return UInt16AttributeType
+
+		case max <= math.MaxUint32:
+			return UInt32AttributeType
+		}
+		return UInt64AttributeType
+	}
+
+	switch {
+	case min >= math.MinInt8 && max <= math.MaxInt8:
+		return Int8AttributeType
+
+	case min >= math.MinInt16 && max <= math.MaxInt16:
+		return Int16AttributeType
+
+	case min >= math.MinInt32 && max <= math.MaxInt32:
+		return Int32AttributeType
+	}
+	return Int64AttributeType
+}
+
 type Attribute struct {
 	Name        string        `json:"name"`
 	Description string        `json:"description"`
@@ -105,6 +105,7 @@ func LoadNode(on *OctreeNode, metadata *Metadata, buf []byte) modeling.Mesh {
 	endian := binary.LittleEndian
 
 	pointcloudData := make(map[string][]vector3.Float64)
+	intData := make(map[string][]int)
 
 	for _, attribute := range metadata.Attributes {
 		if attribute.IsPosition() {
@@ -152,8 +153,19 @@ func LoadNode(on *OctreeNode, metadata *Metadata, buf []byte) modeling.Mesh {
 			pointcloudData[modeling.ColorAttribute] = colorData
 		}
 
+		// Scalar integer attributes like classification and point source
+		// ids are kept as is
+		if !attribute.IsPosition() && !attribute.IsColor() && attribute.NumElements == 1 && attribute.Type.Integer() {
+			data := make([]int, numPoints)
+			for i := range data {
+				pointOffset := (i * bytesPerPoint) + attributeOffset
+				data[i] = attribute.Type.decodeInt(buf[pointOffset:])
+			}
+			intData[attribute.Name] = data
+		}
+
 		attributeOffset += attribute.Size
 	}
 
-	return modeling.NewPointCloud(nil, pointcloudData, nil, nil)
+	return modeling.NewPointCloud(nil, pointcloudData, nil, nil).SetInt1Data(intData)
 }
@@ -0,0 +1,591 @@
+package potree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	// Size of a single node entry within hierarchy.bin
+	hierarchyEntrySize = 22
+
+	// PotreeConverter sets the spacing of the root node to 1/128th of the
+	// octree's size
+	rootSpacingDivisor = 128.
+)
+
+type WriterOptions struct {
+	Name        string
+	Description string
+	Projection  string
+
+	// Precision positions are stored with. Defaults to 0.001
+	Scale float64
+
+	// Nodes containing this many points or less are not subdivided any
+	// further. Defaults to 10,000
+	MaxPointsPerNode int
+
+	// Maximum level of the octree, where all remaining points are placed
+	// into the leaf nodes regardless of their spacing. Defaults to 20
+	MaxDepth int
+
+	// Number of octree levels stored within each chunk of hierarchy.bin.
+	// Defaults to 4
+	StepSize int
+
+	// Encodings to use for attributes other than position and color, keyed
+	// by attribute name. Float attributes not found default to float, while
+	// integer attributes default to the smallest integer type that fits them
+	AttributeTypes map[string]AttributeType
+}
+
+func (wo *WriterOptions) withDefaults() WriterOptions {
+	options := WriterOptions{}
+	if wo != nil {
+		options = *wo
+	}
+
+	if options.Scale <= 0 {
+		options.Scale = 0.001
+	}
+
+	if options.MaxPointsPerNode <= 0 {
+		options.MaxPointsPerNode = 10_000
+	}
+
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = 20
+	}
+
+	if options.StepSize <= 0 {
+		options.StepSize = 4
+	}
+
+	return options
+}
+
+// writerAttribute is an attribute of the point cloud alongside how to pull
+// each of its elements for a specific point
+type writerAttribute struct {
+	Attribute
+	values func(point int, elements []float64)
+}
+
+// writerNode is an octree node under construction, containing the indices of
+// the points sampled into it
+type writerNode struct {
+	name     string
+	level    int
+	bounds   geometry.AABB
+	spacing  float64
+	points   []int
+	children [8]*writerNode
+
+	byteOffset uint64
+	byteSize   uint64
+}
+
+func (wn *writerNode) childMask() uint8 {
+	var mask uint8
+	for i, c := range wn.children {
+		if c != nil {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+func (wn *writerNode) leaf() bool {
+	return wn.childMask() == 0
+}
+
+// Save converts the point cloud into a Potree 2.0 dataset, writing
+// metadata.json, hierarchy.bin and octree.bin to the folder provided
+func Save(folder string, cloud modeling.Mesh, options *WriterOptions) error {
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return err
+	}
+
+	files := make([]*os.File, 0, 3)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	writers := make([]*bufio.Writer, 0, 3)
+	for _, name := range []string{"metadata.json", "hierarchy.bin", "octree.bin"} {
+		f, err := os.Create(filepath.Join(folder, name))
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		writers = append(writers, bufio.NewWriter(f))
+	}
+
+	if err := Write(cloud, writers[0], writers[1], writers[2], options); err != nil {
+		return err
+	}
+
+	for _, w := range writers {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write converts the point cloud into a Potree 2.0 dataset, writing the
+// contents of metadata.json, hierarchy.bin and octree.bin to their respective
+// writers.
+//
+// Points are distributed through the octree by subsampling. Each node keeps
+// the points that are at least the node's spacing away from one another, and
+// passes the remaining points down to its children, where the spacing is
+// halved.
+func Write(cloud modeling.Mesh, metadataOut, hierarchyOut, octreeOut io.Writer, options *WriterOptions) error {
+	if cloud.Topology() != modeling.PointTopology {
+		return fmt.Errorf("mesh must be point topology, was instead %s", cloud.Topology())
+	}
+
+	if !cloud.HasFloat3Attribute(modeling.PositionAttribute) {
+		return fmt.Errorf("required attribute not present on mesh: %s", modeling.PositionAttribute)
+	}
+
+	opts := options.withDefaults()
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	pointCount := positions.Len()
+
+	// Potree's octree is a cube
+	bounds := geometry.NewAABBFromPoints(vector3.Zero[float64]())
+	if pointCount > 0 {
+		bounds = cloud.BoundingBox(modeling.PositionAttribute)
+	}
+	size := bounds.Size().MaxComponent()
+	if size <= 0 {
+		// Give a cloud made of a single location some volume to subdivide
+		size = opts.Scale
+	}
+	if size/opts.Scale > math.MaxInt32 {
+		return fmt.Errorf("point cloud of size %g can not be represented with a scale of %g", size, opts.Scale)
+	}
+	cube := geometry.NewAABBFromPoints(bounds.Min(), bounds.Min().Add(vector3.Fill(size)))
+
+	attributes, err := writerAttributes(cloud, cube, opts)
+	if err != nil {
+		return err
+	}
+
+	bytesPerPoint := 0
+	for _, attr := range attributes {
+		bytesPerPoint += attr.Size
+	}
+
+	root := &writerNode{
+		name:    "r",
+		bounds:  cube,
+		spacing: size / rootSpacingDivisor,
+	}
+	candidates := make([]int, pointCount)
+	for i := range candidates {
+		candidates[i] = i
+	}
+	buildOctree(root, candidates, positions.At, opts)
+
+	// Write out point data breadth first, recording where each node lives
+	// within octree.bin
+	nodes := breadthFirst(root)
+	elements := make([]float64, 4)
+	var offset uint64
+	for _, node := range nodes {
+		buf := make([]byte, len(node.points)*bytesPerPoint)
+		pointOffset := 0
+		for _, point := range node.points {
+			for _, attr := range attributes {
+				attr.values(point, elements[:attr.NumElements])
+				for e := 0; e < attr.NumElements; e++ {
+					attr.Type.encode(buf[pointOffset+(e*attr.ElementSize):], elements[e])
+				}
+				pointOffset += attr.Size
+			}
+		}
+
+		node.byteOffset = offset
+		node.byteSize = uint64(len(buf))
+		offset += node.byteSize
+
+		if _, err := octreeOut.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	hierarchy, firstChunkSize := encodeHierarchy(root, opts.StepSize)
+	if _, err := hierarchyOut.Write(hierarchy); err != nil {
+		return err
+	}
+
+	metadataAttributes := make([]Attribute, len(attributes))
+	for i, attr := range attributes {
+		metadataAttributes[i] = attr.Attribute
+	}
+
+	depth := 0
+	for _, node := range nodes {
+		depth = max(depth, node.level)
+	}
+
+	metadata := Metadata{
+		Version:     "2.0",
+		Name:        opts.Name,
+		Description: opts.Description,
+		Points:      int64(pointCount),
+		Projection:  opts.Projection,
+		Hierarchy: MetadataHierarchy{
+			FirstChunkSize: firstChunkSize,
+			StepSize:       opts.StepSize,
+			Depth:          depth,
+		},
+		Offset:  cube.Min().ToArr(),
+		Scale:   []float64{opts.Scale, opts.Scale, opts.Scale},
+		Spacing: root.spacing,
+		BoundingBox: MetadataBounds{
+			Min: cube.Min().ToArr(),
+			Max: cube.Max().ToArr(),
+		},
+		Encoding:   "DEFAULT",
+		Attributes: metadataAttributes,
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = metadataOut.Write(data)
+	return err
+}
+
+// writerAttributes determines the attributes to write for every point, with
+// position and color written in the encodings potree expects, followed by
+// the remaining attributes of the point cloud
+func writerAttributes(cloud modeling.Mesh, cube geometry.AABB, opts WriterOptions) ([]writerAttribute, error) {
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	offset := cube.Min()
+	attributes := []writerAttribute{
+		{
+			Attribute: Attribute{Name: "position", Type: Int32AttributeType, NumElements: 3},
+			values: func(point int, elements []float64) {
+				p := positions.At(point).Sub(offset).DivByConstant(opts.Scale)
+				elements[0], elements[1], elements[2] = p.X(), p.Y(), p.Z()
+			},
+		},
+	}
+
+	if cloud.HasFloat3Attribute(modeling.ColorAttribute) {
+		colors := cloud.Float3Attribute(modeling.ColorAttribute)
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: "rgb", Type: UInt16AttributeType, NumElements: 3},
+			values: func(point int, elements []float64) {
+				// 16 bit color, as found within LAS files
+				c := colors.At(point).Clamp(0, 1).Scale(255).Round().Scale(256)
+				elements[0], elements[1], elements[2] = c.X(), c.Y(), c.Z()
+			},
+		})
+	}
+
+	attributeType := func(name string) AttributeType {
+		if t, ok := opts.AttributeTypes[name]; ok {
+			return t
+		}
+		return FloatAttributeType
+	}
+
+	for _, name := range cloud.Float1Attributes() {
+		data := cloud.Float1Attribute(name)
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: name, Type: attributeType(name), NumElements: 1},
+			values: func(point int, elements []float64) {
+				elements[0] = data.At(point)
+			},
+		})
+	}
+
+	for _, name := range cloud.Int1Attributes() {
+		data := cloud.Int1Attribute(name)
+		t, ok := opts.AttributeTypes[name]
+		if !ok {
+			lowest, highest := 0, 0
+			cloud.ScanInt1Attribute(name, func(i, v int) {
+				lowest = min(lowest, v)
+				highest = max(highest, v)
+			})
+			t = integerAttributeType(lowest, highest)
+		}
+
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: name, Type: t, NumElements: 1},
+			values: func(point int, elements []float64) {
+				elements[0] = float64(data.At(point))
+			},
+		})
+	}
+
+	for _, name := range cloud.Float2Attributes() {
+		data := cloud.Float2Attribute(name)
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: name, Type: attributeType(name), NumElements: 2},
+			values: func(point int, elements []float64) {
+				v := data.At(point)
+				elements[0], elements[1] = v.X(), v.Y()
+			},
+		})
+	}
+
+	for _, name := range cloud.Float3Attributes() {
+		if name == modeling.PositionAttribute || name == modeling.ColorAttribute {
+			continue
+		}
+		data := cloud.Float3Attribute(name)
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: name, Type: attributeType(name), NumElements: 3},
+			values: func(point int, elements []float64) {
+				v := data.At(point)
+				elements[0], elements[1], elements[2] = v.X(), v.Y(), v.Z()
+			},
+		})
+	}
+
+	for _, name := range cloud.Float4Attributes() {
+		data := cloud.Float4Attribute(name)
+		attributes = append(attributes, writerAttribute{
+			Attribute: Attribute{Name: name, Type: attributeType(name), NumElements: 4},
+			values: func(point int, elements []float64) {
+				v := data.At(point)
+				elements[0], elements[1], elements[2], elements[3] = v.X(), v.Y(), v.Z(), v.W()
+			},
+		})
+	}
+
+	pointCount := positions.Len()
+	elements := make([]float64, 4)
+	for i := range attributes {
+		attr := &attributes[i]
+		if attr.Type == UndefinedAttributeType || attr.Type == "" {
+			return nil, fmt.Errorf("attribute %q has an undefined type", attr.Name)
+		}
+
+		attr.ElementSize = attr.Type.Size()
+		attr.Size = attr.ElementSize * attr.NumElements
+		attr.Min = make([]float64, attr.NumElements)
+		attr.Max = make([]float64, attr.NumElements)
+		for e := range attr.Min {
+			attr.Min[e] = math.Inf(1)
+			attr.Max[e] = math.Inf(-1)
+		}
+
+		for p := 0; p < pointCount; p++ {
+			attr.values(p, elements[:attr.NumElements])
+			for e := 0; e < attr.NumElements; e++ {
+				attr.Min[e] = math.Min(attr.Min[e], elements[e])
+				attr.Max[e] = math.Max(attr.Max[e], elements[e])
+			}
+		}
+
+		// Position bounds are reported in world space rather than in their
+		// encoded form
+		if i == 0 {
+			for e := 0; e < attr.NumElements; e++ {
+				attr.Min[e] = (attr.Min[e] * opts.Scale) + offset.Component(e)
+				attr.Max[e] = (attr.Max[e] * opts.Scale) + offset.Component(e)
+			}
+		}
+
+		if pointCount == 0 {
+			for e := range attr.Min {
+				attr.Min[e] = 0
+				attr.Max[e] = 0
+			}
+		}
+	}
+
+	return attributes, nil
+}
+
+// childIndex follows potree's convention of using the first bit for the
+// z axis, the second for the y axis and the third for the x axis
+func childIndex(bounds geometry.AABB, p vector3.Float64) int {
+	center := bounds.Center()
+	index := 0
+	if p.Z() >= center.Z() {
+		index |= 0b0001
+	}
+	if p.Y() >= center.Y() {
+		index |= 0b0010
+	}
+	if p.X() >= center.X() {
+		index |= 0b0100
+	}
+	return index
+}
+
+// buildOctree keeps the candidates that are at least the node's spacing away
+// from one another, and distributes the rest amongst the node's children
+func buildOctree(node *writerNode, candidates []int, position func(int) vector3.Float64, opts WriterOptions) {
+	if len(candidates) <= opts.MaxPointsPerNode || node.level >= opts.MaxDepth {
+		node.points = candidates
+		return
+	}
+
+	// Points are bucketed into cells the size of the spacing, so only the
+	// neighboring cells need checking for points that are too close
+	min := node.bounds.Min()
+	spacingSquared := node.spacing * node.spacing
+	cell := func(p vector3.Float64) vector3.Int {
+		return p.Sub(min).DivByConstant(node.spacing).FloorToInt()
+	}
+	grid := make(map[vector3.Int][]int)
+
+	var rejected [8][]int
+	for _, candidate := range candidates {
+		p := position(candidate)
+		c := cell(p)
+
+		accepted := true
+		for x := -1; x <= 1 && accepted; x++ {
+			for y := -1; y <= 1 && accepted; y++ {
+				for z := -1; z <= 1 && accepted; z++ {
+					for _, other := range grid[c.Add(vector3.New(x, y, z))] {
+						if position(other).DistanceSquared(p) < spacingSquared {
+							accepted = false
+							break
+						}
+					}
+				}
+			}
+		}
+
+		if accepted {
+			node.points = append(node.points, candidate)
+			grid[c] = append(grid[c], candidate)
+			continue
+		}
+
+		child := childIndex(node.bounds, p)
+		rejected[child] = append(rejected[child], candidate)
+	}
+
+	for i, points := range rejected {
+		if len(points) == 0 {
+			continue
+		}
+
+		child := &writerNode{
+			name:    node.name + strconv.Itoa(i),
+			level:   node.level + 1,
+			bounds:  createChildAABB(node.bounds, i),
+			spacing: node.spacing / 2,
+		}
+		node.children[i] = child
+		buildOctree(child, points, position, opts)
+	}
+}
+
+func breadthFirst(root *writerNode) []*writerNode {
+	nodes := []*writerNode{root}
+	for i := 0; i < len(nodes); i++ {
+		for _, c := range nodes[i].children {
+			if c != nil {
+				nodes = append(nodes, c)
+			}
+		}
+	}
+	return nodes
+}
+
+// hierarchyChunk is a subtree of the octree written contiguously within
+// hierarchy.bin, spanning a fixed number of levels. Nodes at the bottom of
+// the chunk with children are written as proxies pointing to their own chunk.
+type hierarchyChunk struct {
+	root       *writerNode
+	nodes      []*writerNode
+	byteOffset uint64
+}
+
+func (hc hierarchyChunk) byteSize() uint64 {
+	return uint64(len(hc.nodes) * hierarchyEntrySize)
+}
+
+// encodeHierarchy builds the contents of hierarchy.bin, along with the size
+// of the first chunk required for reading it
+func encodeHierarchy(root *writerNode, stepSize int) ([]byte, uint64) {
+	chunks := []*hierarchyChunk{{root: root}}
+	chunkLookup := map[*writerNode]*hierarchyChunk{root: chunks[0]}
+
+	var offset uint64
+	for i := 0; i < len(chunks); i++ {
+		chunk := chunks[i]
+		chunk.nodes = []*writerNode{chunk.root}
+		for n := 0; n < len(chunk.nodes); n++ {
+			node := chunk.nodes[n]
+			if node.level-chunk.root.level == stepSize {
+				if !node.leaf() {
+					next := &hierarchyChunk{root: node}
+					chunks = append(chunks, next)
+					chunkLookup[node] = next
+				}
+				continue
+			}
+
+			for _, c := range node.children {
+				if c != nil {
+					chunk.nodes = append(chunk.nodes, c)
+				}
+			}
+		}
+
+		chunk.byteOffset = offset
+		offset += chunk.byteSize()
+	}
+
+	buf := make([]byte, offset)
+	endian := binary.LittleEndian
+	for _, chunk := range chunks {
+		for i, node := range chunk.nodes {
+			entry := HierarchyNodeEntry{
+				ChildMask:  node.childMask(),
+				NumPoints:  uint32(len(node.points)),
+				ByteOffset: node.byteOffset,
+				ByteSize:   node.byteSize,
+			}
+
+			if proxied, ok := chunkLookup[node]; ok && proxied != chunk {
+				entry.Type = 2
+				entry.ByteOffset = proxied.byteOffset
+				entry.ByteSize = proxied.byteSize()
+			} else if node.leaf() {
+				entry.Type = 1
+			}
+
+			start := chunk.byteOffset + uint64(i*hierarchyEntrySize)
+			b := buf[start : start+hierarchyEntrySize]
+			b[0] = entry.Type
+			b[1] = entry.ChildMask
+			endian.PutUint32(b[2:], entry.NumPoints)
+			endian.PutUint64(b[6:], entry.ByteOffset)
+			endian.PutUint64(b[14:], entry.ByteSize)
+		}
+	}
+
+	return buf, chunks[0].byteSize()
+}
@@ -0,0 +1,225 @@
+package potree_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/potree"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomCloud scatters points across a flat 1m square, snapped to the 1mm
+// precision the dataset is written with
+func randomCloud(count int) modeling.Mesh {
+	rng := rand.New(rand.NewSource(1))
+	used := make(map[vector2.Int]bool)
+	positions := make([]vector3.Float64, 0, count)
+	colors := make([]vector3.Float64, 0, count)
+	intensities := make([]float64, 0, count)
+	for len(positions) < count {
+		cell := vector2.New(rng.Intn(1000), rng.Intn(1000))
+		if used[cell] {
+			continue
+		}
+		used[cell] = true
+
+		positions = append(positions, vector3.New(cell.X(), cell.Y(), 0).ToFloat64().Scale(0.001).Add(vector3.New(-2., 3., 10.)))
+		colors = append(colors, vector3.New(cell.X(), cell.Y(), 0).ToFloat64().Scale(0.001))
+		intensities = append(intensities, float64(cell.X()+cell.Y()))
+	}
+
+	return modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: positions,
+			modeling.ColorAttribute:    colors,
+		},
+		nil,
+		map[string][]float64{
+			"intensity": intensities,
+		},
+	)
+}
+
+type readDataset struct {
+	metadata *potree.Metadata
+	root     *potree.OctreeNode
+	octree   []byte
+}
+
+func (rd readDataset) nodeBuffer(node *potree.OctreeNode) []byte {
+	return rd.octree[node.ByteOffset : node.ByteOffset+node.ByteSize]
+}
+
+func writeDataset(t *testing.T, cloud modeling.Mesh, options *potree.WriterOptions) readDataset {
+	t.Helper()
+	metadataBuf := &bytes.Buffer{}
+	hierarchyBuf := &bytes.Buffer{}
+	octreeBuf := &bytes.Buffer{}
+	require.NoError(t, potree.Write(cloud, metadataBuf, hierarchyBuf, octreeBuf, options))
+
+	metadata, err := potree.ReadMetadata(metadataBuf)
+	require.NoError(t, err)
+
+	root, err := metadata.ReadHierarchy(hierarchyBuf)
+	require.NoError(t, err)
+
+	return readDataset{metadata: metadata, root: root, octree: octreeBuf.Bytes()}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := randomCloud(20_000)
+	options := &potree.WriterOptions{
+		Name:             "grid",
+		MaxPointsPerNode: 200,
+		StepSize:         1,
+		AttributeTypes: map[string]potree.AttributeType{
+			"intensity": potree.UInt32AttributeType,
+		},
+	}
+
+	// ACT ====================================================================
+	dataset := writeDataset(t, cloud, options)
+
+	// ASSERT =================================================================
+	metadata := dataset.metadata
+	assert.Equal(t, "2.0", metadata.Version)
+	assert.Equal(t, "grid", metadata.Name)
+	assert.Equal(t, int64(20_000), metadata.Points)
+	bounds := cloud.BoundingBox(modeling.PositionAttribute)
+	size := bounds.Size().MaxComponent()
+	assert.Equal(t, bounds.Min().ToArr(), metadata.Offset)
+	assert.InDelta(t, size/128, metadata.Spacing, 1e-9)
+	assert.Equal(t, dataset.root.Height(), metadata.Hierarchy.Depth)
+	assert.Greater(t, metadata.Hierarchy.Depth, 1)
+	assert.Equal(t, 12+6+4, metadata.BytesPerPoint())
+
+	intensity, _ := metadata.Attribute("intensity")
+	require.NotNil(t, intensity)
+	assert.Equal(t, potree.UInt32AttributeType, intensity.Type)
+	minIntensity, maxIntensity := math.Inf(1), math.Inf(-1)
+	cloud.ScanFloat1Attribute("intensity", func(i int, v float64) {
+		minIntensity = min(minIntensity, v)
+		maxIntensity = max(maxIntensity, v)
+	})
+	assert.Equal(t, []float64{minIntensity}, intensity.Min)
+	assert.Equal(t, []float64{maxIntensity}, intensity.Max)
+
+	require.Equal(t, uint64(20_000), dataset.root.PointCount())
+
+	expected := make(map[vector3.Int]vector3.Float64)
+	cloud.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		expected[v.Scale(1000).RoundToInt()] = cloud.Float3Attribute(modeling.ColorAttribute).At(i)
+	})
+
+	intensityOffset := metadata.AttributeOffset("intensity")
+	found := make(map[vector3.Int]bool)
+	dataset.root.Walk(func(node *potree.OctreeNode) bool {
+		positions := make([]vector3.Float64, node.NumPoints)
+		colors := make([]vector3.Float64, node.NumPoints)
+		potree.LoadNodePositionDataIntoArray(metadata, dataset.nodeBuffer(node), positions)
+		potree.LoadNodeColorDataIntoArray(metadata, dataset.nodeBuffer(node), colors)
+
+		for i, p := range positions {
+			// Points on the boundary between nodes may round outside of them
+			closest := node.BoundingBox.ClosestPoint(p)
+			assert.InDelta(t, 0, closest.Distance(p), 0.001, "%s does not contain %v", node.Name, p)
+
+			key := p.Scale(1000).RoundToInt()
+			color, ok := expected[key]
+			require.True(t, ok, "unexpected point %v", p)
+			assert.False(t, found[key], "duplicate point %v", p)
+			found[key] = true
+			assert.InDelta(t, 0, color.Distance(colors[i]), 0.01)
+
+			point := dataset.nodeBuffer(node)[i*metadata.BytesPerPoint()+intensityOffset:]
+			x := math.Round((p.X() + 2) * 1000)
+			y := math.Round((p.Y() - 3) * 1000)
+			assert.Equal(t, uint32(x+y), binary.LittleEndian.Uint32(point))
+		}
+
+		// Subsampled nodes keep their points a spacing apart
+		if len(node.Children) > 0 {
+			closest := math.Inf(1)
+			for i := range positions {
+				for j := i + 1; j < len(positions); j++ {
+					closest = min(closest, positions[i].Distance(positions[j]))
+				}
+			}
+			assert.GreaterOrEqual(t, closest, node.Spacing-1e-9, node.Name)
+		}
+		return true
+	})
+	assert.Len(t, found, 20_000)
+}
+
+func TestSave(t *testing.T) {
+	// ARRANGE ================================================================
+	folder := filepath.Join(t.TempDir(), "dataset")
+	cloud := randomCloud(25)
+
+	// ACT ====================================================================
+	err := potree.Save(folder, cloud, nil)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	metadata, err := potree.LoadMetadata(filepath.Join(folder, "metadata.json"))
+	require.NoError(t, err)
+	root, err := metadata.LoadHierarchy(filepath.Join(folder, "hierarchy.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25), root.PointCount())
+	assert.Equal(t, "DEFAULT", metadata.Encoding)
+
+	octree, err := os.ReadFile(filepath.Join(folder, "octree.bin"))
+	require.NoError(t, err)
+	assert.Len(t, octree, 25*metadata.BytesPerPoint())
+}
+
+func TestWrite_RequiresPointTopology(t *testing.T) {
+	err := potree.Write(modeling.NewTriangleMesh([]int{0, 1, 2}), nil, nil, nil, nil)
+	assert.EqualError(t, err, "mesh must be point topology, was instead triangle")
+}
+
+func TestWrite_IntAttributesRoundTrip(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := randomCloud(500)
+	classes := make([]int, 500)
+	cloud.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		classes[i] = int(math.Round((v.X()+2)*1000)) % 7
+	})
+	cloud = cloud.SetInt1Attribute("classification", classes)
+
+	// ACT ====================================================================
+	dataset := writeDataset(t, cloud, &potree.WriterOptions{MaxPointsPerNode: 100})
+
+	// ASSERT =================================================================
+	classification, _ := dataset.metadata.Attribute("classification")
+	require.NotNil(t, classification)
+	assert.Equal(t, potree.UInt8AttributeType, classification.Type)
+	assert.Equal(t, []float64{0}, classification.Min)
+	assert.Equal(t, []float64{6}, classification.Max)
+
+	loaded := 0
+	dataset.root.Walk(func(node *potree.OctreeNode) bool {
+		positions := make([]vector3.Float64, node.NumPoints)
+		potree.LoadNodePositionDataIntoArray(dataset.metadata, dataset.nodeBuffer(node), positions)
+
+		mesh := potree.LoadNode(node, dataset.metadata, dataset.nodeBuffer(node))
+		mesh.ScanInt1Attribute("classification", func(i, v int) {
+			assert.Equal(t, int(math.Round((positions[i].X()+2)*1000))%7, v)
+			loaded++
+		})
+		return true
+	})
+	assert.Equal(t, 500, loaded)
+}
@@ -254,11 +254,5 @@ func Load(filePath string) (*Cloud, error) {
 		return nil, err
 	}
 	defer f.Close()
-	r := bufio.NewReader(f)
-	a, err := Read(r)
-	if err != nil {
-		panic(err)
-	}
-
-	return a, err
+	return Read(bufio.NewReader(f))
 }
@@ -2,8 +2,10 @@ package spz
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/generator/manifest"
 	"github.com/EliCDavis/polyform/modeling"
 	"github.com/EliCDavis/polyform/nodes"
 	"github.com/EliCDavis/polyform/refutil"
@@ -12,6 +14,7 @@ import (
 func init() {
 	factory := &refutil.TypeFactory{}
 	refutil.RegisterType[ReadNode](factory)
+	refutil.RegisterType[ManifestNode](factory)
 	generator.RegisterTypes(factory)
 }
 
@@ -40,3 +43,36 @@ func (gad ReadNodeData) Out() nodes.StructOutput[modeling.Mesh] {
 
 	return nodes.NewStructOutput(cloud.Mesh)
 }
+
+type Artifact struct {
+	Mesh modeling.Mesh
+}
+
+func (sa Artifact) Write(w io.Writer) error {
+	return Write(sa.Mesh, w)
+}
+
+func (Artifact) Mime() string {
+	return "application/octet-stream"
+}
+
+type ManifestNode = nodes.Struct[ManifestNodeData]
+
+type ManifestNodeData struct {
+	Name nodes.Output[string] `description:"Name of the main file in the manifest, defaults to 'model.spz'"`
+	Mesh nodes.Output[modeling.Mesh]
+}
+
+func (pn ManifestNodeData) Description() string {
+	return "Niantic's compressed SPZ format for Gaussian Splats"
+}
+
+func (pn ManifestNodeData) Out() nodes.StructOutput[manifest.Manifest] {
+	name := nodes.TryGetOutputValue(pn.Name, "model.spz")
+	mesh := nodes.TryGetOutputValue(pn.Mesh, modeling.EmptyPointcloud())
+	entry := manifest.Entry{
+		Artifact: Artifact{Mesh: mesh},
+		Metadata: map[string]any{"gaussianSplat": true},
+	}
+	return nodes.NewStructOutput(manifest.SingleEntryManifest(name, entry))
+}
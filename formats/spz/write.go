@@ -1,20 +1,240 @@
 package spz
 
 import (
+	"compress/gzip"
 	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
 
 	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
 )
 
+// Niantic's reference implementation defaults to 12 fractional bits, giving
+// a resolution of ~0.25mm and a range of +/- 2048 units for positions
+const DefaultFractionalBits uint8 = 12
+
+type WriterOptions struct {
+	// Number of bits within each 24-bit fixed point position component used
+	// for the fractional part. Defaults to DefaultFractionalBits when zero.
+	FractionalBits uint8
+}
+
+// Write serializes the gaussian splat point cloud to the output writer using
+// the default writer options.
 func Write(cloud modeling.Mesh, out io.Writer) error {
+	return WriteWithOptions(cloud, out, nil)
+}
+
+// WriteWithOptions serializes the gaussian splat point cloud to the output
+// writer. Attributes are quantized the same way they are decoded by Read,
+// with spherical harmonics taken from the mesh's consecutive SH_0...SH_14
+// attributes.
+func WriteWithOptions(cloud modeling.Mesh, out io.Writer, options *WriterOptions) error {
+	if cloud.Topology() != modeling.PointTopology {
+		return fmt.Errorf("mesh must be point topology, was instead %s", cloud.Topology())
+	}
+
+	fractionalBits := DefaultFractionalBits
+	if options != nil && options.FractionalBits != 0 {
+		fractionalBits = options.FractionalBits
+	}
+
+	if fractionalBits > 23 {
+		return fmt.Errorf("fractional bits must be less than 24, was %d", fractionalBits)
+	}
+
+	count := cloud.PrimitiveCount()
+	if cloud.AttributeLength() > 0 {
+		requiredAttributes := []string{
+			modeling.PositionAttribute,
+			modeling.ScaleAttribute,
+			modeling.FDCAttribute,
+			modeling.OpacityAttribute,
+			modeling.RotationAttribute,
+		}
+
+		for _, attr := range requiredAttributes {
+			if !cloud.HasVertexAttribute(attr) {
+				return fmt.Errorf("required attribute not present on mesh: %s", attr)
+			}
+		}
+	} else {
+		count = 0
+	}
+
+	shDim := 0
+	for cloud.HasFloat3Attribute(fmt.Sprintf("SH_%d", shDim)) {
+		shDim++
+	}
+
 	header := Header{
 		Magic:          magicNum,
 		Version:        2,
-		NumPoints:      uint32(cloud.PrimitiveCount()),
-		ShDegree:       0,
-		FractionalBits: 3,
+		NumPoints:      uint32(count),
+		ShDegree:       uint8(degreeForDim(shDim)),
+		FractionalBits: fractionalBits,
+	}
+
+	if err := header.Validate(); err != nil {
+		return err
+	}
+
+	positions, err := header.encodePositions(cloud)
+	if err != nil {
+		return err
+	}
+
+	sections := [][]byte{
+		positions,
+		header.encodeAlphas(cloud),
+		header.encodeColors(cloud),
+		header.encodeScales(cloud),
+		header.encodeRotations(cloud),
+		header.encodeSh(cloud),
+	}
+
+	writer := gzip.NewWriter(out)
+	if err := binary.Write(writer, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		if _, err := writer.Write(section); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// quantize rounds the value to the nearest integer within [0, 255]
+func quantize(v float64) byte {
+	return byte(math.Round(math.Max(0, math.Min(255, v))))
+}
+
+func (pgh Header) encodePositions(cloud modeling.Mesh) ([]byte, error) {
+	data := make([]byte, pgh.NumPoints*9)
+	if pgh.NumPoints == 0 {
+		return data, nil
+	}
+
+	const maxFixed = (1 << 23) - 1
+	const minFixed = -(1 << 23)
+	scale := float64(int(1) << pgh.FractionalBits)
+
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	for i := 0; i < int(pgh.NumPoints); i++ {
+		p := positions.At(i)
+		for c := 0; c < 3; c++ {
+			fixed := math.Round(p.Component(c) * scale)
+			if fixed > maxFixed || fixed < minFixed {
+				return nil, fmt.Errorf("position %d component %d (%g) can not be represented with %d fractional bits", i, c, p.Component(c), pgh.FractionalBits)
+			}
+
+			fixed32 := uint32(int32(fixed))
+			offset := (i * 9) + (c * 3)
+			data[offset+0] = byte(fixed32)
+			data[offset+1] = byte(fixed32 >> 8)
+			data[offset+2] = byte(fixed32 >> 16)
+		}
+	}
+	return data, nil
+}
+
+func (pgh Header) encodeAlphas(cloud modeling.Mesh) []byte {
+	data := make([]byte, pgh.NumPoints)
+	if pgh.NumPoints == 0 {
+		return data
 	}
 
-	return binary.Write(out, binary.LittleEndian, header)
+	// Mirrors readAlphas, which interprets alpha as a linear value rather
+	// than running it back through the inverse sigmoid
+	alphas := cloud.Float1Attribute(modeling.OpacityAttribute)
+	for i := range data {
+		data[i] = quantize(alphas.At(i) * 255)
+	}
+	return data
+}
+
+func (pgh Header) encodeColors(cloud modeling.Mesh) []byte {
+	data := make([]byte, pgh.NumPoints*3)
+	if pgh.NumPoints == 0 {
+		return data
+	}
+
+	colors := cloud.Float3Attribute(modeling.FDCAttribute)
+	for i := 0; i < int(pgh.NumPoints); i++ {
+		color := colors.At(i).Scale(0.15).Add(vector3.Fill(0.5)).Scale(255)
+		data[(i*3)+0] = quantize(color.X())
+		data[(i*3)+1] = quantize(color.Y())
+		data[(i*3)+2] = quantize(color.Z())
+	}
+	return data
+}
+
+func (pgh Header) encodeScales(cloud modeling.Mesh) []byte {
+	data := make([]byte, pgh.NumPoints*3)
+	if pgh.NumPoints == 0 {
+		return data
+	}
+
+	scales := cloud.Float3Attribute(modeling.ScaleAttribute)
+	for i := 0; i < int(pgh.NumPoints); i++ {
+		scale := scales.At(i).Add(vector3.Fill(10.)).Scale(16)
+		data[(i*3)+0] = quantize(scale.X())
+		data[(i*3)+1] = quantize(scale.Y())
+		data[(i*3)+2] = quantize(scale.Z())
+	}
+	return data
+}
+
+func (pgh Header) encodeRotations(cloud modeling.Mesh) []byte {
+	data := make([]byte, pgh.NumPoints*3)
+	if pgh.NumPoints == 0 {
+		return data
+	}
+
+	rotations := cloud.Float4Attribute(modeling.RotationAttribute)
+	for i := 0; i < int(pgh.NumPoints); i++ {
+		r := rotations.At(i)
+		if length := r.Length(); length > 0 {
+			r = r.DivByConstant(length)
+		} else {
+			r = vector4.New(0., 0., 0., 1.)
+		}
+
+		// Only xyz is stored, with w recovered from the quaternion being
+		// normalized. Flip the quaternion so w is always non-negative.
+		if r.W() < 0 {
+			r = r.Scale(-1)
+		}
+
+		data[(i*3)+0] = quantize((r.X() + 1) * 127.5)
+		data[(i*3)+1] = quantize((r.Y() + 1) * 127.5)
+		data[(i*3)+2] = quantize((r.Z() + 1) * 127.5)
+	}
+	return data
+}
+
+func (pgh Header) encodeSh(cloud modeling.Mesh) []byte {
+	shDim, _ := pgh.ShDimensions()
+	data := make([]byte, int(pgh.NumPoints)*3*shDim)
+	if len(data) == 0 {
+		return data
+	}
+
+	for d := 0; d < shDim; d++ {
+		sh := cloud.Float3Attribute(fmt.Sprintf("SH_%d", d))
+		for i := 0; i < int(pgh.NumPoints); i++ {
+			v := sh.At(i).Scale(128).Add(vector3.Fill(128.))
+			offset := (i * 3 * shDim) + (d * 3)
+			data[offset+0] = quantize(v.X())
+			data[offset+1] = quantize(v.Y())
+			data[offset+2] = quantize(v.Z())
+		}
+	}
+	return data
 }
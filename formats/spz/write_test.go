@@ -0,0 +1,174 @@
+package spz_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/spz"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCloud(shDim int) modeling.Mesh {
+	v3 := map[string][]vector3.Float64{
+		modeling.PositionAttribute: {
+			vector3.New(0., 1., 2.),
+			vector3.New(-3.25, 100.5, -0.001),
+		},
+		modeling.ScaleAttribute: {
+			vector3.New(-1., -2., -3.),
+			vector3.New(0., 1.5, -9.),
+		},
+		modeling.FDCAttribute: {
+			vector3.New(0., 0.5, 1.),
+			vector3.New(-1., -2., 2.),
+		},
+	}
+
+	for i := 0; i < shDim; i++ {
+		v := float64(i) / float64(shDim)
+		v3[fmt.Sprintf("SH_%d", i)] = []vector3.Float64{
+			vector3.New(v, -v, 0.5),
+			vector3.New(-0.5, 0., v),
+		}
+	}
+
+	return modeling.NewPointCloud(
+		map[string][]vector4.Float64{
+			modeling.RotationAttribute: {
+				vector4.New(0., 0., 0., 1.),
+				// Negative w should be flipped to its equivalent rotation
+				vector4.New(0.5, -0.5, 0.5, -0.5),
+			},
+		},
+		v3,
+		nil,
+		map[string][]float64{
+			modeling.OpacityAttribute: {0.25, 1.},
+		},
+	)
+}
+
+func assertCloudsInDelta(t *testing.T, expected, actual modeling.Mesh, shDim int) {
+	t.Helper()
+	require.Equal(t, expected.PrimitiveCount(), actual.PrimitiveCount())
+
+	for i := 0; i < expected.PrimitiveCount(); i++ {
+		assert.InDelta(t, 0., expected.Float3Attribute(modeling.PositionAttribute).At(i).Distance(actual.Float3Attribute(modeling.PositionAttribute).At(i)), 0.001)
+		assert.InDelta(t, 0., expected.Float3Attribute(modeling.ScaleAttribute).At(i).Distance(actual.Float3Attribute(modeling.ScaleAttribute).At(i)), 0.06)
+		assert.InDelta(t, 0., expected.Float3Attribute(modeling.FDCAttribute).At(i).Distance(actual.Float3Attribute(modeling.FDCAttribute).At(i)), 0.05)
+		assert.InDelta(t, expected.Float1Attribute(modeling.OpacityAttribute).At(i), actual.Float1Attribute(modeling.OpacityAttribute).At(i), 0.005)
+
+		// q and -q are the same rotation
+		rotExpected := expected.Float4Attribute(modeling.RotationAttribute).At(i)
+		rotActual := actual.Float4Attribute(modeling.RotationAttribute).At(i)
+		assert.InDelta(t, 1., abs(rotExpected.Dot(rotActual)), 0.01)
+
+		for d := 0; d < shDim; d++ {
+			attr := fmt.Sprintf("SH_%d", d)
+			assert.InDelta(t, 0., expected.Float3Attribute(attr).At(i).Distance(actual.Float3Attribute(attr).At(i)), 0.01)
+		}
+	}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		shDim    int
+		shDegree uint8
+	}{
+		"degree 0": {shDim: 0, shDegree: 0},
+		"degree 1": {shDim: 3, shDegree: 1},
+		"degree 2": {shDim: 8, shDegree: 2},
+		"degree 3": {shDim: 15, shDegree: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			cloud := testCloud(tc.shDim)
+			buf := &bytes.Buffer{}
+
+			// ACT ============================================================
+			err := spz.Write(cloud, buf)
+			require.NoError(t, err)
+			out, readErr := spz.Read(bytes.NewReader(buf.Bytes()))
+
+			// ASSERT =========================================================
+			require.NoError(t, readErr)
+			assert.Equal(t, uint32(2), out.Header.NumPoints)
+			assert.Equal(t, tc.shDegree, out.Header.ShDegree)
+			assert.Equal(t, spz.DefaultFractionalBits, out.Header.FractionalBits)
+			assertCloudsInDelta(t, cloud, out.Mesh, tc.shDim)
+		})
+	}
+}
+
+func TestWrite_Load(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := testCloud(3)
+	path := filepath.Join(t.TempDir(), "cloud.spz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	// ACT ====================================================================
+	writeErr := spz.WriteWithOptions(cloud, f, &spz.WriterOptions{FractionalBits: 16})
+	require.NoError(t, f.Close())
+	out, loadErr := spz.Load(path)
+
+	// ASSERT =================================================================
+	require.NoError(t, writeErr)
+	require.NoError(t, loadErr)
+	assert.Equal(t, uint8(16), out.Header.FractionalBits)
+	assertCloudsInDelta(t, cloud, out.Mesh, 3)
+}
+
+func TestWrite_PositionOutOfRange(t *testing.T) {
+	cloud := modeling.NewPointCloud(
+		map[string][]vector4.Float64{
+			modeling.RotationAttribute: {vector4.New(0., 0., 0., 1.)},
+		},
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {vector3.New(0., 5000., 0.)},
+			modeling.ScaleAttribute:    {vector3.New(0., 0., 0.)},
+			modeling.FDCAttribute:      {vector3.New(0., 0., 0.)},
+		},
+		nil,
+		map[string][]float64{
+			modeling.OpacityAttribute: {1},
+		},
+	)
+
+	err := spz.Write(cloud, &bytes.Buffer{})
+	assert.EqualError(t, err, "position 0 component 1 (5000) can not be represented with 12 fractional bits")
+}
+
+func TestWrite_ErrorOnMissingAttributes(t *testing.T) {
+	in := modeling.
+		NewMesh(modeling.PointTopology, []int{0}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{vector3.Zero[float64]()})
+	err := spz.Write(in, &bytes.Buffer{})
+	assert.EqualError(t, err, "required attribute not present on mesh: Scale")
+}
+
+func TestWrite_EmptyCloud(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, spz.Write(modeling.EmptyPointcloud(), buf))
+
+	out, err := spz.Read(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), out.Header.NumPoints)
+	assert.Equal(t, 0, out.Mesh.PrimitiveCount())
+}
@@ -0,0 +1,246 @@
+package stl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format of the data contained within the STL file
+type Format string
+
+const (
+	BinaryFormat Format = "binary"
+	ASCIIFormat  Format = "ascii"
+)
+
+// Solid is a named collection of triangles found within an ASCII STL file
+type Solid struct {
+	Name      string
+	Triangles []Triangle
+}
+
+// DetectFormat determines whether the contents of an STL file are ASCII or
+// binary. Some binary files start their header with "solid" too, so the data
+// is only considered ASCII if its size doesn't line up with the triangle
+// count of a binary file.
+func DetectFormat(data []byte) Format {
+	if len(data) >= 84 {
+		count := uint64(data[80]) | uint64(data[81])<<8 | uint64(data[82])<<16 | uint64(data[83])<<24
+		if 84+(count*50) == uint64(len(data)) {
+			return BinaryFormat
+		}
+	}
+
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) >= 5 && strings.EqualFold(string(trimmed[:5]), "solid") {
+		return ASCIIFormat
+	}
+
+	return BinaryFormat
+}
+
+type asciiReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// next returns the fields of the next non-empty line, with the keyword
+// lowercased
+func (ar *asciiReader) next() ([]string, error) {
+	for ar.scanner.Scan() {
+		ar.line++
+		fields := strings.Fields(ar.scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = strings.ToLower(fields[0])
+		return fields, nil
+	}
+
+	if err := ar.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (ar *asciiReader) errorf(format string, a ...any) error {
+	return fmt.Errorf("line %d: "+format, append([]any{ar.line}, a...)...)
+}
+
+// expect reads the next line and ensures it begins with the keywords provided
+func (ar *asciiReader) expect(keywords ...string) ([]string, error) {
+	fields, err := ar.next()
+	if err == io.EOF {
+		return nil, ar.errorf("unexpected end of file, expected '%s'", strings.Join(keywords, " "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, keyword := range keywords {
+		if i >= len(fields) || strings.ToLower(fields[i]) != keyword {
+			return nil, ar.errorf("expected '%s', found '%s'", strings.Join(keywords, " "), strings.Join(fields, " "))
+		}
+	}
+	return fields[len(keywords):], nil
+}
+
+func (ar *asciiReader) vec(fields []string) (Vec, error) {
+	if len(fields) != 3 {
+		return Vec{}, ar.errorf("expected 3 components, found %d", len(fields))
+	}
+
+	var components [3]float32
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 32)
+		if err != nil {
+			return Vec{}, ar.errorf("unable to parse component %q: %w", field, err)
+		}
+		components[i] = float32(v)
+	}
+	return Vec{X: components[0], Y: components[1], Z: components[2]}, nil
+}
+
+func (ar *asciiReader) facet(fields []string) (Triangle, error) {
+	tri := Triangle{}
+
+	// The normal is technically required, but some exporters leave it out
+	if len(fields) > 1 {
+		if strings.ToLower(fields[1]) != "normal" {
+			return tri, ar.errorf("expected 'facet normal', found '%s'", strings.Join(fields, " "))
+		}
+
+		normal, err := ar.vec(fields[2:])
+		if err != nil {
+			return tri, err
+		}
+		tri.Normal = normal
+	}
+
+	if _, err := ar.expect("outer", "loop"); err != nil {
+		return tri, err
+	}
+
+	vertices := []*Vec{&tri.Vertex1, &tri.Vertex2, &tri.Vertex3}
+	for _, vertex := range vertices {
+		components, err := ar.expect("vertex")
+		if err != nil {
+			return tri, err
+		}
+
+		if *vertex, err = ar.vec(components); err != nil {
+			return tri, err
+		}
+	}
+
+	if _, err := ar.expect("endloop"); err != nil {
+		return tri, err
+	}
+
+	if _, err := ar.expect("endfacet"); err != nil {
+		return tri, err
+	}
+
+	return tri, nil
+}
+
+func (ar *asciiReader) solid(fields []string) (Solid, error) {
+	solid := Solid{
+		Name:      strings.Join(fields[1:], " "),
+		Triangles: make([]Triangle, 0),
+	}
+
+	for {
+		fields, err := ar.next()
+		if err == io.EOF {
+			return solid, ar.errorf("unexpected end of file, expected 'endsolid'")
+		}
+		if err != nil {
+			return solid, err
+		}
+
+		switch fields[0] {
+		case "endsolid":
+			return solid, nil
+
+		case "facet":
+			tri, err := ar.facet(fields)
+			if err != nil {
+				return solid, err
+			}
+			solid.Triangles = append(solid.Triangles, tri)
+
+		default:
+			return solid, ar.errorf("expected 'facet' or 'endsolid', found '%s'", strings.Join(fields, " "))
+		}
+	}
+}
+
+// ReadASCII parses every solid found within the ASCII STL data
+func ReadASCII(in io.Reader) ([]Solid, error) {
+	reader := &asciiReader{scanner: bufio.NewScanner(in)}
+	solids := make([]Solid, 0, 1)
+
+	for {
+		fields, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if fields[0] != "solid" {
+			return nil, reader.errorf("expected 'solid', found '%s'", strings.Join(fields, " "))
+		}
+
+		solid, err := reader.solid(fields)
+		if err != nil {
+			return nil, err
+		}
+		solids = append(solids, solid)
+	}
+
+	if len(solids) == 0 {
+		return nil, fmt.Errorf("no solids found")
+	}
+
+	return solids, nil
+}
+
+func formatComponent(v float32) string {
+	return strconv.FormatFloat(float64(v), 'g', -1, 32)
+}
+
+func writeASCIIVec(out *bufio.Writer, prefix string, v Vec) {
+	out.WriteString(prefix)
+	out.WriteString(formatComponent(v.X))
+	out.WriteByte(' ')
+	out.WriteString(formatComponent(v.Y))
+	out.WriteByte(' ')
+	out.WriteString(formatComponent(v.Z))
+	out.WriteByte('\n')
+}
+
+// WriteASCII writes the solids out in the ASCII STL format
+func WriteASCII(out io.Writer, solids []Solid) error {
+	writer := bufio.NewWriter(out)
+	for _, solid := range solids {
+		writer.WriteString(strings.TrimSpace("solid "+solid.Name) + "\n")
+		for _, tri := range solid.Triangles {
+			writeASCIIVec(writer, "  facet normal ", tri.Normal)
+			writer.WriteString("    outer loop\n")
+			writeASCIIVec(writer, "      vertex ", tri.Vertex1)
+			writeASCIIVec(writer, "      vertex ", tri.Vertex2)
+			writeASCIIVec(writer, "      vertex ", tri.Vertex3)
+			writer.WriteString("    endloop\n")
+			writer.WriteString("  endfacet\n")
+		}
+		writer.WriteString(strings.TrimSpace("endsolid "+solid.Name) + "\n")
+	}
+	return writer.Flush()
+}
@@ -7,7 +7,14 @@ import (
 	"github.com/EliCDavis/polyform/modeling"
 )
 
+// Save writes the mesh to the filepath in the binary STL format
 func Save(fp string, m modeling.Mesh) error {
+	return SaveWithOptions(fp, m, nil)
+}
+
+// SaveWithOptions writes the mesh to the filepath in the STL format specified
+// by the options
+func SaveWithOptions(fp string, m modeling.Mesh, options *WriterOptions) error {
 	f, err := os.Create(fp)
 	if err != nil {
 		return err
@@ -15,13 +22,15 @@ func Save(fp string, m modeling.Mesh) error {
 	defer f.Close()
 
 	writer := bufio.NewWriter(f)
-	if err := WriteMesh(writer, m); err != nil {
+	if err := WriteMeshWithOptions(writer, m, options); err != nil {
 		return err
 	}
 
 	return writer.Flush()
 }
 
+// Load builds a mesh from the STL file found at the filepath, detecting
+// whether it is in the ASCII or binary format
 func Load(fp string) (*modeling.Mesh, error) {
 	f, err := os.Open(fp)
 	if err != nil {
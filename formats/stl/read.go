@@ -1,6 +1,7 @@
 package stl
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"github.com/EliCDavis/vector/vector3"
 )
 
+// Read parses binary STL data
 func Read(in io.Reader) (*Binary, error) {
 
 	header := new(Header)
@@ -32,23 +34,61 @@ func Read(in io.Reader) (*Binary, error) {
 	}, nil
 }
 
+// ReadMesh builds a mesh from the STL data, detecting whether it is in the
+// ASCII or binary format. All solids within an ASCII file are combined into a
+// single mesh.
 func ReadMesh(in io.Reader) (*modeling.Mesh, error) {
-	bin, err := Read(in)
+	return ReadMeshWithFormat(in, "")
+}
+
+// ReadMeshWithFormat builds a mesh from STL data in the format provided. The
+// format is detected from the data when left empty.
+func ReadMeshWithFormat(in io.Reader, format Format) (*modeling.Mesh, error) {
+	data, err := io.ReadAll(in)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(bin.Triangles) == 0 {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+
+	switch format {
+	case BinaryFormat:
+		bin, err := Read(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return trianglesToMesh(bin.Triangles), nil
+
+	case ASCIIFormat:
+		solids, err := ReadASCII(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		tris := make([]Triangle, 0)
+		for _, solid := range solids {
+			tris = append(tris, solid.Triangles...)
+		}
+		return trianglesToMesh(tris), nil
+	}
+
+	return nil, fmt.Errorf("unrecognized stl format: %q", format)
+}
+
+func trianglesToMesh(tris []Triangle) *modeling.Mesh {
+	if len(tris) == 0 {
 		empty := modeling.EmptyMesh(modeling.TriangleTopology)
-		return &empty, nil
+		return &empty
 	}
 
-	indices := make([]int, len(bin.Triangles)*3)
-	position := make([]vector3.Float64, len(bin.Triangles)*3)
-	normals := make([]vector3.Float64, len(bin.Triangles)*3)
+	indices := make([]int, len(tris)*3)
+	position := make([]vector3.Float64, len(tris)*3)
+	normals := make([]vector3.Float64, len(tris)*3)
 	normalExists := false
 
-	for i, tri := range bin.Triangles {
+	for i, tri := range tris {
 		start := i * 3
 		indices[start] = start
 		indices[start+1] = start + 1
@@ -81,5 +121,5 @@ func ReadMesh(in io.Reader) (*modeling.Mesh, error) {
 		mesh = mesh.SetFloat3Attribute(modeling.NormalAttribute, normals)
 	}
 
-	return &mesh, nil
+	return &mesh
 }
@@ -2,6 +2,7 @@ package stl_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/EliCDavis/polyform/formats/stl"
@@ -68,3 +69,131 @@ func TestWriteReadWithNormals(t *testing.T) {
 	assert.Equal(t, vector3.New(0., 0., 1.), cubeBack.Tri(0).P2Vec3Attr(modeling.NormalAttribute))
 	assert.Equal(t, vector3.New(0., 0., 1.), cubeBack.Tri(0).P3Vec3Attr(modeling.NormalAttribute))
 }
+
+const multiSolidASCII = `solid first part
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1 0 0
+      vertex 1 1 0
+    endloop
+  endfacet
+endsolid first part
+
+SOLID second
+  FACET NORMAL 0 0 0
+    OUTER LOOP
+      VERTEX 0 0 1.5e1
+      VERTEX -1 0 15
+      VERTEX -1 -1 15
+    ENDLOOP
+  ENDFACET
+ENDSOLID second
+`
+
+func TestReadASCII_MultipleSolids(t *testing.T) {
+	// ACT ====================================================================
+	solids, err := stl.ReadASCII(strings.NewReader(multiSolidASCII))
+
+	// ASSERT =================================================================
+	assert.NoError(t, err)
+	assert.Len(t, solids, 2)
+	assert.Equal(t, "first part", solids[0].Name)
+	assert.Equal(t, "second", solids[1].Name)
+	assert.Equal(t, stl.Vec{X: 0, Y: 0, Z: 1}, solids[0].Triangles[0].Normal)
+	assert.Equal(t, stl.Vec{X: -1, Y: -1, Z: 15}, solids[1].Triangles[0].Vertex3)
+}
+
+func TestReadMesh_DetectsASCII(t *testing.T) {
+	// ACT ====================================================================
+	mesh, err := stl.ReadMesh(strings.NewReader(multiSolidASCII))
+
+	// ASSERT =================================================================
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mesh.PrimitiveCount())
+	assert.Equal(t, vector3.New(0., 0., 15.), mesh.Tri(1).P1Vec3Attr(modeling.PositionAttribute))
+
+	// Second solid's missing normal is computed from its winding
+	assert.Equal(t, vector3.New(0., 0., 1.), mesh.Tri(0).P1Vec3Attr(modeling.NormalAttribute))
+	assert.Equal(t, vector3.New(0., 0., 1.), mesh.Tri(1).P1Vec3Attr(modeling.NormalAttribute))
+}
+
+func TestReadASCII_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		err   string
+	}{
+		"empty": {
+			input: "",
+			err:   "no solids found",
+		},
+		"missing endsolid": {
+			input: "solid a\n",
+			err:   "line 1: unexpected end of file, expected 'endsolid'",
+		},
+		"bad vertex": {
+			input: "solid a\nfacet normal 0 0 0\nouter loop\nvertex 0 0\n",
+			err:   "line 4: expected 3 components, found 2",
+		},
+		"bad number": {
+			input: "solid a\nfacet normal 0 0 x\n",
+			err:   "line 2: unable to parse component \"x\": strconv.ParseFloat: parsing \"x\": invalid syntax",
+		},
+		"unexpected keyword": {
+			input: "solid a\nfacet normal 0 0 0\nouter loop\nvertex 0 0 0\nendloop\n",
+			err:   "line 5: expected 'vertex', found 'endloop'",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := stl.ReadASCII(strings.NewReader(tc.input))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestWriteReadASCII(t *testing.T) {
+	// ARRANGE ================================================================
+	tri := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1.25, 0., 0.),
+			vector3.New(1., 1., -0.5),
+		})
+	buf := &bytes.Buffer{}
+
+	// ACT ====================================================================
+	err := stl.WriteMeshWithOptions(buf, tri, &stl.WriterOptions{Format: stl.ASCIIFormat, SolidName: "tri"})
+	assert.NoError(t, err)
+	written := buf.String()
+	back, readErr := stl.ReadMesh(buf)
+
+	// ASSERT =================================================================
+	assert.NoError(t, readErr)
+	assert.True(t, strings.HasPrefix(written, "solid tri\n"))
+	assert.True(t, strings.HasSuffix(written, "endsolid tri\n"))
+	assert.Equal(t, 1, back.PrimitiveCount())
+	assert.Equal(t, vector3.New(1.25, 0., 0.), back.Tri(0).P2Vec3Attr(modeling.PositionAttribute))
+	assert.Equal(t, vector3.New(1., 1., -0.5), back.Tri(0).P3Vec3Attr(modeling.PositionAttribute))
+}
+
+func TestDetectFormat_BinaryStartingWithSolid(t *testing.T) {
+	// ARRANGE ================================================================
+	header := stl.Header{}
+	copy(header[:], "solid exported by a tool that should know better")
+	buf := &bytes.Buffer{}
+	assert.NoError(t, stl.Write(buf, stl.Binary{
+		Header:    header,
+		Triangles: []stl.Triangle{{Vertex2: stl.Vec{X: 1}, Vertex3: stl.Vec{Y: 1}}},
+	}))
+
+	// ACT ====================================================================
+	format := stl.DetectFormat(buf.Bytes())
+	mesh, err := stl.ReadMesh(buf)
+
+	// ASSERT =================================================================
+	assert.Equal(t, stl.BinaryFormat, format)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mesh.PrimitiveCount())
+}
@@ -3,6 +3,7 @@ package stl
 import (
 	"bytes"
 	"io"
+	"strings"
 
 	"github.com/EliCDavis/polyform/generator"
 	"github.com/EliCDavis/polyform/generator/manifest"
@@ -18,10 +19,16 @@ func init() {
 	generator.RegisterTypes(factory)
 }
 
+// nodeFormat interprets a node's format input, leaving it empty when unset
+func nodeFormat(format nodes.Output[string]) Format {
+	return Format(strings.ToLower(strings.TrimSpace(nodes.TryGetOutputValue(format, ""))))
+}
+
 type ReadNode = nodes.Struct[ReadNodeData]
 
 type ReadNodeData struct {
-	Data nodes.Output[[]byte]
+	Data   nodes.Output[[]byte]
+	Format nodes.Output[string] `description:"Either 'ascii' or 'binary', detected from the data when left unset"`
 }
 
 func (gad ReadNodeData) Out() nodes.StructOutput[modeling.Mesh] {
@@ -34,7 +41,7 @@ func (gad ReadNodeData) Out() nodes.StructOutput[modeling.Mesh] {
 		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
 	}
 
-	cloud, err := ReadMesh(bytes.NewReader(data))
+	cloud, err := ReadMeshWithFormat(bytes.NewReader(data), nodeFormat(gad.Format))
 	if err != nil {
 		out := nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
 		out.LogError(err)
@@ -47,11 +54,12 @@ func (gad ReadNodeData) Out() nodes.StructOutput[modeling.Mesh] {
 // ============================================================================
 
 type Artifact struct {
-	Mesh modeling.Mesh
+	Mesh   modeling.Mesh
+	Format Format
 }
 
 func (sa Artifact) Write(w io.Writer) error {
-	return WriteMesh(w, sa.Mesh)
+	return WriteMeshWithOptions(w, sa.Mesh, &WriterOptions{Format: sa.Format})
 }
 
 func (Artifact) Mime() string {
@@ -61,10 +69,11 @@ func (Artifact) Mime() string {
 type ManifestNode = nodes.Struct[ManifestNodeData]
 
 type ManifestNodeData struct {
-	Mesh nodes.Output[modeling.Mesh]
+	Mesh   nodes.Output[modeling.Mesh]
+	Format nodes.Output[string] `description:"Either 'ascii' or 'binary', defaults to binary"`
 }
 
 func (pn ManifestNodeData) Out() nodes.StructOutput[manifest.Manifest] {
-	entry := manifest.Entry{Artifact: Artifact{Mesh: pn.Mesh.Value()}}
+	entry := manifest.Entry{Artifact: Artifact{Mesh: pn.Mesh.Value(), Format: nodeFormat(pn.Format)}}
 	return nodes.NewStructOutput(manifest.SingleEntryManifest("model.stl", entry))
 }
@@ -24,15 +24,46 @@ func Write(out io.Writer, bin Binary) error {
 	return nil
 }
 
+type WriterOptions struct {
+	// Format to write the mesh in, defaults to binary
+	Format Format
+
+	// Name of the solid, only used by the ASCII format
+	SolidName string
+}
+
+// WriteMesh writes the mesh out in the binary STL format
 func WriteMesh(out io.Writer, m modeling.Mesh) error {
+	return WriteMeshWithOptions(out, m, nil)
+}
+
+// WriteMeshWithOptions writes the mesh out in the STL format specified by
+// the options
+func WriteMeshWithOptions(out io.Writer, m modeling.Mesh, options *WriterOptions) error {
 	if m.Topology() != modeling.TriangleTopology {
 		panic(fmt.Errorf("stl format does not supoprt %s topology", m.Topology()))
 	}
 
+	opts := WriterOptions{}
+	if options != nil {
+		opts = *options
+	}
+
+	tris := meshToTriangles(m)
+	switch opts.Format {
+	case "", BinaryFormat:
+		return Write(out, Binary{Triangles: tris})
+
+	case ASCIIFormat:
+		return WriteASCII(out, []Solid{{Name: opts.SolidName, Triangles: tris}})
+	}
+
+	return fmt.Errorf("unrecognized stl format: %q", opts.Format)
+}
+
+func meshToTriangles(m modeling.Mesh) []Triangle {
 	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
-		return Write(out, Binary{
-			Triangles: make([]Triangle, 0),
-		})
+		return make([]Triangle, 0)
 	}
 
 	count := m.PrimitiveCount()
@@ -84,7 +115,5 @@ func WriteMesh(out io.Writer, m modeling.Mesh) error {
 		}
 	}
 
-	return Write(out, Binary{
-		Triangles: tris,
-	})
+	return tris
 }
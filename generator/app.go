@@ -102,7 +102,10 @@ func (a App) Generate(outputPath string) error {
 	for _, manifestName := range a.graphInstance.ProducerNames() {
 		manifestFolder := path.Join(outputPath, manifestName)
 
-		manifest := a.graphInstance.Manifest(manifestName)
+		manifest, err := a.graphInstance.Manifest(manifestName)
+		if err != nil {
+			return fmt.Errorf("unable to produce %q: %w", manifestName, err)
+		}
 		entries := manifest.Entries
 
 		for entryName, entry := range entries {
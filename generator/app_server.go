@@ -2,8 +2,10 @@ package generator
 
 import (
 	"bufio"
+	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -195,15 +197,25 @@ func (as *AppServer) ProducerEndpoint(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Cross-Origin-Embedder-Policy", "require-corp")
 
 	// params, _ := url.ParseQuery(r.URL.RawQuery)
-	err := as.writeProducerDataToRequest(path.Base(path.Dir(r.URL.Path)), path.Base(r.URL.Path), w)
+	err := as.writeProducerDataToRequest(r.Context(), path.Base(path.Dir(r.URL.Path)), path.Base(r.URL.Path), w)
 	if err != nil {
 		log.Print(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(evaluationErrorStatus(err))
 		writeJSONError(w, err)
 	}
 }
 
-func (as *AppServer) writeProducerDataToRequest(producerToLoad, file string, w http.ResponseWriter) (err error) {
+// evaluationErrorStatus picks the status code to respond with when we fail to
+// evaluate the graph. Evaluations get cancelled when the graph is modified
+// out from under them, which is a conflict rather than something breaking.
+func evaluationErrorStatus(err error) int {
+	if errors.Is(err, context.Canceled) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (as *AppServer) writeProducerDataToRequest(ctx context.Context, producerToLoad, file string, w http.ResponseWriter) (err error) {
 	defer func() {
 		if recErr := recover(); recErr != nil {
 			fmt.Println("stacktrace from panic: \n" + string(debug.Stack()))
@@ -211,8 +223,16 @@ func (as *AppServer) writeProducerDataToRequest(producerToLoad, file string, w h
 		}
 	}()
 
-	manifest := as.app.graphInstance.Manifest(producerToLoad)
-	artifact := manifest.Entries[file].Artifact
+	manifest, err := as.app.graphInstance.ManifestContext(ctx, producerToLoad)
+	if err != nil {
+		return
+	}
+
+	entry, ok := manifest.Entries[file]
+	if !ok {
+		return fmt.Errorf("producer %q does not contain an entry %q", producerToLoad, file)
+	}
+	artifact := entry.Artifact
 
 	w.Header().Set("Content-Type", artifact.Mime())
 
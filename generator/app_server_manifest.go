@@ -57,7 +57,10 @@ func (as *AppServer) writeManifest(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	manifest := resolvedNode.output.Value()
+	manifest, err := graph.OutputValueContext(r.Context(), as.app.graphInstance, resolvedNode.output)
+	if err != nil {
+		return err
+	}
 
 	// We're just trying to get the manifest of the node's output manifest
 	if resolvedNode.remainingUrl == "" {
@@ -103,7 +106,7 @@ func (as *AppServer) ManifestEndpoint(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.Print(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(evaluationErrorStatus(err))
 		writeJSONError(w, err)
 	}
 }
@@ -14,16 +14,20 @@ import (
 	"github.com/EliCDavis/polyform/nodes"
 )
 
-func writeManifestToZip(graph *graph.Instance, zw *zip.Writer, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
-	manifest := out.Value()
-	manifestName := fmt.Sprintf("%s-%s", graph.NodeId(node), out.Name())
+func writeManifestToZip(graphInstance *graph.Instance, zw *zip.Writer, node nodes.Node, out nodes.Output[manifest.Manifest]) error {
+	manifest, err := graph.OutputValue(graphInstance, out)
+	if err != nil {
+		return err
+	}
+
+	manifestName := fmt.Sprintf("%s-%s", graphInstance.NodeId(node), out.Name())
 
 	// TODO - There's gonna be an issue if anyone ever names a manifest
 	// output the same name as a nodeID-port combo
 	//
 	// IE: Naming a manifest "Node-8-Out", could conflict with Node-8's
 	// out port
-	if name, named := graph.IsPortNamed(node, out.Name()); named {
+	if name, named := graphInstance.IsPortNamed(node, out.Name()); named {
 		manifestName = name
 	}
 
@@ -42,8 +46,8 @@ func writeManifestToZip(graph *graph.Instance, zw *zip.Writer, node nodes.Node,
 	return nil
 }
 
-func writeGraphManifestsToZip(graph *graph.Instance, zw *zip.Writer) error {
-	if graph == nil {
+func writeGraphManifestsToZip(graphInstance *graph.Instance, zw *zip.Writer) error {
+	if graphInstance == nil {
 		panic("can't zip nil graph")
 	}
 
@@ -51,17 +55,32 @@ func writeGraphManifestsToZip(graph *graph.Instance, zw *zip.Writer) error {
 		panic("can't write to nil zip writer")
 	}
 
-	nodeIds := graph.NodeIds()
+	nodeIds := graphInstance.NodeIds()
 
+	manifestOutputs := make([]nodes.Output[manifest.Manifest], 0)
 	for _, nodeId := range nodeIds {
-		node := graph.Node(nodeId)
+		node := graphInstance.Node(nodeId)
 		outputs := node.Outputs()
 		for _, out := range outputs {
 			manifestOut, ok := out.(nodes.Output[manifest.Manifest])
 			if !ok {
 				continue
 			}
-			writeManifestToZip(graph, zw, node, manifestOut)
+			manifestOutputs = append(manifestOutputs, manifestOut)
+		}
+	}
+
+	// Evaluate everything up front, so a failing node doesn't leave us with
+	// half a zip already written out
+	for _, out := range manifestOutputs {
+		if _, err := graph.OutputValue(graphInstance, out); err != nil {
+			return err
+		}
+	}
+
+	for _, out := range manifestOutputs {
+		if err := writeManifestToZip(graphInstance, zw, out.Node(), out); err != nil {
+			return err
 		}
 	}
 
@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"sort"
@@ -27,10 +29,20 @@ type Instance struct {
 
 	// TODO: Make this a lock across the entire instance
 	producerLock gsync.Mutex
+
+	// Cancelled and replaced every time the model changes, so evaluations of
+	// a stale version of the graph can stop early
+	modelCtx       context.Context
+	cancelModelCtx context.CancelFunc
+	modelCtxLock   gsync.Mutex
 }
 
 func New(typeFactory *refutil.TypeFactory) *Instance {
+	modelCtx, cancelModelCtx := context.WithCancel(context.Background())
 	return &Instance{
+		modelCtx:       modelCtx,
+		cancelModelCtx: cancelModelCtx,
+
 		typeFactory: typeFactory,
 
 		nodeIDs:  make(map[nodes.Node]string),
@@ -63,6 +75,44 @@ func (i *Instance) NodeIds() []string {
 func (i *Instance) incModelVersion() {
 	// TODO: Make thread safe
 	i.movelVersion++
+	i.cancelEvaluations()
+}
+
+// cancelEvaluations stops all evaluations currently running against the
+// graph
+func (i *Instance) cancelEvaluations() {
+	i.modelCtxLock.Lock()
+	defer i.modelCtxLock.Unlock()
+	i.cancelModelCtx()
+	i.modelCtx, i.cancelModelCtx = context.WithCancel(context.Background())
+}
+
+// beginModification cancels every evaluation running against the graph and
+// waits for them to let go of it, so the graph can be safely modified
+func (i *Instance) beginModification() {
+	i.cancelEvaluations()
+	i.producerLock.Lock()
+}
+
+// endModification marks the graph as changed and lets evaluations resume
+func (i *Instance) endModification() {
+	i.incModelVersion()
+	i.producerLock.Unlock()
+}
+
+// evaluationContext derives a context from the parent that is also cancelled
+// once the model changes
+func (i *Instance) evaluationContext(parent context.Context) (context.Context, context.CancelFunc) {
+	i.modelCtxLock.Lock()
+	modelCtx := i.modelCtx
+	i.modelCtxLock.Unlock()
+
+	ctx, cancel := context.WithCancel(parent)
+	stop := context.AfterFunc(modelCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
 }
 
 func (i *Instance) NodeInstanceSchema(node nodes.Node) schema.NodeInstance {
@@ -94,9 +144,17 @@ func (i *Instance) NodeInstanceSchema(node nodes.Node) schema.NodeInstance {
 	}
 
 	for outputPortName, outputPort := range node.Outputs() {
-		nodeInstance.Output[outputPortName] = schema.NodeInstanceOutputPort{
+		outputSchema := schema.NodeInstanceOutputPort{
 			Version: outputPort.Version(),
 		}
+
+		if errorOutput, ok := outputPort.(nodes.ErrorOutput); ok {
+			if err := i.wrapNodeError(errorOutput.CachedErr()); err != nil {
+				outputSchema.Error = err.Error()
+			}
+		}
+
+		nodeInstance.Output[outputPortName] = outputSchema
 	}
 
 	for inputPortName, inputPort := range node.Inputs() {
@@ -248,6 +306,9 @@ func (i *Instance) ApplyAppSchema(jsonPayload []byte) error {
 		return fmt.Errorf("unable to build a jbtf decoder: %w", err)
 	}
 
+	i.beginModification()
+	defer i.endModification()
+
 	i.Reset()
 	i.metadata.OverwriteData(appSchema.Metadata)
 
@@ -335,8 +396,6 @@ func (i *Instance) ApplyAppSchema(jsonPayload []byte) error {
 		}
 	}
 
-	i.incModelVersion()
-
 	return nil
 }
 
@@ -561,12 +620,10 @@ func (i *Instance) Parameter(nodeId string) Parameter {
 }
 
 func (i *Instance) UpdateParameter(nodeId string, data []byte) (bool, error) {
-	i.producerLock.Lock()
-	defer i.producerLock.Unlock()
+	i.beginModification()
+	defer i.endModification()
 
-	r, err := i.Parameter(nodeId).ApplyMessage(data)
-	i.incModelVersion()
-	return r, err
+	return i.Parameter(nodeId).ApplyMessage(data)
 }
 
 func (i *Instance) ParameterData(nodeId string) []byte {
@@ -588,6 +645,9 @@ func (i *Instance) DeleteMetadata(key string) {
 // CONNECTIONS ================================================================
 
 func (i *Instance) DeleteNodeInputConnection(nodeId, portName string) {
+	i.beginModification()
+	defer i.endModification()
+
 	node := i.Node(nodeId)
 
 	cleanPortName := portName
@@ -622,11 +682,11 @@ func (i *Instance) DeleteNodeInputConnection(nodeId, portName string) {
 		array.Remove(array.Value()[portIndex])
 
 	}
-
-	i.incModelVersion()
 }
 
 func (i *Instance) ConnectNodes(nodeOutId, outPortName, nodeInId, inPortName string) {
+	i.beginModification()
+	defer i.endModification()
 
 	cleanedInputName := inPortName
 	components := strings.Split(inPortName, ".")
@@ -660,13 +720,14 @@ func (i *Instance) ConnectNodes(nodeOutId, outPortName, nodeInId, inPortName str
 	} else {
 		panic(fmt.Errorf("can not determine type of node %q's input %q", nodeInId, cleanedInputName))
 	}
-
-	i.incModelVersion()
 }
 
 // PRODUCERS ==================================================================
 
 func (i *Instance) SetNodeAsProducer(nodeId, nodePort, producerName string) {
+	i.beginModification()
+	defer i.endModification()
+
 	producerNode := i.Node(nodeId)
 
 	if producerNode == nil {
@@ -685,7 +746,6 @@ func (i *Instance) SetNodeAsProducer(nodeId, nodePort, producerName string) {
 	}
 
 	i.namedManifests.NamePort(producerName, nodePort, producerNode, casted)
-	i.incModelVersion()
 }
 
 func (i *Instance) recursivelyRegisterNodeTypes(node nodes.Node) {
@@ -697,16 +757,63 @@ func (i *Instance) recursivelyRegisterNodeTypes(node nodes.Node) {
 	}
 }
 
-func (i *Instance) Manifest(producerName string) manifest.Manifest {
+func (i *Instance) Manifest(producerName string) (manifest.Manifest, error) {
+	return i.ManifestContext(context.Background(), producerName)
+}
+
+// ManifestContext evaluates the producer's manifest, giving up once the
+// context is done or the graph is modified
+func (i *Instance) ManifestContext(ctx context.Context, producerName string) (manifest.Manifest, error) {
 	producer, ok := i.namedManifests.namedPorts[producerName]
 	if !ok {
-		panic(fmt.Errorf("no producer registered for: %s", producerName))
+		return manifest.Manifest{}, fmt.Errorf("no producer registered for: %s", producerName)
 	}
 
+	ctx, cancel := i.evaluationContext(ctx)
+	defer cancel()
+
 	i.producerLock.Lock()
 	defer i.producerLock.Unlock()
 
-	return producer.port.Value()
+	return OutputValueContext(ctx, i, producer.port)
+}
+
+// OutputValue evaluates the output port, returning an error naming the node
+// responsible if the port, or anything it depends on, failed to evaluate
+func OutputValue[T any](i *Instance, port nodes.Output[T]) (T, error) {
+	return OutputValueContext(context.Background(), i, port)
+}
+
+// OutputValueContext is OutputValue, but stops evaluating the port once the
+// context is done or the graph is modified
+func OutputValueContext[T any](ctx context.Context, i *Instance, port nodes.Output[T]) (T, error) {
+	ctx, cancel := i.evaluationContext(ctx)
+	defer cancel()
+
+	if err := nodes.OutputErrorContext(ctx, port); err != nil {
+		var v T
+		return v, i.wrapNodeError(err)
+	}
+	return port.Value(), nil
+}
+
+// wrapNodeError prefixes node errors with the ID of the node the error
+// originated from
+func (i *Instance) wrapNodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var nodeErr nodes.NodeError
+	if !errors.As(err, &nodeErr) {
+		return err
+	}
+
+	id, ok := i.nodeIDs[nodeErr.Node]
+	if !ok {
+		return err
+	}
+	return fmt.Errorf("node %q: %w", id, err)
 }
 
 func (i *Instance) AddProducer(producerName string, producer nodes.Output[manifest.Manifest]) {
@@ -2,7 +2,9 @@ package graph_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"testing"
 
@@ -71,7 +73,8 @@ func TestInstance_AddProducer_InitializeParameters_Artifacts(t *testing.T) {
 	producerNames := instance.ProducerNames()
 	instance.InitializeParameters(flags)
 	assert.NoError(t, flags.Parse([]string{"-yeet", contentToSetViaFlag}))
-	textManifest := instance.Manifest("test.txt")
+	textManifest, manifestErr := instance.Manifest("test.txt")
+	assert.NoError(t, manifestErr)
 
 	buf := &bytes.Buffer{}
 	assert.NoError(t, textManifest.Entries[textManifest.Main].Artifact.Write(buf))
@@ -177,3 +180,131 @@ func TestInstance_AddProducer_InitializeParameters_Artifacts(t *testing.T) {
 	}
 }`, string(appSchemaData))
 }
+
+type FailingTestNode = nodes.Struct[FailingTestNodeData]
+
+type FailingTestNodeData struct {
+	In nodes.Output[string]
+}
+
+func (fn FailingTestNodeData) Out() nodes.StructOutput[string] {
+	out := nodes.NewStructOutput(fn.In.Value())
+	out.LogError(errors.New("bad file"))
+	return out
+}
+
+func TestInstance_Manifest_NodeError(t *testing.T) {
+	// ARRANGE ================================================================
+	instance := graph.New(&refutil.TypeFactory{})
+
+	failingNode := &FailingTestNode{
+		Data: FailingTestNodeData{
+			In: nodes.GetNodeOutputPort[string](&parameter.String{Name: "Path"}, "Value"),
+		},
+	}
+
+	textNode := &basics.TextNode{
+		Data: basics.TextNodeData{
+			In: nodes.GetNodeOutputPort[string](failingNode, "Out"),
+		},
+	}
+	instance.AddProducer("test.txt", nodes.GetNodeOutputPort[manifest.Manifest](textNode, "Out"))
+
+	// ACT ====================================================================
+	_, err := instance.Manifest("test.txt")
+	failingSchema := instance.NodeInstanceSchema(failingNode)
+	textSchema := instance.NodeInstanceSchema(textNode)
+	_, missingErr := instance.Manifest("missing.txt")
+
+	// ASSERT =================================================================
+	failingID := instance.NodeId(failingNode)
+	expected := `node "` + failingID + `": Failing Test output "Out": bad file`
+	assert.EqualError(t, err, expected)
+	assert.Equal(t, expected, failingSchema.Output["Out"].Error)
+	assert.Equal(t, expected, textSchema.Output["Out"].Error)
+	assert.EqualError(t, missingErr, "no producer registered for: missing.txt")
+}
+
+type BlockingTestNode = nodes.Struct[BlockingTestNodeData]
+
+type BlockingTestNodeData struct {
+	In nodes.Output[string]
+}
+
+var blockingTestNodeStarted = make(chan struct{}, 1)
+
+func (bn BlockingTestNodeData) Out(ctx context.Context) nodes.StructOutput[string] {
+	blockingTestNodeStarted <- struct{}{}
+	<-ctx.Done()
+
+	out := nodes.NewStructOutput(bn.In.Value())
+	out.LogError(ctx.Err())
+	return out
+}
+
+func TestInstance_ManifestContext_CancelledByModelChange(t *testing.T) {
+	// ARRANGE ================================================================
+	instance := graph.New(&refutil.TypeFactory{})
+	param := &parameter.String{Name: "Text", DefaultValue: "a"}
+	blockingNode := &BlockingTestNode{
+		Data: BlockingTestNodeData{
+			In: nodes.GetNodeOutputPort[string](param, "Value"),
+		},
+	}
+	textNode := &basics.TextNode{
+		Data: basics.TextNodeData{
+			In: nodes.GetNodeOutputPort[string](blockingNode, "Out"),
+		},
+	}
+	instance.AddProducer("test.txt", nodes.GetNodeOutputPort[manifest.Manifest](textNode, "Out"))
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := instance.ManifestContext(context.Background(), "test.txt")
+		errs <- err
+	}()
+	<-blockingTestNodeStarted
+
+	// ACT ====================================================================
+	_, updateErr := instance.UpdateParameter(instance.NodeId(param), []byte(`"b"`))
+	err := <-errs
+
+	// ASSERT =================================================================
+	assert.NoError(t, updateErr)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, `node "`+instance.NodeId(blockingNode)+`"`)
+	assert.Equal(t, uint32(1), instance.ModelVersion())
+}
+
+func TestInstance_ManifestContext_CancelledByConnectionChange(t *testing.T) {
+	// ARRANGE ================================================================
+	instance := graph.New(&refutil.TypeFactory{})
+	param := &parameter.String{Name: "Text", DefaultValue: "a"}
+	blockingNode := &BlockingTestNode{
+		Data: BlockingTestNodeData{
+			In: nodes.GetNodeOutputPort[string](param, "Value"),
+		},
+	}
+	textNode := &basics.TextNode{
+		Data: basics.TextNodeData{
+			In: nodes.GetNodeOutputPort[string](blockingNode, "Out"),
+		},
+	}
+	instance.AddProducer("test.txt", nodes.GetNodeOutputPort[manifest.Manifest](textNode, "Out"))
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := instance.ManifestContext(context.Background(), "test.txt")
+		errs <- err
+	}()
+	<-blockingTestNodeStarted
+
+	// ACT ====================================================================
+	instance.DeleteNodeInputConnection(instance.NodeId(blockingNode), "In")
+	err := <-errs
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, blockingNode.Data.In)
+	assert.Equal(t, uint32(1), instance.ModelVersion())
+}
@@ -6,7 +6,8 @@ type PortReference struct {
 }
 
 type NodeInstanceOutputPort struct {
-	Version int `json:"version"`
+	Version int    `json:"version"`
+	Error   string `json:"error,omitempty"`
 }
 
 type NodeInstance struct {
@@ -260,3 +260,45 @@ func FromMatrix(k mat.Matrix4x4) Quaternion {
 
 	return result.Normalize()
 }
+
+// Dot computes the 4 dimensional dot product between two quaternions
+func (q Quaternion) Dot(other Quaternion) float64 {
+	return q.v.Dot(other.v) + (q.w * other.w)
+}
+
+// Slerp spherically interpolates between two rotations, taking the shortest
+// path between them. A t of 0 returns a, while a t of 1 returns b.
+//
+// Resources Used:
+//
+//	https://en.wikipedia.org/wiki/Slerp
+func Slerp(a, b Quaternion, t float64) Quaternion {
+	a = a.Normalize()
+	b = b.Normalize()
+
+	dot := a.Dot(b)
+
+	// q and -q represent the same rotation, flip to take the shorter arc
+	if dot < 0 {
+		b = Quaternion{v: b.v.Scale(-1), w: -b.w}
+		dot = -dot
+	}
+
+	// Rotations are close enough that linear interpolation is stable
+	if dot > 0.9995 {
+		return Quaternion{
+			v: a.v.Add(b.v.Sub(a.v).Scale(t)),
+			w: a.w + ((b.w - a.w) * t),
+		}.Normalize()
+	}
+
+	theta := math.Acos(dot)
+	sinTheta := math.Sin(theta)
+	wa := math.Sin((1-t)*theta) / sinTheta
+	wb := math.Sin(t*theta) / sinTheta
+
+	return Quaternion{
+		v: a.v.Scale(wa).Add(b.v.Scale(wb)),
+		w: (a.w * wa) + (b.w * wb),
+	}
+}
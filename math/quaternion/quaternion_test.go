@@ -76,3 +76,26 @@ func TestQuaternion_Rotate(t *testing.T) {
 		})
 	}
 }
+
+func TestSlerp(t *testing.T) {
+	a := quaternion.FromTheta(0, vector3.New(0., 1., 0.))
+	b := quaternion.FromTheta(math.Pi/2, vector3.New(0., 1., 0.))
+
+	tests := map[string]struct {
+		t     float64
+		theta float64
+	}{
+		"start":   {t: 0, theta: 0},
+		"quarter": {t: 0.25, theta: math.Pi / 8},
+		"half":    {t: 0.5, theta: math.Pi / 4},
+		"end":     {t: 1, theta: math.Pi / 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := quaternion.FromTheta(tc.theta, vector3.New(0., 1., 0.))
+			got := quaternion.Slerp(a, b, tc.t)
+			assert.InDelta(t, 1., math.Abs(want.Dot(got)), 1e-9)
+		})
+	}
+}
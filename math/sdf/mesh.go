@@ -0,0 +1,191 @@
+package sdf
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/EliCDavis/polyform/math/sample"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Sign is determined using angle weighted pseudo-normals, from "Signed
+// Distance Computation Using the Angle Weighted Pseudonormal" by Bærentzen and
+// Aanæs. The side of the closest point's face, edge, or vertex the sample
+// sits on, judged by the pseudo-normal of that feature, tells whether it's
+// inside the mesh.
+
+type signedDistanceMesh struct {
+	positions []vector3.Float64
+
+	// Triangle corners, with vertices sharing a position sharing an index
+	tris [][3]int
+
+	faceNormals   []vector3.Float64
+	edgeNormals   map[modeling.Edge]vector3.Float64
+	vertexNormals []vector3.Float64
+}
+
+func newSignedDistanceMesh(m modeling.Mesh) signedDistanceMesh {
+	if m.Topology() != modeling.TriangleTopology {
+		panic(errors.New("signed distance requires a mesh with a triangle topology"))
+	}
+
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		panic(errors.New("signed distance requires a mesh with a position attribute"))
+	}
+
+	if m.PrimitiveCount() == 0 {
+		panic(errors.New("signed distance requires a mesh with at least one triangle"))
+	}
+
+	sdm := signedDistanceMesh{
+		positions:   make([]vector3.Float64, m.AttributeLength()),
+		edgeNormals: make(map[modeling.Edge]vector3.Float64),
+	}
+	m.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		sdm.positions[i] = v
+	})
+	remap := modeling.WeldPositions(sdm.positions)
+
+	indices := m.Indices()
+	sdm.tris = make([][3]int, m.PrimitiveCount())
+	sdm.faceNormals = make([]vector3.Float64, m.PrimitiveCount())
+	sdm.vertexNormals = make([]vector3.Float64, len(sdm.positions))
+	for i := range sdm.tris {
+		tri := [3]int{
+			remap[indices.At(i*3)],
+			remap[indices.At(i*3+1)],
+			remap[indices.At(i*3+2)],
+		}
+		sdm.tris[i] = tri
+
+		a, b, c := sdm.positions[tri[0]], sdm.positions[tri[1]], sdm.positions[tri[2]]
+		normal := b.Sub(a).Cross(c.Sub(a))
+		if normal.LengthSquared() == 0 {
+			continue
+		}
+		normal = normal.Normalized()
+		sdm.faceNormals[i] = normal
+
+		for corner := range 3 {
+			p := sdm.positions[tri[corner]]
+			e1 := sdm.positions[tri[(corner+1)%3]].Sub(p).Normalized()
+			e2 := sdm.positions[tri[(corner+2)%3]].Sub(p).Normalized()
+			angle := math.Acos(math.Max(-1, math.Min(1, e1.Dot(e2))))
+			sdm.vertexNormals[tri[corner]] = sdm.vertexNormals[tri[corner]].Add(normal.Scale(angle))
+
+			edge := modeling.NewEdge(tri[corner], tri[(corner+1)%3])
+			sdm.edgeNormals[edge] = sdm.edgeNormals[edge].Add(normal)
+		}
+	}
+
+	return sdm
+}
+
+// pseudoNormal is the normal of the feature of the triangle the point lies
+// on, whether that's a vertex, an edge, or the face itself
+func (sdm signedDistanceMesh) pseudoNormal(triangle int, point vector3.Float64) vector3.Float64 {
+	tri := sdm.tris[triangle]
+	a, b, c := sdm.positions[tri[0]], sdm.positions[tri[1]], sdm.positions[tri[2]]
+
+	// Barycentric coordinates of the point
+	v0, v1, v2 := b.Sub(a), c.Sub(a), point.Sub(a)
+	d00, d01, d11 := v0.Dot(v0), v0.Dot(v1), v1.Dot(v1)
+	d20, d21 := v2.Dot(v0), v2.Dot(v1)
+	denom := d00*d11 - d01*d01
+	if denom == 0 {
+		return sdm.faceNormals[triangle]
+	}
+	v := (d11*d20 - d01*d21) / denom
+	w := (d00*d21 - d01*d20) / denom
+	weights := [3]float64{1 - v - w, v, w}
+
+	const epsilon = 1e-6
+	zeros := make([]int, 0, 3)
+	for i, weight := range weights {
+		if weight < epsilon {
+			zeros = append(zeros, i)
+		}
+	}
+
+	switch len(zeros) {
+	case 1:
+		// Opposite the corner with no weight
+		return sdm.edgeNormals[modeling.NewEdge(tri[(zeros[0]+1)%3], tri[(zeros[0]+2)%3])]
+
+	case 2:
+		return sdm.vertexNormals[tri[3-zeros[0]-zeros[1]]]
+	}
+	return sdm.faceNormals[triangle]
+}
+
+// Mesh builds a field of the exact signed distance to the surface of a
+// closed triangle mesh, negative inside and positive outside. Faces should
+// wind counter-clockwise when viewed from outside the mesh. Vertices sharing
+// a position are treated as the same vertex, so meshes split along seams
+// still count as closed. Lookups are accelerated by the mesh's octree, and
+// the field is safe to sample from multiple goroutines.
+func Mesh(m modeling.Mesh) sample.Vec3ToFloat {
+	sdm := newSignedDistanceMesh(m)
+	tree := m.OctTree()
+
+	return func(v vector3.Float64) float64 {
+		triangle, closest := tree.ClosestPoint(v)
+		dist := v.Distance(closest)
+		if sdm.pseudoNormal(triangle, closest).Dot(v.Sub(closest)) < 0 {
+			return -dist
+		}
+		return dist
+	}
+}
+
+// CachedMesh builds the same field as Mesh, but only computes exact
+// distances at the corners of a grid of cells the size provided, blending
+// between them for everything in between. Corners are computed the first time
+// a sample lands in one of the cells they touch and are kept for every sample
+// after, so repeatedly sampling the same region, as marching cubes does,
+// only pays for each distance once. Smaller cells follow the surface more
+// closely at the cost of memory.
+func CachedMesh(m modeling.Mesh, cellSize float64) sample.Vec3ToFloat {
+	if cellSize <= 0 {
+		panic(errors.New("signed distance cache requires a cell size greater than 0"))
+	}
+
+	exact := Mesh(m)
+
+	var lock sync.RWMutex
+	corners := make(map[vector3.Int]float64)
+	corner := func(c vector3.Int) float64 {
+		lock.RLock()
+		dist, ok := corners[c]
+		lock.RUnlock()
+		if ok {
+			return dist
+		}
+
+		dist = exact(c.ToFloat64().Scale(cellSize))
+		lock.Lock()
+		corners[c] = dist
+		lock.Unlock()
+		return dist
+	}
+
+	return func(v vector3.Float64) float64 {
+		cell := v.DivByConstant(cellSize)
+		base := cell.FloorToInt()
+		t := cell.Sub(base.ToFloat64())
+
+		x00 := lerp(corner(base), corner(base.Add(vector3.New(1, 0, 0))), t.X())
+		x10 := lerp(corner(base.Add(vector3.New(0, 1, 0))), corner(base.Add(vector3.New(1, 1, 0))), t.X())
+		x01 := lerp(corner(base.Add(vector3.New(0, 0, 1))), corner(base.Add(vector3.New(1, 0, 1))), t.X())
+		x11 := lerp(corner(base.Add(vector3.New(0, 1, 1))), corner(base.Add(vector3.New(1, 1, 1))), t.X())
+
+		return lerp(lerp(x00, x10, t.Y()), lerp(x01, x11, t.Y()), t.Z())
+	}
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
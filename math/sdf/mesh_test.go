@@ -0,0 +1,106 @@
+package sdf_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/math/sample"
+	"github.com/EliCDavis/polyform/math/sdf"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/marching"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMesh(t *testing.T) {
+	cube := sdf.Mesh(primitives.UnitCube())
+
+	tests := map[string]struct {
+		pos  vector3.Float64
+		want float64
+	}{
+		"center":         {pos: vector3.Zero[float64](), want: -0.5},
+		"inside face":    {pos: vector3.New(0.4, 0., 0.), want: -0.1},
+		"on face":        {pos: vector3.New(0., 0.5, 0.), want: 0.},
+		"outside face":   {pos: vector3.New(0., 0., 2.), want: 1.5},
+		"outside edge":   {pos: vector3.New(1., 1., 0.), want: math.Sqrt(0.5)},
+		"outside corner": {pos: vector3.New(-1., -1., -1.), want: math.Sqrt(0.75)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, cube(tc.pos), 1e-9)
+		})
+	}
+}
+
+func TestMesh_UnweldedSphere(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := sdf.Mesh(primitives.UVSphereUnwelded(1, 32, 32))
+
+	// ACT ====================================================================
+	center := sphere(vector3.Zero[float64]())
+	pole := sphere(vector3.New(0., 1.5, 0.))
+	inside := sphere(vector3.New(0.3, -0.2, 0.1))
+
+	// ASSERT =================================================================
+	assert.InDelta(t, -1, center, 0.01)
+	assert.InDelta(t, 0.5, pole, 1e-9)
+	assert.Less(t, inside, 0.)
+}
+
+func TestMesh_Operators(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := sdf.Mesh(primitives.UnitCube())
+	sphere := sdf.Sphere(vector3.New(0.5, 0., 0.), 0.25)
+
+	// ACT ====================================================================
+	union := sdf.Union(cube, sphere)
+	subtract := sdf.Subtract(cube, sphere)
+
+	// ASSERT =================================================================
+	assert.InDelta(t, -0.25, union(vector3.New(0.5, 0., 0.)), 1e-9)
+	assert.Greater(t, subtract(vector3.New(0.45, 0., 0.)), 0.)
+	assert.Less(t, subtract(vector3.New(-0.45, 0., 0.)), 0.)
+}
+
+func TestCachedMesh(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphere(1, 16, 16)
+	exact := sdf.Mesh(mesh)
+
+	// ACT ====================================================================
+	cached := sdf.CachedMesh(mesh, 0.05)
+
+	// ASSERT =================================================================
+	for _, p := range []vector3.Float64{
+		vector3.Zero[float64](),
+		vector3.New(0.51, 0.23, -0.37),
+		vector3.New(1.2, 0.1, 0.),
+		vector3.New(-0.1, -0.98, 0.05),
+	} {
+		assert.InDelta(t, exact(p), cached(p), 0.05, "%v", p)
+	}
+}
+
+func TestCachedMesh_March(t *testing.T) {
+	// ARRANGE ================================================================
+	field := marching.Field{
+		Domain: primitives.UnitCube().BoundingBox(modeling.PositionAttribute),
+		Float1Functions: map[string]sample.Vec3ToFloat{
+			modeling.PositionAttribute: sdf.CachedMesh(primitives.UnitCube(), 0.1),
+		},
+	}
+	field.Domain.Expand(0.5)
+
+	// ACT ====================================================================
+	marched := field.March(modeling.PositionAttribute, 10, 0)
+
+	// ASSERT =================================================================
+	require.Greater(t, marched.PrimitiveCount(), 0)
+	bounds := marched.BoundingBox(modeling.PositionAttribute)
+	assert.InDelta(t, -0.5, bounds.Min().X(), 0.05)
+	assert.InDelta(t, 0.5, bounds.Max().Y(), 0.05)
+}
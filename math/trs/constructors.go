@@ -1,6 +1,8 @@
 package trs
 
 import (
+	"math"
+
 	"github.com/EliCDavis/polyform/math/mat"
 	"github.com/EliCDavis/polyform/math/quaternion"
 	"github.com/EliCDavis/vector/vector3"
@@ -53,13 +55,87 @@ func Rotation(rotation quaternion.Quaternion) TRS {
 
 // https://github.com/CedricGuillemet/ImGuizmo/blob/cf287a3fd48d503ad19a8f8ca81a1a15b63bccf1/ImGuizmo.cpp#L2359
 func FromMatrix(m mat.Matrix4x4) TRS {
+	basis := [3]vector3.Float64{
+		vector3.New(m.X00, m.X10, m.X20),
+		vector3.New(m.X01, m.X11, m.X21),
+		vector3.New(m.X02, m.X12, m.X22),
+	}
+	scale := [3]float64{basis[0].Length(), basis[1].Length(), basis[2].Length()}
+
+	// A rotation can't mirror, so a basis with a negative determinant
+	// carries its mirroring in the sign of the X scale instead
+	if basis[0].Dot(basis[1].Cross(basis[2])) < 0 {
+		scale[0] = -scale[0]
+	}
+
+	// Strip the scale out of the basis vectors so we're left with a pure
+	// rotation. Axes scaled down to nothing have no direction left, and are
+	// rebuilt from the others
+	var axes [3]vector3.Float64
+	var present [3]bool
+	for i, v := range basis {
+		if scale[i] != 0 {
+			axes[i] = v.DivByConstant(scale[i])
+			present[i] = true
+		}
+	}
+	axes = completeBasis(axes, present)
+
+	// quaternion.FromMatrix expects the rotation in row-vector form, so we
+	// hand it the transpose of our column-vector basis
+	rotation := mat.Matrix4x4{
+		X00: axes[0].X(), X01: axes[0].Y(), X02: axes[0].Z(),
+		X10: axes[1].X(), X11: axes[1].Y(), X12: axes[1].Z(),
+		X20: axes[2].X(), X21: axes[2].Y(), X22: axes[2].Z(),
+		X33: 1,
+	}
+
 	return TRS{
 		position: vector3.New(m.X03, m.X13, m.X23),
-		scale: vector3.New(
-			vector3.New(m.X00, m.X10, m.X20).Length(),
-			vector3.New(m.X01, m.X11, m.X21).Length(),
-			vector3.New(m.X02, m.X12, m.X22).Length(),
-		),
-		rotation: quaternion.FromMatrix(m),
+		scale:    vector3.New(scale[0], scale[1], scale[2]),
+		rotation: quaternion.FromMatrix(rotation),
 	}
 }
+
+// completeBasis fills in the missing axes of a right handed orthonormal
+// basis, falling back to the identity when no axes are present
+func completeBasis(axes [3]vector3.Float64, present [3]bool) [3]vector3.Float64 {
+	count := 0
+	for _, p := range present {
+		if p {
+			count++
+		}
+	}
+
+	switch count {
+	case 0:
+		return [3]vector3.Float64{
+			vector3.Right[float64](),
+			vector3.Up[float64](),
+			vector3.Forward[float64](),
+		}
+
+	case 1:
+		i := 0
+		for !present[i] {
+			i++
+		}
+
+		helper := vector3.Right[float64]()
+		if math.Abs(axes[i].X()) > 0.9 {
+			helper = vector3.Up[float64]()
+		}
+		next := axes[i].Cross(helper).Normalized()
+		axes[(i+1)%3] = next
+		axes[(i+2)%3] = axes[i].Cross(next)
+
+	case 2:
+		for i, p := range present {
+			if !p {
+				axes[i] = axes[(i+1)%3].Cross(axes[(i+2)%3]).Normalized()
+			}
+		}
+	}
+
+	return axes
+}
@@ -79,3 +79,69 @@ func TestConstructor_New(t *testing.T) {
 	assert.Equal(t, vector3.New(4., 5., 6.), scale)
 
 }
+
+func TestConstructor_FromMatrix(t *testing.T) {
+
+	// ARRANGE ================================================================
+	rot := quaternion.FromTheta(0.7, vector3.New(1., 2., 3.).Normalized())
+	original := trs.New(vector3.New(1., 2., 3.), rot, vector3.New(2., 3., 4.))
+
+	// ACT ====================================================================
+	transform := trs.FromMatrix(original.Matrix())
+
+	// ASSERT =================================================================
+	assert.InDelta(t, 1., transform.Position().X(), 1e-9)
+	assert.InDelta(t, 2., transform.Position().Y(), 1e-9)
+	assert.InDelta(t, 3., transform.Position().Z(), 1e-9)
+
+	assert.InDelta(t, 2., transform.Scale().X(), 1e-9)
+	assert.InDelta(t, 3., transform.Scale().Y(), 1e-9)
+	assert.InDelta(t, 4., transform.Scale().Z(), 1e-9)
+
+	assert.InDelta(t, rot.Dir().X(), transform.Rotation().Dir().X(), 1e-9)
+	assert.InDelta(t, rot.Dir().Y(), transform.Rotation().Dir().Y(), 1e-9)
+	assert.InDelta(t, rot.Dir().Z(), transform.Rotation().Dir().Z(), 1e-9)
+	assert.InDelta(t, rot.W(), transform.Rotation().W(), 1e-9)
+}
+
+func TestConstructor_FromMatrix_ZeroScale(t *testing.T) {
+	// ARRANGE ================================================================
+	rot := quaternion.FromTheta(0.7, vector3.New(1., 2., 3.).Normalized())
+	hidden := trs.Scale(vector3.Zero[float64]())
+	flattened := trs.New(vector3.New(1., 2., 3.), rot, vector3.New(2., 0., 0.))
+
+	// ACT ====================================================================
+	child := hidden.Multiply(trs.New(vector3.New(1., 2., 3.), rot, vector3.One[float64]()))
+	partial := trs.FromMatrix(flattened.Matrix())
+
+	// ASSERT =================================================================
+	assert.Equal(t, vector3.Zero[float64](), child.Position())
+	assert.Equal(t, vector3.Zero[float64](), child.Scale())
+	assert.Equal(t, quaternion.Identity(), child.Rotation())
+
+	assert.InDelta(t, 0., partial.Scale().Distance(vector3.New(2., 0., 0.)), 1e-9)
+	assert.False(t, math.IsNaN(partial.Rotation().W()))
+	assert.InDelta(t, 0., partial.Transform(vector3.New(1., 5., 5.)).Distance(flattened.Transform(vector3.New(1., 5., 5.))), 1e-9)
+}
+
+func TestConstructor_FromMatrix_Mirrored(t *testing.T) {
+	// ARRANGE ================================================================
+	mirrored := trs.Scale(vector3.New(-1., 1., 1.))
+	child := trs.New(
+		vector3.New(1., 2., 3.),
+		quaternion.FromTheta(0.7, vector3.New(1., 2., 3.).Normalized()),
+		vector3.New(2., 3., 4.),
+	)
+
+	// ACT ====================================================================
+	world := mirrored.Multiply(child)
+
+	// ASSERT =================================================================
+	assert.InDelta(t, 0., world.Position().Distance(vector3.New(-1., 2., 3.)), 1e-9)
+	assert.Less(t, world.Scale().X()*world.Scale().Y()*world.Scale().Z(), 0.)
+
+	for _, p := range []vector3.Float64{vector3.New(1., 0., 0.), vector3.New(0., 1., 0.), vector3.New(1., 2., 3.)} {
+		expected := mirrored.Transform(child.Transform(p))
+		assert.InDelta(t, 0., world.Transform(p).Distance(expected), 1e-9)
+	}
+}
@@ -98,7 +98,7 @@ func WeightMeshWithHeatDiffusion(mesh modeling.Mesh, skeleton Skeleton, voxiliza
 	}
 
 	weightData := make([]vector4.Float64, mesh.AttributeLength())
-	jointData := make([]vector4.Float64, mesh.AttributeLength())
+	jointData := make([]vector4.Int, mesh.AttributeLength())
 
 	return mesh.
 		ScanFloat3Attribute(modeling.PositionAttribute, func(vertexIndex int, v vector3.Float64) {
@@ -128,14 +128,14 @@ func WeightMeshWithHeatDiffusion(mesh modeling.Mesh, skeleton Skeleton, voxiliza
 			}
 
 			if totalVal == 0 {
-				jointData[vertexIndex] = vector4.New(float64(joints[0]), 0., 0., 0.)
+				jointData[vertexIndex] = vector4.New(joints[0], 0, 0, 0)
 				weightData[vertexIndex] = vector4.New(1., 0., 0., 0.)
 				return
 			}
 
-			jointData[vertexIndex] = vector4.New(float64(joints[0]), float64(joints[1]), float64(joints[2]), float64(joints[3]))
+			jointData[vertexIndex] = vector4.New(joints[0], joints[1], joints[2], joints[3])
 			weightData[vertexIndex] = vector4.New(jointVals[0], jointVals[1], jointVals[2], jointVals[3]).DivByConstant(totalVal)
 		}).
 		SetFloat4Attribute(modeling.WeightAttribute, weightData).
-		SetFloat4Attribute(modeling.JointAttribute, jointData)
+		SetInt4Attribute(modeling.JointAttribute, jointData)
 }
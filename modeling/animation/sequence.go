@@ -1,10 +1,21 @@
 package animation
 
-import "github.com/EliCDavis/vector/vector3"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
 
 type Frame[T any] struct {
 	time float64
 	val  T
+
+	// Only used by cubic spline interpolation
+	inTangent, outTangent T
 }
 
 func NewFrame[T any](time float64, val T) Frame[T] {
@@ -14,6 +25,17 @@ func NewFrame[T any](time float64, val T) Frame[T] {
 	}
 }
 
+// NewCubicSplineFrame builds a keyframe for a sequence using cubic spline
+// interpolation. The tangents are expressed in units per second.
+func NewCubicSplineFrame[T any](time float64, inTangent, val, outTangent T) Frame[T] {
+	return Frame[T]{
+		time:       time,
+		val:        val,
+		inTangent:  inTangent,
+		outTangent: outTangent,
+	}
+}
+
 func (s Frame[T]) Time() float64 {
 	return s.time
 }
@@ -22,24 +44,345 @@ func (s Frame[T]) Val() T {
 	return s.val
 }
 
-// type Sequence interface{}
+func (s Frame[T]) InTangent() T {
+	return s.inTangent
+}
+
+func (s Frame[T]) OutTangent() T {
+	return s.outTangent
+}
+
+// Interpolation dictates how values are computed between keyframes
+type Interpolation int
+
+const (
+	// Values are linearly interpolated between keyframes, using spherical
+	// linear interpolation for rotations
+	LinearInterpolation Interpolation = iota
+
+	// Values remain constant to the value of the previous keyframe until the
+	// next keyframe is reached
+	StepInterpolation
+
+	// Values are computed with a cubic hermite spline, using the tangents
+	// stored on each keyframe
+	CubicSplineInterpolation
+)
+
+func (i Interpolation) String() string {
+	switch i {
+	case LinearInterpolation:
+		return "Linear"
+
+	case StepInterpolation:
+		return "Step"
+
+	case CubicSplineInterpolation:
+		return "CubicSpline"
+	}
+	return fmt.Sprintf("Interpolation(%d)", int(i))
+}
+
+// Channel is the property of the joint a sequence animates
+type Channel int
+
+const (
+	TranslationChannel Channel = iota
+	RotationChannel
+	ScaleChannel
+
+	// Weights of a model's morph targets, rather than a property of a joint
+	WeightsChannel
+)
 
+func (c Channel) String() string {
+	switch c {
+	case TranslationChannel:
+		return "Translation"
+
+	case RotationChannel:
+		return "Rotation"
+
+	case ScaleChannel:
+		return "Scale"
+
+	case WeightsChannel:
+		return "Weights"
+	}
+	return fmt.Sprintf("Channel(%d)", int(c))
+}
+
+// Sequence is a series of keyframes animating a single property of a joint,
+// or the morph target weights of a model
 type Sequence struct {
-	joint  string
-	frames []Frame[vector3.Float64]
+	joint         string
+	channel       Channel
+	interpolation Interpolation
+	frames        []Frame[vector3.Float64]
+	rotations     []Frame[quaternion.Quaternion]
+	weights       []Frame[[]float64]
 }
 
+// Frames are the keyframes of a translation or scale sequence
 func (s Sequence) Frames() []Frame[vector3.Float64] {
 	return s.frames
 }
 
+// RotationFrames are the keyframes of a rotation sequence
+func (s Sequence) RotationFrames() []Frame[quaternion.Quaternion] {
+	return s.rotations
+}
+
+// WeightFrames are the keyframes of a morph target weights sequence, where
+// each keyframe contains a weight per morph target
+func (s Sequence) WeightFrames() []Frame[[]float64] {
+	return s.weights
+}
+
+// Joint is the path of the joint the sequence animates. Weight sequences
+// aren't associated with a joint, and return an empty string.
 func (s Sequence) Joint() string {
 	return s.joint
 }
 
+func (s Sequence) Channel() Channel {
+	return s.channel
+}
+
+func (s Sequence) Interpolation() Interpolation {
+	return s.interpolation
+}
+
+// Len is the number of keyframes within the sequence
+func (s Sequence) Len() int {
+	switch s.channel {
+	case RotationChannel:
+		return len(s.rotations)
+
+	case WeightsChannel:
+		return len(s.weights)
+	}
+	return len(s.frames)
+}
+
+// Time returns the time of the keyframe at the index provided
+func (s Sequence) Time(index int) float64 {
+	switch s.channel {
+	case RotationChannel:
+		return s.rotations[index].time
+
+	case WeightsChannel:
+		return s.weights[index].time
+	}
+	return s.frames[index].time
+}
+
+// Duration is the time of the final keyframe in the sequence
+func (s Sequence) Duration() float64 {
+	if s.Len() == 0 {
+		return 0
+	}
+	return s.Time(s.Len() - 1)
+}
+
+// NewSequence creates a linearly interpolated translation sequence
 func NewSequence(joint string, frames []Frame[vector3.Float64]) Sequence {
+	return NewTranslationSequence(joint, LinearInterpolation, frames)
+}
+
+func NewTranslationSequence(joint string, interpolation Interpolation, frames []Frame[vector3.Float64]) Sequence {
+	return Sequence{
+		joint:         joint,
+		channel:       TranslationChannel,
+		interpolation: interpolation,
+		frames:        frames,
+	}
+}
+
+func NewScaleSequence(joint string, interpolation Interpolation, frames []Frame[vector3.Float64]) Sequence {
+	return Sequence{
+		joint:         joint,
+		channel:       ScaleChannel,
+		interpolation: interpolation,
+		frames:        frames,
+	}
+}
+
+func NewRotationSequence(joint string, interpolation Interpolation, frames []Frame[quaternion.Quaternion]) Sequence {
 	return Sequence{
-		joint:  joint,
-		frames: frames,
+		joint:         joint,
+		channel:       RotationChannel,
+		interpolation: interpolation,
+		rotations:     frames,
 	}
 }
+
+// NewWeightsSequence animates the weights of a model's morph targets. Every
+// keyframe is required to contain the same number of weights.
+func NewWeightsSequence(interpolation Interpolation, frames []Frame[[]float64]) Sequence {
+	for i, f := range frames {
+		if len(f.val) != len(frames[0].val) {
+			panic(fmt.Errorf("keyframe %d contains %d weights, expected %d", i, len(f.val), len(frames[0].val)))
+		}
+	}
+
+	return Sequence{
+		channel:       WeightsChannel,
+		interpolation: interpolation,
+		weights:       frames,
+	}
+}
+
+// keyframeSpan finds the pair of keyframes surrounding the time provided,
+// along with how far along the time is between the two, normalized to [0, 1].
+// Times outside of the sequence clamp to the first and last keyframes.
+func (s Sequence) keyframeSpan(time float64) (int, int, float64) {
+	count := s.Len()
+	if count == 0 {
+		panic(fmt.Errorf("can not sample empty sequence for joint %q", s.joint))
+	}
+
+	if time <= s.Time(0) {
+		return 0, 0, 0
+	}
+
+	if time >= s.Time(count-1) {
+		return count - 1, count - 1, 0
+	}
+
+	next := sort.Search(count, func(i int) bool { return s.Time(i) > time })
+	previous := next - 1
+
+	duration := s.Time(next) - s.Time(previous)
+	if duration <= 0 {
+		return next, next, 0
+	}
+	return previous, next, (time - s.Time(previous)) / duration
+}
+
+// Hermite spline basis functions
+// https://registry.khronos.org/glTF/specs/2.0/glTF-2.0.html#interpolation-cubic
+func cubicSplineWeights(t, duration float64) (float64, float64, float64, float64) {
+	t2 := t * t
+	t3 := t2 * t
+	return (2 * t3) - (3 * t2) + 1,
+		duration * (t3 - (2 * t2) + t),
+		(-2 * t3) + (3 * t2),
+		duration * (t3 - t2)
+}
+
+// SampleVector3 computes the value of a translation or scale sequence at the
+// time provided
+func (s Sequence) SampleVector3(time float64) vector3.Float64 {
+	if s.channel == RotationChannel || s.channel == WeightsChannel {
+		panic(fmt.Errorf("can not sample %s sequence for joint %q as a vector3", s.channel, s.joint))
+	}
+
+	a, b, t := s.keyframeSpan(time)
+	start := s.frames[a]
+	end := s.frames[b]
+
+	if a == b {
+		return start.val
+	}
+
+	switch s.interpolation {
+	case StepInterpolation:
+		return start.val
+
+	case CubicSplineInterpolation:
+		wa, wOut, wb, wIn := cubicSplineWeights(t, end.time-start.time)
+		return start.val.Scale(wa).
+			Add(start.outTangent.Scale(wOut)).
+			Add(end.val.Scale(wb)).
+			Add(end.inTangent.Scale(wIn))
+	}
+
+	return start.val.Add(end.val.Sub(start.val).Scale(t))
+}
+
+// SampleRotation computes the value of a rotation sequence at the time
+// provided
+func (s Sequence) SampleRotation(time float64) quaternion.Quaternion {
+	if s.channel != RotationChannel {
+		panic(fmt.Errorf("can not sample %s sequence for joint %q as a rotation", s.channel, s.joint))
+	}
+
+	a, b, t := s.keyframeSpan(time)
+	start := s.rotations[a]
+	end := s.rotations[b]
+
+	if a == b {
+		return start.val
+	}
+
+	switch s.interpolation {
+	case StepInterpolation:
+		return start.val
+
+	case CubicSplineInterpolation:
+		wa, wOut, wb, wIn := cubicSplineWeights(t, end.time-start.time)
+		v := start.val.Vector4().Scale(wa).
+			Add(start.outTangent.Vector4().Scale(wOut)).
+			Add(end.val.Vector4().Scale(wb)).
+			Add(end.inTangent.Vector4().Scale(wIn))
+		return vector4ToQuaternion(v).Normalize()
+	}
+
+	return quaternion.Slerp(start.val, end.val, t)
+}
+
+// SampleWeights computes the morph target weights of a weights sequence at
+// the time provided
+func (s Sequence) SampleWeights(time float64) []float64 {
+	if s.channel != WeightsChannel {
+		panic(fmt.Errorf("can not sample %s sequence for joint %q as weights", s.channel, s.joint))
+	}
+
+	a, b, t := s.keyframeSpan(time)
+	start := s.weights[a]
+	end := s.weights[b]
+
+	results := make([]float64, len(start.val))
+	if a == b || s.interpolation == StepInterpolation {
+		copy(results, start.val)
+		return results
+	}
+
+	if s.interpolation == CubicSplineInterpolation {
+		wa, wOut, wb, wIn := cubicSplineWeights(t, end.time-start.time)
+		for i := range results {
+			results[i] = (start.val[i] * wa) +
+				(start.outTangent[i] * wOut) +
+				(end.val[i] * wb) +
+				(end.inTangent[i] * wIn)
+		}
+		return results
+	}
+
+	for i := range results {
+		results[i] = start.val[i] + ((end.val[i] - start.val[i]) * t)
+	}
+	return results
+}
+
+// Sample evaluates the sequence at the time provided, and overwrites the
+// component of the transform the sequence animates. Weight sequences don't
+// animate a transform, and return it unchanged.
+func (s Sequence) Sample(time float64, transform trs.TRS) trs.TRS {
+	switch s.channel {
+	case WeightsChannel:
+		return transform
+
+	case RotationChannel:
+		return transform.SetRotation(s.SampleRotation(time))
+
+	case ScaleChannel:
+		return transform.SetScale(s.SampleVector3(time))
+	}
+	return transform.SetTranslation(s.SampleVector3(time))
+}
+
+func vector4ToQuaternion(v vector4.Float64) quaternion.Quaternion {
+	return quaternion.New(vector3.New(v.X(), v.Y(), v.Z()), v.W())
+}
@@ -0,0 +1,115 @@
+package animation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling/animation"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequence_SampleVector3(t *testing.T) {
+	frames := []animation.Frame[vector3.Float64]{
+		animation.NewFrame(1., vector3.New(0., 0., 0.)),
+		animation.NewFrame(2., vector3.New(2., 0., 0.)),
+		animation.NewFrame(4., vector3.New(2., 4., 0.)),
+	}
+
+	tests := map[string]struct {
+		interpolation animation.Interpolation
+		time          float64
+		want          vector3.Float64
+	}{
+		"linear before start":   {interpolation: animation.LinearInterpolation, time: 0, want: vector3.New(0., 0., 0.)},
+		"linear after end":      {interpolation: animation.LinearInterpolation, time: 5, want: vector3.New(2., 4., 0.)},
+		"linear on keyframe":    {interpolation: animation.LinearInterpolation, time: 2, want: vector3.New(2., 0., 0.)},
+		"linear first span":     {interpolation: animation.LinearInterpolation, time: 1.5, want: vector3.New(1., 0., 0.)},
+		"linear second span":    {interpolation: animation.LinearInterpolation, time: 3, want: vector3.New(2., 2., 0.)},
+		"step first span":       {interpolation: animation.StepInterpolation, time: 1.9, want: vector3.New(0., 0., 0.)},
+		"step second span":      {interpolation: animation.StepInterpolation, time: 3.9, want: vector3.New(2., 0., 0.)},
+		"step on last keyframe": {interpolation: animation.StepInterpolation, time: 4, want: vector3.New(2., 4., 0.)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sequence := animation.NewTranslationSequence("Joint", tc.interpolation, frames)
+			got := sequence.SampleVector3(tc.time)
+			assert.InDelta(t, 0., tc.want.Distance(got), 1e-9)
+		})
+	}
+}
+
+func TestSequence_CubicSpline(t *testing.T) {
+	// With tangents matching the slope between keyframes, the spline is a
+	// straight line
+	slope := vector3.New(2., 0., 0.)
+	sequence := animation.NewScaleSequence("Joint", animation.CubicSplineInterpolation, []animation.Frame[vector3.Float64]{
+		animation.NewCubicSplineFrame(0., slope, vector3.New(1., 1., 1.), slope),
+		animation.NewCubicSplineFrame(1., slope, vector3.New(3., 1., 1.), slope),
+	})
+
+	assert.Equal(t, animation.ScaleChannel, sequence.Channel())
+	assert.InDelta(t, 0., vector3.New(2., 1., 1.).Distance(sequence.SampleVector3(0.5)), 1e-9)
+	assert.InDelta(t, 0., vector3.New(1.5, 1., 1.).Distance(sequence.SampleVector3(0.25)), 1e-9)
+
+	// Flat tangents ease in and out of each keyframe
+	flat := vector3.Zero[float64]()
+	eased := animation.NewScaleSequence("Joint", animation.CubicSplineInterpolation, []animation.Frame[vector3.Float64]{
+		animation.NewCubicSplineFrame(0., flat, vector3.New(0., 0., 0.), flat),
+		animation.NewCubicSplineFrame(1., flat, vector3.New(1., 0., 0.), flat),
+	})
+	assert.InDelta(t, 0.5, eased.SampleVector3(0.5).X(), 1e-9)
+	assert.InDelta(t, 0.15625, eased.SampleVector3(0.25).X(), 1e-9)
+}
+
+func TestSequence_SampleRotation(t *testing.T) {
+	up := vector3.New(0., 1., 0.)
+	sequence := animation.NewRotationSequence("Joint", animation.LinearInterpolation, []animation.Frame[quaternion.Quaternion]{
+		animation.NewFrame(0., quaternion.FromTheta(0, up)),
+		animation.NewFrame(2., quaternion.FromTheta(math.Pi/2, up)),
+	})
+
+	assert.Equal(t, 2., sequence.Duration())
+	assert.Equal(t, 2, sequence.Len())
+
+	got := sequence.SampleRotation(1)
+	assert.InDelta(t, 1., math.Abs(got.Dot(quaternion.FromTheta(math.Pi/4, up))), 1e-9)
+
+	transform := sequence.Sample(1, trs.Position(vector3.New(1., 2., 3.)))
+	assert.Equal(t, vector3.New(1., 2., 3.), transform.Position())
+	assert.InDelta(t, 1., math.Abs(transform.Rotation().Dot(quaternion.FromTheta(math.Pi/4, up))), 1e-9)
+
+	assert.Panics(t, func() { sequence.SampleVector3(1) })
+}
+
+func TestNewSequence_DefaultsToLinearTranslation(t *testing.T) {
+	sequence := animation.NewSequence("Joint", []animation.Frame[vector3.Float64]{
+		animation.NewFrame(0., vector3.New(0., 0., 0.)),
+	})
+
+	assert.Equal(t, animation.TranslationChannel, sequence.Channel())
+	assert.Equal(t, animation.LinearInterpolation, sequence.Interpolation())
+	assert.Equal(t, "Joint", sequence.Joint())
+}
+
+func TestSequence_SampleWeights(t *testing.T) {
+	sequence := animation.NewWeightsSequence(animation.LinearInterpolation, []animation.Frame[[]float64]{
+		animation.NewFrame(0., []float64{0, 1}),
+		animation.NewFrame(1., []float64{1, 0}),
+	})
+
+	assert.Equal(t, animation.WeightsChannel, sequence.Channel())
+	assert.Equal(t, "", sequence.Joint())
+	assert.InDeltaSlice(t, []float64{0.25, 0.75}, sequence.SampleWeights(0.25), 1e-9)
+	assert.InDeltaSlice(t, []float64{1, 0}, sequence.SampleWeights(2), 1e-9)
+
+	assert.Panics(t, func() {
+		animation.NewWeightsSequence(animation.LinearInterpolation, []animation.Frame[[]float64]{
+			animation.NewFrame(0., []float64{0, 1}),
+			animation.NewFrame(1., []float64{1}),
+		})
+	})
+}
@@ -35,6 +35,18 @@ func (s Skeleton) Lookup(name string) int {
 	panic(fmt.Errorf("skeleton did not contain a joint with the path: %s", name))
 }
 
+// Path is the joint's name prefixed by the names of all of its ancestors,
+// separated by '/'
+func (s Skeleton) Path(index int) string {
+	return s.joints[index].path
+}
+
+// Name is the name of the joint, without the names of its ancestors
+func (s Skeleton) Name(index int) string {
+	path := s.joints[index].path
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
 func (s Skeleton) Children(index int) []int {
 	return s.joints[index].children
 }
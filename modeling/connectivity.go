@@ -0,0 +1,374 @@
+package modeling
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Edge is an undirected edge between two vertices, with A always being the
+// smaller of the two indices
+type Edge struct {
+	A, B int
+}
+
+func NewEdge(a, b int) Edge {
+	if a > b {
+		return Edge{A: b, B: a}
+	}
+	return Edge{A: a, B: b}
+}
+
+// Other returns the vertex on the opposite end of the edge
+func (e Edge) Other(v int) int {
+	if e.A == v {
+		return e.B
+	}
+	return e.A
+}
+
+// WeldPositions maps every vertex to the first vertex sharing its exact
+// position. Meshes that have been split along attribute seams (UVs, hard
+// normals) can then be treated as a single connected surface by building
+// their connectivity from the welded indices.
+func WeldPositions(positions []vector3.Float64) []int {
+	welded := make([]int, len(positions))
+	lookup := make(map[vector3.Float64]int, len(positions))
+	for i, p := range positions {
+		if existing, ok := lookup[p]; ok {
+			welded[i] = existing
+			continue
+		}
+		lookup[p] = i
+		welded[i] = i
+	}
+	return welded
+}
+
+// HalfEdge is one side of an edge, running along the winding of the face it
+// belongs to
+type HalfEdge struct {
+	// Vertex the half edge starts at
+	Vertex int
+
+	// Face the half edge belongs to
+	Face int
+
+	// Next and previous half edges around the face
+	Next, Previous int
+
+	// Half edge on the other side of the edge, or -1 if the edge doesn't
+	// belong to exactly two faces. If the two faces are wound
+	// inconsistently, the twin runs the same direction as the half edge.
+	Twin int
+}
+
+// Connectivity is the half edge structure of a triangle or quad mesh,
+// answering adjacency queries between its vertices, edges, and faces.
+//
+// Faces that reference the same vertex more than once are considered
+// degenerate, and are left out of every adjacency query.
+type Connectivity struct {
+	faceSize   int
+	indices    []int
+	halfEdges  []HalfEdge
+	degenerate []bool
+
+	edges     []Edge
+	edgeHalfs map[Edge][]int
+
+	// Half edges starting at each vertex
+	outgoing [][]int
+}
+
+func newConnectivity(indices []int, faceSize int) *Connectivity {
+	faceCount := len(indices) / faceSize
+	c := &Connectivity{
+		faceSize:   faceSize,
+		indices:    indices,
+		halfEdges:  make([]HalfEdge, len(indices)),
+		degenerate: make([]bool, faceCount),
+		edges:      make([]Edge, 0, len(indices)/2),
+		edgeHalfs:  make(map[Edge][]int, len(indices)/2),
+	}
+
+	vertexCount := 0
+	for _, v := range indices {
+		vertexCount = max(vertexCount, v+1)
+	}
+	c.outgoing = make([][]int, vertexCount)
+
+	for f := range faceCount {
+		start := f * faceSize
+		face := indices[start : start+faceSize]
+
+		for i, v := range face {
+			for _, other := range face[i+1:] {
+				if v == other {
+					c.degenerate[f] = true
+				}
+			}
+		}
+
+		for i, v := range face {
+			c.halfEdges[start+i] = HalfEdge{
+				Vertex:   v,
+				Face:     f,
+				Next:     start + (i+1)%faceSize,
+				Previous: start + (i+faceSize-1)%faceSize,
+				Twin:     -1,
+			}
+		}
+
+		if c.degenerate[f] {
+			continue
+		}
+
+		for i, v := range face {
+			h := start + i
+			c.outgoing[v] = append(c.outgoing[v], h)
+
+			e := NewEdge(v, face[(i+1)%faceSize])
+			halfs, ok := c.edgeHalfs[e]
+			if !ok {
+				c.edges = append(c.edges, e)
+			}
+			c.edgeHalfs[e] = append(halfs, h)
+		}
+	}
+
+	for _, halfs := range c.edgeHalfs {
+		if len(halfs) != 2 {
+			continue
+		}
+		c.halfEdges[halfs[0]].Twin = halfs[1]
+		c.halfEdges[halfs[1]].Twin = halfs[0]
+	}
+
+	return c
+}
+
+// Connectivity builds the half edge structure of the mesh. Only triangle and
+// quad topologies are supported.
+func (m Mesh) Connectivity() *Connectivity {
+	switch m.topology {
+	case TriangleTopology, QuadTopology:
+		return newConnectivity(m.indices, m.topology.IndexSize())
+	}
+	panic(fmt.Errorf("unimplemented topology for connectivity: %s", m.topology.String()))
+}
+
+// VertexCount is one more than the largest vertex index referenced by the
+// mesh's faces
+func (c *Connectivity) VertexCount() int {
+	return len(c.outgoing)
+}
+
+// FaceCount is the number of faces in the mesh, including degenerate ones
+func (c *Connectivity) FaceCount() int {
+	return len(c.degenerate)
+}
+
+func (c *Connectivity) face(f int) []int {
+	return c.indices[f*c.faceSize : (f+1)*c.faceSize]
+}
+
+// Face returns the vertices that make up the face, in winding order
+func (c *Connectivity) Face(f int) []int {
+	face := make([]int, c.faceSize)
+	copy(face, c.face(f))
+	return face
+}
+
+// Degenerate reports whether the face references the same vertex more than
+// once
+func (c *Connectivity) Degenerate(f int) bool {
+	return c.degenerate[f]
+}
+
+// FaceHalfEdge returns the first half edge of the face
+func (c *Connectivity) FaceHalfEdge(f int) int {
+	return f * c.faceSize
+}
+
+// HalfEdgeCount is the number of half edges, which is the number of faces
+// multiplied by the number of vertices per face
+func (c *Connectivity) HalfEdgeCount() int {
+	return len(c.halfEdges)
+}
+
+func (c *Connectivity) HalfEdge(h int) HalfEdge {
+	return c.halfEdges[h]
+}
+
+// Destination returns the vertex the half edge ends at
+func (c *Connectivity) Destination(h int) int {
+	return c.halfEdges[c.halfEdges[h].Next].Vertex
+}
+
+// Edges returns every unique edge in the mesh, in the order they're first
+// encountered
+//
+// The slice returned is shared, and should not be modified.
+func (c *Connectivity) Edges() []Edge {
+	return c.edges
+}
+
+// EdgeHalfEdges returns every half edge running along the edge, in either
+// direction
+//
+// The slice returned is shared, and should not be modified.
+func (c *Connectivity) EdgeHalfEdges(e Edge) []int {
+	return c.edgeHalfs[e]
+}
+
+// EdgeFaces returns every face that contains the edge
+func (c *Connectivity) EdgeFaces(e Edge) []int {
+	halfs := c.edgeHalfs[e]
+	faces := make([]int, len(halfs))
+	for i, h := range halfs {
+		faces[i] = c.halfEdges[h].Face
+	}
+	return faces
+}
+
+// IsBoundaryEdge reports whether the edge belongs to exactly one face
+func (c *Connectivity) IsBoundaryEdge(e Edge) bool {
+	return len(c.edgeHalfs[e]) == 1
+}
+
+// IsManifoldEdge reports whether the edge belongs to one or two faces
+func (c *Connectivity) IsManifoldEdge(e Edge) bool {
+	count := len(c.edgeHalfs[e])
+	return count == 1 || count == 2
+}
+
+// BoundaryEdges returns every edge belonging to exactly one face
+func (c *Connectivity) BoundaryEdges() []Edge {
+	out := make([]Edge, 0)
+	for _, e := range c.edges {
+		if c.IsBoundaryEdge(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// NonManifoldEdges returns every edge belonging to more than two faces
+func (c *Connectivity) NonManifoldEdges() []Edge {
+	out := make([]Edge, 0)
+	for _, e := range c.edges {
+		if len(c.edgeHalfs[e]) > 2 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// IsBoundaryVertex reports whether the vertex lies on a boundary edge
+func (c *Connectivity) IsBoundaryVertex(v int) bool {
+	for _, n := range c.VertexNeighbors(v) {
+		if c.IsBoundaryEdge(NewEdge(v, n)) {
+			return true
+		}
+	}
+	return false
+}
+
+// VertexHalfEdges returns every half edge starting at the vertex
+//
+// The slice returned is shared, and should not be modified.
+func (c *Connectivity) VertexHalfEdges(v int) []int {
+	if v < 0 || v >= len(c.outgoing) {
+		return nil
+	}
+	return c.outgoing[v]
+}
+
+// VertexFaces returns every face that contains the vertex
+func (c *Connectivity) VertexFaces(v int) []int {
+	halfs := c.VertexHalfEdges(v)
+	faces := make([]int, len(halfs))
+	for i, h := range halfs {
+		faces[i] = c.halfEdges[h].Face
+	}
+	return faces
+}
+
+// VertexNeighbors returns the one ring of the vertex, every vertex that
+// shares an edge with it
+func (c *Connectivity) VertexNeighbors(v int) []int {
+	neighbors := make([]int, 0, 6)
+	add := func(n int) {
+		for _, existing := range neighbors {
+			if existing == n {
+				return
+			}
+		}
+		neighbors = append(neighbors, n)
+	}
+
+	for _, h := range c.VertexHalfEdges(v) {
+		add(c.Destination(h))
+		add(c.halfEdges[c.halfEdges[h].Previous].Vertex)
+	}
+	return neighbors
+}
+
+// Valence is the number of edges connected to the vertex
+func (c *Connectivity) Valence(v int) int {
+	return len(c.VertexNeighbors(v))
+}
+
+// FaceNeighbors returns every face that shares an edge with the face
+func (c *Connectivity) FaceNeighbors(f int) []int {
+	if c.degenerate[f] {
+		return nil
+	}
+
+	neighbors := make([]int, 0, c.faceSize)
+	face := c.face(f)
+	for i, v := range face {
+	next:
+		for _, other := range c.EdgeFaces(NewEdge(v, face[(i+1)%c.faceSize])) {
+			if other == f {
+				continue
+			}
+			for _, existing := range neighbors {
+				if existing == other {
+					continue next
+				}
+			}
+			neighbors = append(neighbors, other)
+		}
+	}
+	return neighbors
+}
+
+// ConnectedComponents groups the mesh's faces into pieces, where two faces
+// belong to the same piece if they're connected through shared vertices.
+// Degenerate faces are left out.
+func (c *Connectivity) ConnectedComponents() [][]int {
+	visited := make([]bool, c.FaceCount())
+	components := make([][]int, 0)
+	for seed := range visited {
+		if visited[seed] || c.degenerate[seed] {
+			continue
+		}
+
+		visited[seed] = true
+		component := []int{seed}
+		for i := 0; i < len(component); i++ {
+			for _, v := range c.face(component[i]) {
+				for _, f := range c.VertexFaces(v) {
+					if !visited[f] {
+						visited[f] = true
+						component = append(component, f)
+					}
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
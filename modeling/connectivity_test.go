@@ -0,0 +1,120 @@
+package modeling_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectivity_Triangles(t *testing.T) {
+	// Two triangles sharing the edge 1-2, plus a lone triangle
+	c := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		2, 1, 3,
+		4, 5, 6,
+	}).Connectivity()
+
+	assert.Equal(t, 3, c.FaceCount())
+	assert.Equal(t, 9, c.HalfEdgeCount())
+	assert.Equal(t, 7, c.VertexCount())
+	assert.Len(t, c.Edges(), 8)
+	assert.Len(t, c.BoundaryEdges(), 7)
+	assert.Empty(t, c.NonManifoldEdges())
+
+	assert.ElementsMatch(t, []int{0, 1}, c.EdgeFaces(modeling.NewEdge(2, 1)))
+	assert.False(t, c.IsBoundaryEdge(modeling.NewEdge(1, 2)))
+	assert.True(t, c.IsBoundaryEdge(modeling.NewEdge(0, 1)))
+	assert.True(t, c.IsBoundaryVertex(1))
+
+	assert.Equal(t, []int{1}, c.FaceNeighbors(0))
+	assert.Equal(t, []int{0}, c.FaceNeighbors(1))
+	assert.Empty(t, c.FaceNeighbors(2))
+
+	assert.Equal(t, 3, c.Valence(1))
+	assert.ElementsMatch(t, []int{0, 2, 3}, c.VertexNeighbors(1))
+	assert.ElementsMatch(t, []int{0, 1}, c.VertexFaces(2))
+
+	components := c.ConnectedComponents()
+	assert.Len(t, components, 2)
+	assert.ElementsMatch(t, []int{0, 1}, components[0])
+	assert.ElementsMatch(t, []int{2}, components[1])
+}
+
+func TestConnectivity_HalfEdges(t *testing.T) {
+	c := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		2, 1, 3,
+	}).Connectivity()
+
+	// Walking around the first face
+	h := c.FaceHalfEdge(0)
+	for i, v := range []int{0, 1, 2} {
+		he := c.HalfEdge(h)
+		assert.Equal(t, v, he.Vertex, i)
+		assert.Equal(t, 0, he.Face)
+		assert.Equal(t, h, c.HalfEdge(he.Next).Previous)
+		h = he.Next
+	}
+	assert.Equal(t, c.FaceHalfEdge(0), h)
+
+	// The 1 -> 2 half edge of the first face twins with 2 -> 1 of the second
+	twin := c.HalfEdge(c.FaceHalfEdge(0) + 1).Twin
+	assert.Equal(t, c.FaceHalfEdge(1), twin)
+	assert.Equal(t, 2, c.HalfEdge(twin).Vertex)
+	assert.Equal(t, 1, c.Destination(twin))
+
+	assert.Equal(t, -1, c.HalfEdge(c.FaceHalfEdge(0)).Twin)
+}
+
+func TestConnectivity_Quads(t *testing.T) {
+	c := modeling.NewMesh(modeling.QuadTopology, []int{
+		0, 1, 2, 3,
+		3, 2, 4, 5,
+	}).Connectivity()
+
+	assert.Equal(t, 2, c.FaceCount())
+	assert.Equal(t, []int{3, 2, 4, 5}, c.Face(1))
+	assert.Len(t, c.Edges(), 7)
+	assert.Len(t, c.BoundaryEdges(), 6)
+	assert.Equal(t, []int{1}, c.FaceNeighbors(0))
+	assert.Equal(t, 3, c.Valence(2))
+	assert.Len(t, c.ConnectedComponents(), 1)
+}
+
+func TestConnectivity_NonManifoldAndDegenerate(t *testing.T) {
+	c := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		1, 0, 3,
+		0, 1, 4,
+		5, 5, 6,
+	}).Connectivity()
+
+	assert.Equal(t, []modeling.Edge{modeling.NewEdge(0, 1)}, c.NonManifoldEdges())
+	assert.False(t, c.IsManifoldEdge(modeling.NewEdge(0, 1)))
+	assert.Equal(t, -1, c.HalfEdge(c.FaceHalfEdge(0)).Twin)
+	assert.ElementsMatch(t, []int{1, 2}, c.FaceNeighbors(0))
+
+	assert.True(t, c.Degenerate(3))
+	assert.Empty(t, c.VertexFaces(5))
+	assert.Nil(t, c.FaceNeighbors(3))
+	assert.Len(t, c.ConnectedComponents(), 1)
+}
+
+func TestConnectivity_UnsupportedTopology(t *testing.T) {
+	assert.Panics(t, func() {
+		modeling.EmptyPointcloud().Connectivity()
+	})
+}
+
+func TestWeldPositions(t *testing.T) {
+	welded := modeling.WeldPositions([]vector3.Float64{
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(0., 0., 0.),
+		vector3.New(1., 0., 0.),
+		vector3.New(0., 1., 0.),
+	})
+	assert.Equal(t, []int{0, 1, 0, 1, 4}, welded)
+}
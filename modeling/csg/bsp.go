@@ -0,0 +1,264 @@
+package csg
+
+import (
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Distance from a plane a point can be while still being considered on it
+const epsilon = 1e-5
+
+type side int
+
+const (
+	coplanar side = 0
+	front    side = 1
+	back     side = 2
+	spanning side = 3
+)
+
+type vertex struct {
+	position vector3.Float64
+
+	// Every other attribute's components flattened, laid out according to
+	// the attributeLayout the vertex was built with
+	data []float64
+}
+
+func (v vertex) lerp(other vertex, t float64) vertex {
+	data := make([]float64, len(v.data))
+	for i, d := range v.data {
+		data[i] = d + (other.data[i]-d)*t
+	}
+
+	return vertex{
+		position: v.position.Add(other.position.Sub(v.position).Scale(t)),
+		data:     data,
+	}
+}
+
+type plane struct {
+	normal vector3.Float64
+	w      float64
+}
+
+func planeFromPoints(a, b, c vector3.Float64) (plane, bool) {
+	normal := b.Sub(a).Cross(c.Sub(a))
+	length := normal.Length()
+	if length < 1e-12 {
+		return plane{}, false
+	}
+	normal = normal.DivByConstant(length)
+	return plane{normal: normal, w: normal.Dot(a)}, true
+}
+
+func (p plane) flip() plane {
+	return plane{normal: p.normal.Scale(-1), w: -p.w}
+}
+
+func (p plane) classify(v vector3.Float64) side {
+	t := p.normal.Dot(v) - p.w
+	if t < -epsilon {
+		return back
+	}
+	if t > epsilon {
+		return front
+	}
+	return coplanar
+}
+
+// split places the polygon in the list corresponding to which side of the
+// plane it falls on, cutting it in two if it spans the plane
+func (p plane) split(poly polygon, coplanarFront, coplanarBack, frontPolys, backPolys *[]polygon) {
+	polygonType := coplanar
+	types := make([]side, len(poly.vertices))
+	for i, v := range poly.vertices {
+		types[i] = p.classify(v.position)
+		polygonType |= types[i]
+	}
+
+	switch polygonType {
+	case coplanar:
+		if p.normal.Dot(poly.plane.normal) > 0 {
+			*coplanarFront = append(*coplanarFront, poly)
+		} else {
+			*coplanarBack = append(*coplanarBack, poly)
+		}
+
+	case front:
+		*frontPolys = append(*frontPolys, poly)
+
+	case back:
+		*backPolys = append(*backPolys, poly)
+
+	case spanning:
+		f := make([]vertex, 0, len(poly.vertices)+1)
+		b := make([]vertex, 0, len(poly.vertices)+1)
+		for i, vi := range poly.vertices {
+			j := (i + 1) % len(poly.vertices)
+			ti, tj := types[i], types[j]
+			vj := poly.vertices[j]
+
+			if ti != back {
+				f = append(f, vi)
+			}
+			if ti != front {
+				b = append(b, vi)
+			}
+
+			if (ti | tj) == spanning {
+				t := (p.w - p.normal.Dot(vi.position)) / p.normal.Dot(vj.position.Sub(vi.position))
+				v := vi.lerp(vj, t)
+				f = append(f, v)
+				b = append(b, v)
+			}
+		}
+
+		if len(f) >= 3 {
+			*frontPolys = append(*frontPolys, polygon{vertices: f, plane: poly.plane, source: poly.source})
+		}
+		if len(b) >= 3 {
+			*backPolys = append(*backPolys, polygon{vertices: b, plane: poly.plane, source: poly.source})
+		}
+	}
+}
+
+// polygon is a convex, planar polygon
+type polygon struct {
+	vertices []vertex
+	plane    plane
+
+	// Primitive the polygon was cut from, counting the primitives of the
+	// second mesh after those of the first
+	source int
+}
+
+// flip reverses the polygon's winding order. Any normals stored at the
+// vertices are flipped by the caller, since the polygon has no knowledge of
+// what its vertex data contains
+func (p polygon) flip(flipVertex func(vertex) vertex) polygon {
+	vertices := make([]vertex, len(p.vertices))
+	for i, v := range p.vertices {
+		vertices[len(vertices)-1-i] = flipVertex(v)
+	}
+	return polygon{vertices: vertices, plane: p.plane.flip(), source: p.source}
+}
+
+// bspNode is a node within a binary space partitioning tree, where every
+// polygon in front of the node's plane lives in the front subtree, and every
+// polygon behind it lives in the back subtree. The tree represents a solid,
+// with the polygons' front faces pointing outward.
+type bspNode struct {
+	plane    *plane
+	front    *bspNode
+	back     *bspNode
+	polygons []polygon
+}
+
+func newBSP(polygons []polygon) *bspNode {
+	node := &bspNode{}
+	node.build(polygons)
+	return node
+}
+
+// invert converts the solid space into empty space and vice versa
+func (n *bspNode) invert(flipVertex func(vertex) vertex) {
+	for i, p := range n.polygons {
+		n.polygons[i] = p.flip(flipVertex)
+	}
+
+	if n.plane != nil {
+		flipped := n.plane.flip()
+		n.plane = &flipped
+	}
+
+	if n.front != nil {
+		n.front.invert(flipVertex)
+	}
+
+	if n.back != nil {
+		n.back.invert(flipVertex)
+	}
+
+	n.front, n.back = n.back, n.front
+}
+
+// clipPolygons removes all portions of the polygons provided that are found
+// inside the solid this tree represents
+func (n *bspNode) clipPolygons(polygons []polygon) []polygon {
+	if n.plane == nil {
+		return append([]polygon(nil), polygons...)
+	}
+
+	frontPolys := make([]polygon, 0)
+	backPolys := make([]polygon, 0)
+	for _, p := range polygons {
+		n.plane.split(p, &frontPolys, &backPolys, &frontPolys, &backPolys)
+	}
+
+	if n.front != nil {
+		frontPolys = n.front.clipPolygons(frontPolys)
+	}
+
+	if n.back != nil {
+		backPolys = n.back.clipPolygons(backPolys)
+	} else {
+		backPolys = nil
+	}
+
+	return append(frontPolys, backPolys...)
+}
+
+// clipTo removes all polygons in this tree that are inside the other tree
+func (n *bspNode) clipTo(other *bspNode) {
+	n.polygons = other.clipPolygons(n.polygons)
+	if n.front != nil {
+		n.front.clipTo(other)
+	}
+	if n.back != nil {
+		n.back.clipTo(other)
+	}
+}
+
+func (n *bspNode) allPolygons() []polygon {
+	polygons := append([]polygon(nil), n.polygons...)
+	if n.front != nil {
+		polygons = append(polygons, n.front.allPolygons()...)
+	}
+	if n.back != nil {
+		polygons = append(polygons, n.back.allPolygons()...)
+	}
+	return polygons
+}
+
+// build inserts the polygons into the tree, using the first polygon's plane
+// to split the rest whenever a new node is required
+func (n *bspNode) build(polygons []polygon) {
+	if len(polygons) == 0 {
+		return
+	}
+
+	if n.plane == nil {
+		p := polygons[0].plane
+		n.plane = &p
+	}
+
+	frontPolys := make([]polygon, 0)
+	backPolys := make([]polygon, 0)
+	for _, p := range polygons {
+		n.plane.split(p, &n.polygons, &n.polygons, &frontPolys, &backPolys)
+	}
+
+	if len(frontPolys) > 0 {
+		if n.front == nil {
+			n.front = &bspNode{}
+		}
+		n.front.build(frontPolys)
+	}
+
+	if len(backPolys) > 0 {
+		if n.back == nil {
+			n.back = &bspNode{}
+		}
+		n.back.build(backPolys)
+	}
+}
@@ -0,0 +1,377 @@
+package csg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// attributeLayout describes how every attribute besides position is packed
+// into a vertex's data
+type attributeLayout struct {
+	float4 []string
+	float3 []string
+	float2 []string
+	float1 []string
+
+	// Offset of the normal attribute within the vertex data, or -1 if the
+	// meshes have no normals
+	normalOffset int
+}
+
+func unionOf(a, b []string) []string {
+	seen := make(map[string]bool)
+	out := make([]string, 0, len(a)+len(b))
+	for _, attrs := range [][]string{a, b} {
+		for _, attr := range attrs {
+			if seen[attr] {
+				continue
+			}
+			seen[attr] = true
+			out = append(out, attr)
+		}
+	}
+	return out
+}
+
+func newAttributeLayout(a, b modeling.Mesh) attributeLayout {
+	layout := attributeLayout{
+		float4:       unionOf(a.Float4Attributes(), b.Float4Attributes()),
+		float2:       unionOf(a.Float2Attributes(), b.Float2Attributes()),
+		float1:       unionOf(a.Float1Attributes(), b.Float1Attributes()),
+		normalOffset: -1,
+	}
+
+	for _, attr := range unionOf(a.Float3Attributes(), b.Float3Attributes()) {
+		if attr == modeling.PositionAttribute {
+			continue
+		}
+
+		if attr == modeling.NormalAttribute {
+			layout.normalOffset = (len(layout.float4) * 4) + (len(layout.float3) * 3)
+		}
+		layout.float3 = append(layout.float3, attr)
+	}
+
+	return layout
+}
+
+func (al attributeLayout) size() int {
+	return (len(al.float4) * 4) + (len(al.float3) * 3) + (len(al.float2) * 2) + len(al.float1)
+}
+
+// flipVertex negates the vertex's normal, if it has one
+func (al attributeLayout) flipVertex(v vertex) vertex {
+	if al.normalOffset == -1 {
+		return v
+	}
+
+	data := append([]float64(nil), v.data...)
+	for i := al.normalOffset; i < al.normalOffset+3; i++ {
+		data[i] = -data[i]
+	}
+	return vertex{position: v.position, data: data}
+}
+
+// vertices reads every vertex in the mesh, filling in zeros for any
+// attribute found in the layout that the mesh doesn't have
+func (al attributeLayout) vertices(m modeling.Mesh) []vertex {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	vertices := make([]vertex, positions.Len())
+	for i := range vertices {
+		vertices[i] = vertex{
+			position: positions.At(i),
+			data:     make([]float64, al.size()),
+		}
+	}
+
+	offset := 0
+	for _, attr := range al.float4 {
+		if m.HasFloat4Attribute(attr) {
+			m.ScanFloat4Attribute(attr, func(i int, v vector4.Float64) {
+				copy(vertices[i].data[offset:], []float64{v.X(), v.Y(), v.Z(), v.W()})
+			})
+		}
+		offset += 4
+	}
+
+	for _, attr := range al.float3 {
+		if m.HasFloat3Attribute(attr) {
+			m.ScanFloat3Attribute(attr, func(i int, v vector3.Float64) {
+				copy(vertices[i].data[offset:], []float64{v.X(), v.Y(), v.Z()})
+			})
+		}
+		offset += 3
+	}
+
+	for _, attr := range al.float2 {
+		if m.HasFloat2Attribute(attr) {
+			m.ScanFloat2Attribute(attr, func(i int, v vector2.Float64) {
+				copy(vertices[i].data[offset:], []float64{v.X(), v.Y()})
+			})
+		}
+		offset += 2
+	}
+
+	for _, attr := range al.float1 {
+		if m.HasFloat1Attribute(attr) {
+			m.ScanFloat1Attribute(attr, func(i int, v float64) {
+				vertices[i].data[offset] = v
+			})
+		}
+		offset++
+	}
+
+	return vertices
+}
+
+func (al attributeLayout) polygons(m modeling.Mesh, firstSource int) []polygon {
+	if m.PrimitiveCount() == 0 {
+		return nil
+	}
+
+	vertices := al.vertices(m)
+	polygons := make([]polygon, 0, m.PrimitiveCount())
+	indices := m.Indices()
+	for i := 0; i < indices.Len(); i += 3 {
+		a, b, c := vertices[indices.At(i)], vertices[indices.At(i+1)], vertices[indices.At(i+2)]
+
+		// Degenerate triangles have no plane to split space with
+		p, ok := planeFromPoints(a.position, b.position, c.position)
+		if !ok {
+			continue
+		}
+
+		polygons = append(polygons, polygon{
+			vertices: []vertex{a, b, c},
+			plane:    p,
+			source:   firstSource + (i / 3),
+		})
+	}
+	return polygons
+}
+
+func vertexKey(v vertex) string {
+	buf := make([]byte, 0, (3+len(v.data))*8)
+	for _, f := range []float64{v.position.X(), v.position.Y(), v.position.Z()} {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+	}
+	for _, f := range v.data {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+	}
+	return string(buf)
+}
+
+// mesh triangulates the polygons, welding together vertices that share the
+// exact same position and attribute values. Alongside the mesh, the source
+// primitive of each triangle is returned.
+func (al attributeLayout) mesh(polygons []polygon) (modeling.Mesh, []int) {
+	vertices := make([]vertex, 0)
+	lookup := make(map[string]int)
+	indices := make([]int, 0)
+	sources := make([]int, 0)
+
+	index := func(v vertex) int {
+		key := vertexKey(v)
+		if i, ok := lookup[key]; ok {
+			return i
+		}
+		lookup[key] = len(vertices)
+		vertices = append(vertices, v)
+		return len(vertices) - 1
+	}
+
+	for _, p := range polygons {
+		first := index(p.vertices[0])
+		for i := 2; i < len(p.vertices); i++ {
+			indices = append(indices, first, index(p.vertices[i-1]), index(p.vertices[i]))
+			sources = append(sources, p.source)
+		}
+	}
+
+	positions := make([]vector3.Float64, len(vertices))
+	for i, v := range vertices {
+		positions[i] = v.position
+	}
+
+	float4 := make(map[string][]vector4.Float64)
+	float3 := map[string][]vector3.Float64{modeling.PositionAttribute: positions}
+	float2 := make(map[string][]vector2.Float64)
+	float1 := make(map[string][]float64)
+
+	offset := 0
+	for _, attr := range al.float4 {
+		data := make([]vector4.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector4.New(v.data[offset], v.data[offset+1], v.data[offset+2], v.data[offset+3])
+		}
+		float4[attr] = data
+		offset += 4
+	}
+
+	for _, attr := range al.float3 {
+		data := make([]vector3.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector3.New(v.data[offset], v.data[offset+1], v.data[offset+2])
+		}
+
+		// Interpolating normals shortens them
+		if attr == modeling.NormalAttribute {
+			for i, n := range data {
+				if n.Length() > 0 {
+					data[i] = n.Normalized()
+				}
+			}
+		}
+
+		float3[attr] = data
+		offset += 3
+	}
+
+	for _, attr := range al.float2 {
+		data := make([]vector2.Float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = vector2.New(v.data[offset], v.data[offset+1])
+		}
+		float2[attr] = data
+		offset += 2
+	}
+
+	for _, attr := range al.float1 {
+		data := make([]float64, len(vertices))
+		for i, v := range vertices {
+			data[i] = v.data[offset]
+		}
+		float1[attr] = data
+		offset++
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat4Data(float4).
+		SetFloat3Data(float3).
+		SetFloat2Data(float2).
+		SetFloat1Data(float1), sources
+}
+
+// materialSource stands in for both meshes when assigning the result's
+// primitives to material slots, with the primitives of b following those of a
+func materialSource(a, b modeling.Mesh) modeling.Mesh {
+	source := modeling.NewTriangleMesh(make([]int, (a.PrimitiveCount()+b.PrimitiveCount())*3))
+	if !a.HasPrimitiveGroups() && !b.HasPrimitiveGroups() {
+		return source
+	}
+
+	groups := a.PrimitiveGroups()
+	for _, group := range b.PrimitiveGroups() {
+		primitives := make([]int, len(group.Primitives))
+		for i, primitive := range group.Primitives {
+			primitives[i] = primitive + a.PrimitiveCount()
+		}
+		groups = append(groups, modeling.PrimitiveGroup{
+			Material:   group.Material,
+			Primitives: primitives,
+		})
+	}
+	return source.SetPrimitiveGroups(groups)
+}
+
+func validate(m modeling.Mesh) error {
+	if m.Topology() != modeling.TriangleTopology {
+		return fmt.Errorf("mesh must be triangle topology, was instead %s", m.Topology())
+	}
+
+	if m.PrimitiveCount() > 0 && !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return fmt.Errorf("mesh is required to have the vector3 attribute: '%s'", modeling.PositionAttribute)
+	}
+
+	return nil
+}
+
+type operation func(a, b *bspNode, flip func(vertex) vertex) []polygon
+
+func apply(a, b modeling.Mesh, op operation) (modeling.Mesh, error) {
+	if err := validate(a); err != nil {
+		return modeling.EmptyMesh(modeling.TriangleTopology), fmt.Errorf("mesh a: %w", err)
+	}
+
+	if err := validate(b); err != nil {
+		return modeling.EmptyMesh(modeling.TriangleTopology), fmt.Errorf("mesh b: %w", err)
+	}
+
+	layout := newAttributeLayout(a, b)
+	polygons := op(
+		newBSP(layout.polygons(a, 0)),
+		newBSP(layout.polygons(b, a.PrimitiveCount())),
+		layout.flipVertex,
+	)
+	result, sources := layout.mesh(polygons)
+	return result.RemapPrimitiveGroups(materialSource(a, b), sources), nil
+}
+
+// Union combines the two watertight meshes, keeping everything that is
+// inside either one of them.
+//
+// Every float attribute found on either mesh is carried over to the result,
+// interpolated across any new vertices introduced where the meshes
+// intersect. Attributes only found on one of the meshes are filled with
+// zeros for the other. Each face stays in the material slot of the face it
+// was cut from.
+func Union(a, b modeling.Mesh) (modeling.Mesh, error) {
+	return apply(a, b, func(a, b *bspNode, flip func(vertex) vertex) []polygon {
+		a.clipTo(b)
+		b.clipTo(a)
+		b.invert(flip)
+		b.clipTo(a)
+		b.invert(flip)
+		a.build(b.allPolygons())
+		return a.allPolygons()
+	})
+}
+
+// Difference carves the watertight mesh b out of the watertight mesh a.
+// Attributes are carried over the same way as Union, with the normals of
+// the faces of b left lining the cavity flipped to point outward.
+func Difference(a, b modeling.Mesh) (modeling.Mesh, error) {
+	return apply(a, b, func(a, b *bspNode, flip func(vertex) vertex) []polygon {
+		// Nothing can be carved out of an empty mesh
+		if a.plane == nil {
+			return nil
+		}
+
+		a.invert(flip)
+		a.clipTo(b)
+		b.clipTo(a)
+		b.invert(flip)
+		b.clipTo(a)
+		b.invert(flip)
+		a.build(b.allPolygons())
+		a.invert(flip)
+		return a.allPolygons()
+	})
+}
+
+// Intersection keeps only the volume found inside both watertight meshes.
+// Attributes are carried over the same way as Union.
+func Intersection(a, b modeling.Mesh) (modeling.Mesh, error) {
+	return apply(a, b, func(a, b *bspNode, flip func(vertex) vertex) []polygon {
+		// An inverted empty tree would be treated as if it was empty rather
+		// than containing all of space
+		if a.plane == nil || b.plane == nil {
+			return nil
+		}
+
+		a.invert(flip)
+		b.clipTo(a)
+		b.invert(flip)
+		a.clipTo(b)
+		b.clipTo(a)
+		a.build(b.allPolygons())
+		a.invert(flip)
+		return a.allPolygons()
+	})
+}
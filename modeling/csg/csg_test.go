@@ -0,0 +1,162 @@
+package csg_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/csg"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cube builds a unit cube with an extra attribute recording each vertex's x
+// position, so we can check attributes are interpolated along with position
+func cube(offset vector3.Float64) modeling.Mesh {
+	m := primitives.UnitCube().Translate(offset)
+	xs := make([]float64, 0)
+	m.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		xs = append(xs, v.X())
+	})
+	return m.SetFloat1Attribute("x", xs)
+}
+
+func TestOperations(t *testing.T) {
+	tests := map[string]struct {
+		op     func(a, b modeling.Mesh) (modeling.Mesh, error)
+		volume float64
+	}{
+		"union":        {op: csg.Union, volume: 2 - 0.125},
+		"difference":   {op: csg.Difference, volume: 1 - 0.125},
+		"intersection": {op: csg.Intersection, volume: 0.125},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			a := cube(vector3.Zero[float64]())
+			b := cube(vector3.Fill(0.5))
+
+			// ACT ============================================================
+			result, err := tc.op(a, b)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			assert.Equal(t, modeling.TriangleTopology, result.Topology())
+			assert.InDelta(t, tc.volume, meshops.Volume(result, modeling.PositionAttribute), 1e-9)
+
+			positions := result.Float3Attribute(modeling.PositionAttribute)
+			xs := result.Float1Attribute("x")
+			normals := result.Float3Attribute(modeling.NormalAttribute)
+			for i := 0; i < positions.Len(); i++ {
+				assert.InDelta(t, positions.At(i).X(), xs.At(i), 1e-9)
+				assert.InDelta(t, 1., normals.At(i).Length(), 1e-9)
+			}
+
+			// Vertex normals should agree with the winding of the faces
+			result.ScanPrimitives(func(i int, p modeling.Primitive) {
+				tri := p.(modeling.Tri)
+				p1 := tri.P1Vec3Attr(modeling.PositionAttribute)
+				p2 := tri.P2Vec3Attr(modeling.PositionAttribute)
+				p3 := tri.P3Vec3Attr(modeling.PositionAttribute)
+				faceNormal := p2.Sub(p1).Cross(p3.Sub(p1))
+
+				vertexNormal := tri.P1Vec3Attr(modeling.NormalAttribute).
+					Add(tri.P2Vec3Attr(modeling.NormalAttribute)).
+					Add(tri.P3Vec3Attr(modeling.NormalAttribute))
+				assert.Greater(t, faceNormal.Dot(vertexNormal), 0.)
+			})
+		})
+	}
+}
+
+func TestOperations_Disjoint(t *testing.T) {
+	a := cube(vector3.Zero[float64]())
+	b := cube(vector3.New(3., 0., 0.))
+
+	union, err := csg.Union(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, 2., meshops.Volume(union, modeling.PositionAttribute), 1e-9)
+
+	difference, err := csg.Difference(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, 1., meshops.Volume(difference, modeling.PositionAttribute), 1e-9)
+
+	intersection, err := csg.Intersection(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 0, intersection.PrimitiveCount())
+}
+
+func TestOperations_Empty(t *testing.T) {
+	a := cube(vector3.Zero[float64]())
+	empty := modeling.EmptyMesh(modeling.TriangleTopology)
+
+	union, err := csg.Union(empty, a)
+	require.NoError(t, err)
+	assert.InDelta(t, 1., meshops.Volume(union, modeling.PositionAttribute), 1e-9)
+
+	difference, err := csg.Difference(empty, a)
+	require.NoError(t, err)
+	assert.Equal(t, 0, difference.PrimitiveCount())
+
+	intersection, err := csg.Intersection(a, empty)
+	require.NoError(t, err)
+	assert.Equal(t, 0, intersection.PrimitiveCount())
+}
+
+func TestOperations_MixedAttributes(t *testing.T) {
+	a := cube(vector3.Zero[float64]())
+	b := primitives.UnitCube().Translate(vector3.Fill(0.5))
+
+	union, err := csg.Union(a, b)
+	require.NoError(t, err)
+	require.True(t, union.HasFloat1Attribute("x"))
+
+	// Vertices that came from b should have their missing attribute zeroed
+	positions := union.Float3Attribute(modeling.PositionAttribute)
+	xs := union.Float1Attribute("x")
+	for i := 0; i < positions.Len(); i++ {
+		if positions.At(i).Distance(vector3.Fill(1.)) < 1e-9 {
+			assert.Equal(t, 0., xs.At(i))
+		}
+	}
+}
+
+func TestOperations_RequireTriangles(t *testing.T) {
+	_, err := csg.Union(modeling.EmptyPointcloud(), primitives.UnitCube())
+	assert.EqualError(t, err, "mesh a: mesh must be triangle topology, was instead point")
+}
+
+func TestOperations_KeepsMaterialSlots(t *testing.T) {
+	// ARRANGE ================================================================
+	a := primitives.UnitCube().SetMaterial("outer")
+	b := primitives.UnitCube().Translate(vector3.Fill(0.5)).SetMaterial("cutter")
+
+	// ACT ====================================================================
+	difference, err := csg.Difference(a, b)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"outer", "cutter"}, difference.MaterialSlots())
+
+	// Faces of a all lie along its surface, while those of b left lining
+	// the cavity sit within a
+	for i := 0; i < difference.PrimitiveCount(); i++ {
+		center := difference.Tri(i).Average(modeling.PositionAttribute)
+		onSurface := math.Abs(center.Abs().MaxComponent()-0.5) < 1e-9
+		if onSurface {
+			assert.Equal(t, "outer", difference.PrimitiveMaterial(i))
+		} else {
+			assert.Equal(t, "cutter", difference.PrimitiveMaterial(i))
+		}
+	}
+}
+
+func TestOperations_WithoutMaterialSlots(t *testing.T) {
+	union, err := csg.Union(cube(vector3.Zero[float64]()), cube(vector3.Fill(0.5)))
+	require.NoError(t, err)
+	assert.False(t, union.HasPrimitiveGroups())
+}
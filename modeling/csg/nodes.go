@@ -0,0 +1,97 @@
+package csg
+
+import (
+	"github.com/EliCDavis/polyform/generator"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/polyform/refutil"
+)
+
+func init() {
+	factory := &refutil.TypeFactory{}
+	refutil.RegisterType[UnionNode](factory)
+	refutil.RegisterType[DifferenceNode](factory)
+	refutil.RegisterType[IntersectionNode](factory)
+	generator.RegisterTypes(factory)
+}
+
+// fold applies the operation across every mesh connected, left to right
+func fold(meshes []nodes.Output[modeling.Mesh], op func(a, b modeling.Mesh) (modeling.Mesh, error)) nodes.StructOutput[modeling.Mesh] {
+	fallback := modeling.EmptyMesh(modeling.TriangleTopology)
+
+	connected := make([]modeling.Mesh, 0, len(meshes))
+	for _, m := range meshes {
+		if m != nil {
+			connected = append(connected, m.Value())
+		}
+	}
+
+	if len(connected) == 0 {
+		return nodes.NewStructOutput(fallback)
+	}
+
+	result := connected[0]
+	for i := 1; i < len(connected); i++ {
+		var err error
+		result, err = op(result, connected[i])
+		if err != nil {
+			out := nodes.NewStructOutput(fallback)
+			out.LogError(err)
+			return out
+		}
+	}
+
+	return nodes.NewStructOutput(result)
+}
+
+type UnionNode = nodes.Struct[UnionNodeData]
+
+type UnionNodeData struct {
+	Meshes []nodes.Output[modeling.Mesh] `description:"Watertight meshes to combine"`
+}
+
+func (und UnionNodeData) Description() string {
+	return "Combines watertight meshes into a single solid, removing any geometry found inside of them"
+}
+
+func (und UnionNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	return fold(und.Meshes, Union)
+}
+
+type DifferenceNode = nodes.Struct[DifferenceNodeData]
+
+type DifferenceNodeData struct {
+	A nodes.Output[modeling.Mesh] `description:"Watertight mesh to carve into"`
+	B nodes.Output[modeling.Mesh] `description:"Watertight mesh to carve out of A"`
+}
+
+func (dnd DifferenceNodeData) Description() string {
+	return "Removes the volume of mesh B from mesh A"
+}
+
+func (dnd DifferenceNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	fallback := modeling.EmptyMesh(modeling.TriangleTopology)
+	a := nodes.TryGetOutputValue(dnd.A, fallback)
+	if dnd.B == nil {
+		return nodes.NewStructOutput(a)
+	}
+
+	result, err := Difference(a, dnd.B.Value())
+	out := nodes.NewStructOutput(result)
+	out.LogError(err)
+	return out
+}
+
+type IntersectionNode = nodes.Struct[IntersectionNodeData]
+
+type IntersectionNodeData struct {
+	Meshes []nodes.Output[modeling.Mesh] `description:"Watertight meshes to intersect"`
+}
+
+func (ind IntersectionNodeData) Description() string {
+	return "Keeps only the volume shared by every watertight mesh"
+}
+
+func (ind IntersectionNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	return fold(ind.Meshes, Intersection)
+}
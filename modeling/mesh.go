@@ -22,8 +22,17 @@ type Mesh struct {
 	v3Data   map[string][]vector3.Float64
 	v2Data   map[string][]vector2.Float64
 	v1Data   map[string][]float64
+	i4Data   map[string][]vector4.Int
+	i3Data   map[string][]vector3.Int
+	i2Data   map[string][]vector2.Int
+	i1Data   map[string][]int
 	indices  []int
 	topology Topology
+
+	// Material slot names, and the slot each primitive is assigned to. Both
+	// are nil for meshes without primitive groups.
+	materials          []string
+	primitiveMaterials []int
 }
 
 // New Mesh creates a new mesh with the specified topology with all empty
@@ -36,6 +45,10 @@ func NewMesh(topo Topology, indices []int) Mesh {
 		v2Data:   make(map[string][]vector2.Float64),
 		v3Data:   make(map[string][]vector3.Float64),
 		v4Data:   make(map[string][]vector4.Float64),
+		i4Data:   make(map[string][]vector4.Int),
+		i3Data:   make(map[string][]vector3.Int),
+		i2Data:   make(map[string][]vector2.Int),
+		i1Data:   make(map[string][]int),
 	}
 }
 
@@ -49,6 +62,10 @@ func NewTriangleMesh(indices []int) Mesh {
 		v2Data:   make(map[string][]vector2.Float64),
 		v3Data:   make(map[string][]vector3.Float64),
 		v4Data:   make(map[string][]vector4.Float64),
+		i4Data:   make(map[string][]vector4.Int),
+		i3Data:   make(map[string][]vector3.Int),
+		i2Data:   make(map[string][]vector2.Int),
+		i1Data:   make(map[string][]int),
 	}
 }
 
@@ -113,6 +130,10 @@ func newImpliedIndicesMesh(
 		v3Data:   cleanedV3Data,
 		v2Data:   cleanedV2Data,
 		v1Data:   cleanedV1Data,
+		i4Data:   make(map[string][]vector4.Int),
+		i3Data:   make(map[string][]vector3.Int),
+		i2Data:   make(map[string][]vector2.Int),
+		i1Data:   make(map[string][]int),
 	}
 }
 
@@ -143,6 +164,10 @@ func EmptyMesh(topo Topology) Mesh {
 		v3Data:   make(map[string][]vector3.Float64),
 		v2Data:   make(map[string][]vector2.Float64),
 		v1Data:   make(map[string][]float64),
+		i4Data:   make(map[string][]vector4.Int),
+		i3Data:   make(map[string][]vector3.Int),
+		i2Data:   make(map[string][]vector2.Int),
+		i1Data:   make(map[string][]int),
 	}
 }
 
@@ -175,20 +200,35 @@ func (m Mesh) ToPointCloud() Mesh {
 		v3Data:   m.v3Data,
 		v2Data:   m.v2Data,
 		v1Data:   m.v1Data,
+		i4Data:   m.i4Data,
+		i3Data:   m.i3Data,
+		i2Data:   m.i2Data,
+		i1Data:   m.i1Data,
 		indices:  indices,
 		topology: PointTopology,
 	}
 }
 
+// SetIndices replaces the mesh's indices. Primitive groups are kept only if
+// the number of primitives remains the same.
 func (m Mesh) SetIndices(indices []int) Mesh {
-	return Mesh{
+	result := Mesh{
 		v4Data:   m.v4Data,
 		v3Data:   m.v3Data,
 		v2Data:   m.v2Data,
 		v1Data:   m.v1Data,
+		i4Data:   m.i4Data,
+		i3Data:   m.i3Data,
+		i2Data:   m.i2Data,
+		i1Data:   m.i1Data,
 		indices:  indices,
 		topology: m.topology,
 	}
+
+	if m.primitiveMaterials != nil && result.primitiveCount() == len(m.primitiveMaterials) {
+		return result.CopyPrimitiveGroups(m)
+	}
+	return result
 }
 
 func (m Mesh) Indices() *iter.ArrayIterator[int] {
@@ -321,19 +361,28 @@ func (m Mesh) Append(other Mesh) Mesh {
 	finalV3Data := appendData(m.v3Data, other.v3Data, mAtrLength, oAtrLength, func() vector3.Vector[float64] { return vector3.Zero[float64]() })
 	finalV4Data := appendData(m.v4Data, other.v4Data, mAtrLength, oAtrLength, func() vector4.Vector[float64] { return vector4.Zero[float64]() })
 
+	finalI1Data := appendData(m.i1Data, other.i1Data, mAtrLength, oAtrLength, func() int { return 0 })
+	finalI2Data := appendData(m.i2Data, other.i2Data, mAtrLength, oAtrLength, vector2.Zero[int])
+	finalI3Data := appendData(m.i3Data, other.i3Data, mAtrLength, oAtrLength, vector3.Zero[int])
+	finalI4Data := appendData(m.i4Data, other.i4Data, mAtrLength, oAtrLength, vector4.Zero[int])
+
 	finalTris := append(m.indices, other.indices...)
 	for i := len(m.indices); i < len(finalTris); i++ {
 		finalTris[i] += mAtrLength
 	}
 
-	return Mesh{
+	return appendPrimitiveGroups(Mesh{
 		v1Data:   finalV1Data,
 		v2Data:   finalV2Data,
 		v3Data:   finalV3Data,
 		v4Data:   finalV4Data,
+		i4Data:   finalI4Data,
+		i3Data:   finalI3Data,
+		i2Data:   finalI2Data,
+		i1Data:   finalI1Data,
 		indices:  finalTris,
 		topology: m.topology,
-	}
+	}, m, other)
 }
 
 func (m Mesh) Rotate(q quaternion.Quaternion) Mesh {
@@ -837,8 +886,10 @@ func (m Mesh) WeldByFloat3Attribute(attribute string, decimalPlace int) Mesh {
 		}
 	}
 
-	// Building tris from unique vertices
+	// Building tris from unique vertices, tracking which of the original
+	// triangles each came from
 	newTris := make([]int, 0)
+	keptTris := make([]int, 0)
 	for triI := 0; triI < len(m.indices); triI += 3 {
 		v1 := Vector3ToInt(data[m.indices[triI+0]], decimalPlace)
 		v2 := Vector3ToInt(data[m.indices[triI+1]], decimalPlace)
@@ -860,6 +911,7 @@ func (m Mesh) WeldByFloat3Attribute(attribute string, decimalPlace int) Mesh {
 		vertLUUsed[v2] = true
 		vertLUUsed[v3] = true
 		newTris = append(newTris, vertILU[v1], vertILU[v2], vertILU[v3])
+		keptTris = append(keptTris, triI/3)
 	}
 
 	finalV4Data := make(map[string][]vector4.Float64)
@@ -882,6 +934,26 @@ func (m Mesh) WeldByFloat3Attribute(attribute string, decimalPlace int) Mesh {
 		finalV1Data[key] = make([]float64, 0)
 	}
 
+	finalI4Data := make(map[string][]vector4.Int)
+	for key := range m.i4Data {
+		finalI4Data[key] = make([]vector4.Int, 0)
+	}
+
+	finalI3Data := make(map[string][]vector3.Int)
+	for key := range m.i3Data {
+		finalI3Data[key] = make([]vector3.Int, 0)
+	}
+
+	finalI2Data := make(map[string][]vector2.Int)
+	for key := range m.i2Data {
+		finalI2Data[key] = make([]vector2.Int, 0)
+	}
+
+	finalI1Data := make(map[string][]int)
+	for key := range m.i1Data {
+		finalI1Data[key] = make([]int, 0)
+	}
+
 	shiftBy := make([]int, uniqueVertCount)
 	curShift := 0
 	for vertIndex := 0; vertIndex < uniqueVertCount; vertIndex++ {
@@ -906,6 +978,22 @@ func (m Mesh) WeldByFloat3Attribute(attribute string, decimalPlace int) Mesh {
 				finalV1Data[key] = append(finalV1Data[key], vals[originalIndex])
 			}
 
+			for key, vals := range m.i4Data {
+				finalI4Data[key] = append(finalI4Data[key], vals[originalIndex])
+			}
+
+			for key, vals := range m.i3Data {
+				finalI3Data[key] = append(finalI3Data[key], vals[originalIndex])
+			}
+
+			for key, vals := range m.i2Data {
+				finalI2Data[key] = append(finalI2Data[key], vals[originalIndex])
+			}
+
+			for key, vals := range m.i1Data {
+				finalI1Data[key] = append(finalI1Data[key], vals[originalIndex])
+			}
+
 		} else {
 			// Not used, need to shift triangles who's points point to vertices that come after this unsed one
 			curShift++
@@ -924,8 +1012,12 @@ func (m Mesh) WeldByFloat3Attribute(attribute string, decimalPlace int) Mesh {
 		v3Data:   finalV3Data,
 		v2Data:   finalV2Data,
 		v1Data:   finalV1Data,
+		i4Data:   finalI4Data,
+		i3Data:   finalI3Data,
+		i2Data:   finalI2Data,
+		i1Data:   finalI1Data,
 		topology: m.topology,
-	}
+	}.RemapPrimitiveGroups(m, keptTris)
 }
 
 func (m Mesh) VertexNeighborTable() VertexLUT {
@@ -989,23 +1081,35 @@ func (m Mesh) SetFloat4Attribute(attr string, data []vector4.Float64) Mesh {
 	}
 
 	return Mesh{
-		v4Data:   finalV4Data,
-		v3Data:   m.v3Data,
-		v2Data:   m.v2Data,
-		v1Data:   m.v1Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v4Data:             finalV4Data,
+		v3Data:             m.v3Data,
+		v2Data:             m.v2Data,
+		v1Data:             m.v1Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
 func (m Mesh) SetFloat4Data(data map[string][]vector4.Float64) Mesh {
 	return Mesh{
-		v1Data:   m.v1Data,
-		v2Data:   m.v2Data,
-		v3Data:   m.v3Data,
-		v4Data:   data,
-		indices:  m.indices,
-		topology: m.topology,
+		v1Data:             m.v1Data,
+		v2Data:             m.v2Data,
+		v3Data:             m.v3Data,
+		v4Data:             data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
@@ -1026,23 +1130,35 @@ func (m Mesh) SetFloat3Attribute(attr string, data []vector3.Float64) Mesh {
 	}
 
 	return Mesh{
-		v4Data:   m.v4Data,
-		v3Data:   finalV3Data,
-		v2Data:   m.v2Data,
-		v1Data:   m.v1Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v4Data:             m.v4Data,
+		v3Data:             finalV3Data,
+		v2Data:             m.v2Data,
+		v1Data:             m.v1Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
 func (m Mesh) SetFloat3Data(data map[string][]vector3.Float64) Mesh {
 	return Mesh{
-		v1Data:   m.v1Data,
-		v2Data:   m.v2Data,
-		v3Data:   data,
-		v4Data:   m.v4Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v1Data:             m.v1Data,
+		v2Data:             m.v2Data,
+		v3Data:             data,
+		v4Data:             m.v4Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
@@ -1079,23 +1195,35 @@ func (m Mesh) SetFloat2Attribute(attr string, data []vector2.Float64) Mesh {
 	}
 
 	return Mesh{
-		v4Data:   m.v4Data,
-		v3Data:   m.v3Data,
-		v2Data:   finalV2Data,
-		v1Data:   m.v1Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v4Data:             m.v4Data,
+		v3Data:             m.v3Data,
+		v2Data:             finalV2Data,
+		v1Data:             m.v1Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
 func (m Mesh) SetFloat2Data(data map[string][]vector2.Float64) Mesh {
 	return Mesh{
-		v1Data:   m.v1Data,
-		v2Data:   data,
-		v3Data:   m.v3Data,
-		v4Data:   m.v4Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v1Data:             m.v1Data,
+		v2Data:             data,
+		v3Data:             m.v3Data,
+		v4Data:             m.v4Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
@@ -1116,34 +1244,52 @@ func (m Mesh) SetFloat1Attribute(attr string, data []float64) Mesh {
 	}
 
 	return Mesh{
-		v4Data:   m.v4Data,
-		v3Data:   m.v3Data,
-		v2Data:   m.v2Data,
-		v1Data:   finalV1Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v4Data:             m.v4Data,
+		v3Data:             m.v3Data,
+		v2Data:             m.v2Data,
+		v1Data:             finalV1Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
 func (m Mesh) SetFloat1Data(data map[string][]float64) Mesh {
 	return Mesh{
-		v1Data:   data,
-		v2Data:   m.v2Data,
-		v3Data:   m.v3Data,
-		v4Data:   m.v4Data,
-		indices:  m.indices,
-		topology: m.topology,
+		v1Data:             data,
+		v2Data:             m.v2Data,
+		v3Data:             m.v3Data,
+		v4Data:             m.v4Data,
+		i4Data:             m.i4Data,
+		i3Data:             m.i3Data,
+		i2Data:             m.i2Data,
+		i1Data:             m.i1Data,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
 func (m Mesh) ClearAttributeData() Mesh {
 	return Mesh{
-		v1Data:   nil,
-		v2Data:   nil,
-		v3Data:   nil,
-		v4Data:   nil,
-		indices:  m.indices,
-		topology: m.topology,
+		v1Data:             nil,
+		v2Data:             nil,
+		v3Data:             nil,
+		v4Data:             nil,
+		i4Data:             nil,
+		i3Data:             nil,
+		i2Data:             nil,
+		i1Data:             nil,
+		indices:            m.indices,
+		topology:           m.topology,
+		materials:          m.materials,
+		primitiveMaterials: m.primitiveMaterials,
 	}
 }
 
@@ -1164,7 +1310,7 @@ func (m Mesh) HasVertexAttribute(attribute string) bool {
 		return true
 	}
 
-	return false
+	return m.HasIntAttribute(attribute)
 }
 
 func (m Mesh) HasFloat4Attribute(attribute string) bool {
@@ -1224,7 +1370,7 @@ func (m Mesh) AttributeLength() int {
 	for _, v := range m.v1Data {
 		return len(v)
 	}
-	return 0
+	return m.intAttributeLength()
 }
 
 func (m Mesh) Translate(v vector3.Float64) Mesh {
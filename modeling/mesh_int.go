@@ -0,0 +1,291 @@
+package modeling
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// Integer attributes hold per vertex data that has no business being
+// interpolated or stored as a float, like joint indices, classifications,
+// and ids. Values are stored as int, wide enough to hold any of the signed
+// or unsigned integer types found within the formats polyform reads.
+
+func sortedAttributes[T any](data map[string][]T) []string {
+	attributes := make([]string, 0, len(data))
+	for atr := range data {
+		attributes = append(attributes, atr)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+func setAttributeData[T any](data map[string][]T, attr string, values []T) map[string][]T {
+	final := make(map[string][]T, len(data)+1)
+	for key, val := range data {
+		final[key] = val
+	}
+	final[attr] = values
+
+	if len(values) == 0 {
+		delete(final, attr)
+	}
+	return final
+}
+
+func modifyAttributeData[T any](data []T, f func(i int, v T) T) []T {
+	modified := make([]T, len(data))
+	for i, v := range data {
+		modified[i] = f(i, v)
+	}
+	return modified
+}
+
+func (m Mesh) intAttributeLength() int {
+	for _, v := range m.i4Data {
+		return len(v)
+	}
+	for _, v := range m.i3Data {
+		return len(v)
+	}
+	for _, v := range m.i2Data {
+		return len(v)
+	}
+	for _, v := range m.i1Data {
+		return len(v)
+	}
+	return 0
+}
+
+// HasIntAttribute reports whether or not the mesh contains an integer
+// attribute of any dimension with the name provided
+func (m Mesh) HasIntAttribute(attribute string) bool {
+	return m.HasInt4Attribute(attribute) ||
+		m.HasInt3Attribute(attribute) ||
+		m.HasInt2Attribute(attribute) ||
+		m.HasInt1Attribute(attribute)
+}
+
+func (m Mesh) requireIntAttribute(has bool, attr string) {
+	if !has {
+		panic(fmt.Errorf("can not perform operation for a mesh without the integer attribute '%s'", attr))
+	}
+}
+
+// INT 4 =======================================================================
+
+func (m Mesh) Int4Attributes() []string {
+	return sortedAttributes(m.i4Data)
+}
+
+func (m Mesh) HasInt4Attribute(attribute string) bool {
+	_, ok := m.i4Data[attribute]
+	return ok
+}
+
+func (m Mesh) Int4Attribute(attr string) *iter.ArrayIterator[vector4.Int] {
+	m.requireIntAttribute(m.HasInt4Attribute(attr), attr)
+	return iter.Array(m.i4Data[attr])
+}
+
+func (m Mesh) SetInt4Attribute(attr string, data []vector4.Int) Mesh {
+	m.i4Data = setAttributeData(m.i4Data, attr, data)
+	return m
+}
+
+func (m Mesh) SetInt4Data(data map[string][]vector4.Int) Mesh {
+	m.i4Data = data
+	return m
+}
+
+func (m Mesh) CopyInt4Attribute(src Mesh, attr string) Mesh {
+	return m.SetInt4Attribute(attr, src.i4Data[attr])
+}
+
+func (m Mesh) ScanInt4Attribute(attr string, f func(i int, v vector4.Int)) Mesh {
+	m.requireIntAttribute(m.HasInt4Attribute(attr), attr)
+	for i, v := range m.i4Data[attr] {
+		f(i, v)
+	}
+	return m
+}
+
+func (m Mesh) ModifyInt4Attribute(attr string, f func(i int, v vector4.Int) vector4.Int) Mesh {
+	m.requireIntAttribute(m.HasInt4Attribute(attr), attr)
+	return m.SetInt4Attribute(attr, modifyAttributeData(m.i4Data[attr], f))
+}
+
+// INT 3 =======================================================================
+
+func (m Mesh) Int3Attributes() []string {
+	return sortedAttributes(m.i3Data)
+}
+
+func (m Mesh) HasInt3Attribute(attribute string) bool {
+	_, ok := m.i3Data[attribute]
+	return ok
+}
+
+func (m Mesh) Int3Attribute(attr string) *iter.ArrayIterator[vector3.Int] {
+	m.requireIntAttribute(m.HasInt3Attribute(attr), attr)
+	return iter.Array(m.i3Data[attr])
+}
+
+func (m Mesh) SetInt3Attribute(attr string, data []vector3.Int) Mesh {
+	m.i3Data = setAttributeData(m.i3Data, attr, data)
+	return m
+}
+
+func (m Mesh) SetInt3Data(data map[string][]vector3.Int) Mesh {
+	m.i3Data = data
+	return m
+}
+
+func (m Mesh) CopyInt3Attribute(src Mesh, attr string) Mesh {
+	return m.SetInt3Attribute(attr, src.i3Data[attr])
+}
+
+func (m Mesh) ScanInt3Attribute(attr string, f func(i int, v vector3.Int)) Mesh {
+	m.requireIntAttribute(m.HasInt3Attribute(attr), attr)
+	for i, v := range m.i3Data[attr] {
+		f(i, v)
+	}
+	return m
+}
+
+func (m Mesh) ModifyInt3Attribute(attr string, f func(i int, v vector3.Int) vector3.Int) Mesh {
+	m.requireIntAttribute(m.HasInt3Attribute(attr), attr)
+	return m.SetInt3Attribute(attr, modifyAttributeData(m.i3Data[attr], f))
+}
+
+// INT 2 =======================================================================
+
+func (m Mesh) Int2Attributes() []string {
+	return sortedAttributes(m.i2Data)
+}
+
+func (m Mesh) HasInt2Attribute(attribute string) bool {
+	_, ok := m.i2Data[attribute]
+	return ok
+}
+
+func (m Mesh) Int2Attribute(attr string) *iter.ArrayIterator[vector2.Int] {
+	m.requireIntAttribute(m.HasInt2Attribute(attr), attr)
+	return iter.Array(m.i2Data[attr])
+}
+
+func (m Mesh) SetInt2Attribute(attr string, data []vector2.Int) Mesh {
+	m.i2Data = setAttributeData(m.i2Data, attr, data)
+	return m
+}
+
+func (m Mesh) SetInt2Data(data map[string][]vector2.Int) Mesh {
+	m.i2Data = data
+	return m
+}
+
+func (m Mesh) CopyInt2Attribute(src Mesh, attr string) Mesh {
+	return m.SetInt2Attribute(attr, src.i2Data[attr])
+}
+
+func (m Mesh) ScanInt2Attribute(attr string, f func(i int, v vector2.Int)) Mesh {
+	m.requireIntAttribute(m.HasInt2Attribute(attr), attr)
+	for i, v := range m.i2Data[attr] {
+		f(i, v)
+	}
+	return m
+}
+
+func (m Mesh) ModifyInt2Attribute(attr string, f func(i int, v vector2.Int) vector2.Int) Mesh {
+	m.requireIntAttribute(m.HasInt2Attribute(attr), attr)
+	return m.SetInt2Attribute(attr, modifyAttributeData(m.i2Data[attr], f))
+}
+
+// INT 1 =======================================================================
+
+func (m Mesh) Int1Attributes() []string {
+	return sortedAttributes(m.i1Data)
+}
+
+func (m Mesh) HasInt1Attribute(attribute string) bool {
+	_, ok := m.i1Data[attribute]
+	return ok
+}
+
+func (m Mesh) Int1Attribute(attr string) *iter.ArrayIterator[int] {
+	m.requireIntAttribute(m.HasInt1Attribute(attr), attr)
+	return iter.Array(m.i1Data[attr])
+}
+
+func (m Mesh) SetInt1Attribute(attr string, data []int) Mesh {
+	m.i1Data = setAttributeData(m.i1Data, attr, data)
+	return m
+}
+
+func (m Mesh) SetInt1Data(data map[string][]int) Mesh {
+	m.i1Data = data
+	return m
+}
+
+func (m Mesh) CopyInt1Attribute(src Mesh, attr string) Mesh {
+	return m.SetInt1Attribute(attr, src.i1Data[attr])
+}
+
+func (m Mesh) ScanInt1Attribute(attr string, f func(i int, v int)) Mesh {
+	m.requireIntAttribute(m.HasInt1Attribute(attr), attr)
+	for i, v := range m.i1Data[attr] {
+		f(i, v)
+	}
+	return m
+}
+
+func (m Mesh) ModifyInt1Attribute(attr string, f func(i int, v int) int) Mesh {
+	m.requireIntAttribute(m.HasInt1Attribute(attr), attr)
+	return m.SetInt1Attribute(attr, modifyAttributeData(m.i1Data[attr], f))
+}
+
+// CopyIntAttributes replaces the mesh's integer attributes with all of the
+// integer attributes found within the source mesh
+func (m Mesh) CopyIntAttributes(src Mesh) Mesh {
+	m.i4Data = src.i4Data
+	m.i3Data = src.i3Data
+	m.i2Data = src.i2Data
+	m.i1Data = src.i1Data
+	return m
+}
+
+func remapAttributeData[T any](data map[string][]T, sources []int) map[string][]T {
+	out := make(map[string][]T, len(data))
+	if len(sources) == 0 {
+		return out
+	}
+
+	for attr, vals := range data {
+		remapped := make([]T, len(sources))
+		for i, source := range sources {
+			if source >= 0 {
+				remapped[i] = vals[source]
+			}
+		}
+		out[attr] = remapped
+	}
+	return out
+}
+
+// RemapIntAttributes sets the mesh's integer attributes to the values of the
+// source mesh's vertices they originated from, where sources[i] is the vertex
+// within the source mesh that vertex i was built from. Vertices with a
+// negative source are assigned zero values. Integer data can't be blended, so
+// operations that build vertices from multiple others should pass the most
+// influential one.
+func (m Mesh) RemapIntAttributes(src Mesh, sources []int) Mesh {
+	m.i4Data = remapAttributeData(src.i4Data, sources)
+	m.i3Data = remapAttributeData(src.i3Data, sources)
+	m.i2Data = remapAttributeData(src.i2Data, sources)
+	m.i1Data = remapAttributeData(src.i1Data, sources)
+	return m
+}
@@ -0,0 +1,136 @@
+package modeling_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntAttributes_SetScanModify(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(0., 1., 0.),
+		}).
+		SetInt1Attribute(modeling.ClassAttribute, []int{2, 6, 9}).
+		SetInt4Attribute(modeling.JointAttribute, []vector4.Int{
+			vector4.New(0, 1, 0, 0),
+			vector4.New(1, 2, 0, 0),
+			vector4.New(2, 0, 0, 0),
+		})
+
+	// ACT ====================================================================
+	sum := 0
+	mesh.ScanInt1Attribute(modeling.ClassAttribute, func(i, v int) {
+		sum += v
+	})
+	shifted := mesh.ModifyInt4Attribute(modeling.JointAttribute, func(i int, v vector4.Int) vector4.Int {
+		return v.Add(vector4.New(10, 10, 10, 10))
+	})
+	cleared := mesh.SetInt1Attribute(modeling.ClassAttribute, nil)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 17, sum)
+	assert.Equal(t, 3, mesh.AttributeLength())
+	assert.True(t, mesh.HasVertexAttribute(modeling.ClassAttribute))
+	assert.True(t, mesh.HasIntAttribute(modeling.JointAttribute))
+	assert.False(t, mesh.HasFloat4Attribute(modeling.JointAttribute))
+	assert.Equal(t, []string{modeling.ClassAttribute}, mesh.Int1Attributes())
+	assert.Equal(t, []string{modeling.JointAttribute}, mesh.Int4Attributes())
+
+	assert.Equal(t, vector4.New(11, 12, 10, 10), shifted.Int4Attribute(modeling.JointAttribute).At(1))
+	assert.Equal(t, vector4.New(1, 2, 0, 0), mesh.Int4Attribute(modeling.JointAttribute).At(1))
+
+	assert.False(t, cleared.HasInt1Attribute(modeling.ClassAttribute))
+	assert.True(t, mesh.HasInt1Attribute(modeling.ClassAttribute))
+}
+
+func TestIntAttributes_AppendFillsMissingWithZero(t *testing.T) {
+	// ARRANGE ================================================================
+	meshA := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat1Attribute("weight", []float64{0, 1, 2}).
+		SetInt1Attribute("id", []int{7, 8, 9})
+
+	meshB := modeling.NewTriangleMesh([]int{0, 1, 2}).
+		SetFloat1Attribute("weight", []float64{3, 4, 5})
+
+	// ACT ====================================================================
+	appended := meshA.Append(meshB)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 6, appended.AttributeLength())
+	ids := appended.Int1Attribute("id")
+	assert.Equal(t, 6, ids.Len())
+	assert.Equal(t, 9, ids.At(2))
+	assert.Equal(t, 0, ids.At(3))
+}
+
+func TestIntAttributes_TransformsKeepIntegers(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.NewTriangleMesh([]int{0, 1, 2, 2, 3, 0}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(1., 1., 0.),
+		}).
+		SetInt1Attribute("id", []int{10, 11, 12, 12})
+
+	// ACT ====================================================================
+	welded := mesh.WeldByFloat3Attribute(modeling.PositionAttribute, 3)
+	translated := mesh.Translate(vector3.New(1., 2., 3.))
+	cloud := mesh.ToPointCloud()
+
+	// ASSERT =================================================================
+	assert.Equal(t, 3, welded.AttributeLength())
+	assert.Equal(t, []int{10, 11, 12}, []int{
+		welded.Int1Attribute("id").At(0),
+		welded.Int1Attribute("id").At(1),
+		welded.Int1Attribute("id").At(2),
+	})
+	assert.True(t, translated.HasInt1Attribute("id"))
+	assert.True(t, cloud.HasInt1Attribute("id"))
+}
+
+func TestRemapIntAttributes(t *testing.T) {
+	// ARRANGE ================================================================
+	src := modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+		modeling.PositionAttribute: {
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(2., 0., 0.),
+		},
+	}, nil, nil).SetInt1Attribute("id", []int{4, 5, 6})
+
+	dst := modeling.NewPointCloud(nil, map[string][]vector3.Float64{
+		modeling.PositionAttribute: {
+			vector3.New(2., 0., 0.),
+			vector3.New(0., 0., 0.),
+			vector3.New(5., 0., 0.),
+		},
+	}, nil, nil)
+
+	// ACT ====================================================================
+	remapped := dst.RemapIntAttributes(src, []int{2, 0, -1})
+
+	// ASSERT =================================================================
+	ids := remapped.Int1Attribute("id")
+	assert.Equal(t, 6, ids.At(0))
+	assert.Equal(t, 4, ids.At(1))
+	assert.Equal(t, 0, ids.At(2))
+}
+
+func TestIntAttributes_MissingPanics(t *testing.T) {
+	mesh := modeling.EmptyPointcloud()
+	assert.Panics(t, func() {
+		mesh.Int1Attribute("id")
+	})
+	assert.Panics(t, func() {
+		mesh.ModifyInt2Attribute("id", nil)
+	})
+}
@@ -0,0 +1,241 @@
+package meshops
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const AmbientOcclusionAttribute = "AmbientOcclusion"
+
+type AmbientOcclusionTransformer struct {
+	// Attribute the occlusion is written to, defaults to AmbientOcclusion
+	Attribute string
+
+	// Rays cast from each vertex, defaults to 64
+	Samples int
+
+	// Geometry further away than this doesn't occlude a vertex. Zero lets
+	// geometry anywhere in the mesh occlude it.
+	MaxDistance float64
+
+	Seed uint64
+}
+
+func (aot AmbientOcclusionTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	return aot.TransformContext(context.Background(), m)
+}
+
+// TransformContext is Transform, but stops casting rays once the context is
+// done, returning the context's error
+func (aot AmbientOcclusionTransformer) TransformContext(ctx context.Context, m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	attribute := aot.Attribute
+	if attribute == "" {
+		attribute = AmbientOcclusionAttribute
+	}
+
+	return AmbientOcclusionContext(ctx, m, attribute, aot.Samples, aot.MaxDistance, aot.Seed)
+}
+
+// AmbientOcclusion casts rays from each vertex out across the hemisphere
+// around its normal, writing the fraction of rays that escape the mesh to
+// the float1 attribute specified. Vertices out in the open receive 1, while
+// those tucked away in crevices approach 0. Rays are cosine weighted, and
+// normals are taken from the mesh's normal attribute, or smoothed from its
+// faces if it has none. The same seed always produces the same occlusion.
+func AmbientOcclusion(m modeling.Mesh, attribute string, samples int, maxDistance float64, seed uint64) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	occluded, _ := AmbientOcclusionContext(context.Background(), m, attribute, samples, maxDistance, seed)
+	return occluded
+}
+
+// AmbientOcclusionContext is AmbientOcclusion, but checks for cancellation
+// before each vertex, returning the context's error if it's done before
+// every vertex has been ray cast.
+func AmbientOcclusionContext(ctx context.Context, m modeling.Mesh, attribute string, samples int, maxDistance float64, seed uint64) (modeling.Mesh, error) {
+	if err := RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return m, err
+	}
+
+	if err := RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return m, err
+	}
+
+	if samples <= 0 {
+		samples = 64
+	}
+
+	if !m.HasFloat3Attribute(modeling.NormalAttribute) {
+		m = SmoothNormals(m)
+	}
+
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	normals := m.Float3Attribute(modeling.NormalAttribute)
+	indices := m.Indices()
+	tree := m.OctTree()
+
+	bounds := tree.BoundingBox()
+	diagonal := bounds.Size().Length()
+	if maxDistance <= 0 {
+		maxDistance = diagonal
+	}
+
+	// Rays start just off of the surface so they don't immediately hit the
+	// faces surrounding the vertex they're cast from
+	bias := math.Max(diagonal*1e-5, 1e-9)
+
+	occluded := func(origin, dir vector3.Float64) bool {
+		hit := false
+		tree.TraverseIntersectingRay(geometry.NewRay(origin, dir), bias, maxDistance, func(i int, min, max *float64) {
+			t, ok := rayTriangle(
+				origin, dir,
+				positions.At(indices.At(i*3)),
+				positions.At(indices.At(i*3+1)),
+				positions.At(indices.At(i*3+2)),
+			)
+			if ok && t > *min && t < *max {
+				hit = true
+				*max = t
+			}
+		})
+		return hit
+	}
+
+	occlusion := make([]float64, positions.Len())
+
+	var wg sync.WaitGroup
+	workers := runtime.NumCPU()
+	for worker := range workers {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for vi := start; vi < len(occlusion); vi += workers {
+				if ctx.Err() != nil {
+					return
+				}
+
+				normal := normals.At(vi)
+				if normal.LengthSquared() == 0 {
+					occlusion[vi] = 1
+					continue
+				}
+				normal = normal.Normalized()
+				tangent, bitangent := orthonormalBasis(normal)
+				origin := positions.At(vi).Add(normal.Scale(bias))
+
+				// Seeding by vertex keeps results the same no matter how the
+				// vertices are split across workers
+				r := rand.New(rand.NewPCG(seed, uint64(vi)))
+				escaped := 0
+				for range samples {
+					phi := 2 * math.Pi * r.Float64()
+					r2 := r.Float64()
+					radius := math.Sqrt(r2)
+					dir := tangent.Scale(math.Cos(phi) * radius).
+						Add(bitangent.Scale(math.Sin(phi) * radius)).
+						Add(normal.Scale(math.Sqrt(1 - r2)))
+
+					if !occluded(origin, dir) {
+						escaped++
+					}
+				}
+				occlusion[vi] = float64(escaped) / float64(samples)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return m, err
+	}
+
+	return m.SetFloat1Attribute(attribute, occlusion), nil
+}
+
+// orthonormalBasis builds two unit vectors perpendicular to n and to each
+// other
+func orthonormalBasis(n vector3.Float64) (vector3.Float64, vector3.Float64) {
+	up := vector3.Up[float64]()
+	if math.Abs(n.Y()) > 0.9 {
+		up = vector3.Right[float64]()
+	}
+	tangent := up.Cross(n).Normalized()
+	return tangent, n.Cross(tangent)
+}
+
+// rayTriangle returns the distance along the ray to where it passes through
+// the triangle, using the Möller–Trumbore algorithm
+func rayTriangle(origin, dir, v0, v1, v2 vector3.Float64) (float64, bool) {
+	const epsilon = 1e-12
+
+	v0v1 := v1.Sub(v0)
+	v0v2 := v2.Sub(v0)
+	pvec := dir.Cross(v0v2)
+	det := v0v1.Dot(pvec)
+	if math.Abs(det) < epsilon {
+		return 0, false
+	}
+	invDet := 1. / det
+
+	tvec := origin.Sub(v0)
+	u := tvec.Dot(pvec) * invDet
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+
+	qvec := tvec.Cross(v0v1)
+	v := dir.Dot(qvec) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+
+	return v0v2.Dot(qvec) * invDet, true
+}
+
+type AmbientOcclusionNode = nodes.Struct[AmbientOcclusionNodeData]
+
+type AmbientOcclusionNodeData struct {
+	Mesh        nodes.Output[modeling.Mesh]
+	Attribute   nodes.Output[string]  `description:"Float1 attribute to write the occlusion to, defaults to AmbientOcclusion"`
+	Samples     nodes.Output[int]     `description:"Rays cast from each vertex"`
+	MaxDistance nodes.Output[float64] `description:"Geometry further away than this doesn't occlude a vertex. Zero lets geometry anywhere in the mesh occlude it"`
+	Seed        nodes.Output[int]     `description:"Seed for the random ray directions"`
+}
+
+func (aon AmbientOcclusionNodeData) Description() string {
+	return "Ray casts each vertex against the rest of the mesh, recording how exposed it is, from 0 (fully occluded) to 1 (fully open)"
+}
+
+func (aon AmbientOcclusionNodeData) Out(ctx context.Context) nodes.StructOutput[modeling.Mesh] {
+	if aon.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := AmbientOcclusionTransformer{
+		Attribute:   nodes.TryGetOutputValue(aon.Attribute, AmbientOcclusionAttribute),
+		Samples:     nodes.TryGetOutputValue(aon.Samples, 64),
+		MaxDistance: nodes.TryGetOutputValue(aon.MaxDistance, 0.),
+		Seed:        uint64(nodes.TryGetOutputValue(aon.Seed, 0)),
+	}.TransformContext(ctx, aon.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
@@ -0,0 +1,100 @@
+package meshops_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// square builds a 2x2 square facing up or down at the height provided
+func square(height float64, up bool) modeling.Mesh {
+	indices := []int{0, 1, 2, 0, 2, 3}
+	if !up {
+		indices = []int{0, 2, 1, 0, 3, 2}
+	}
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(-1., height, -1.),
+			vector3.New(-1., height, 1.),
+			vector3.New(1., height, 1.),
+			vector3.New(1., height, -1.),
+		})
+}
+
+func TestAmbientOcclusionTransformer(t *testing.T) {
+	tests := map[string]struct {
+		mesh        modeling.Mesh
+		maxDistance float64
+		min, max    float64
+	}{
+		"open floor": {
+			mesh: square(0, true),
+			min:  1,
+			max:  1,
+		},
+		"floor under ceiling": {
+			mesh: square(0, true).Append(square(0.1, false).Scale(vector3.New(10., 1., 10.))),
+			min:  0,
+			max:  0.3,
+		},
+		"ceiling out of range": {
+			mesh:        square(0, true).Append(square(0.1, false).Scale(vector3.New(10., 1., 10.))),
+			maxDistance: 0.05,
+			min:         1,
+			max:         1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ARRANGE ========================================================
+			transformer := meshops.AmbientOcclusionTransformer{
+				Samples:     32,
+				MaxDistance: tc.maxDistance,
+			}
+
+			// ACT ============================================================
+			occluded, err := transformer.Transform(tc.mesh)
+
+			// ASSERT =========================================================
+			require.NoError(t, err)
+			require.True(t, occluded.HasFloat1Attribute(meshops.AmbientOcclusionAttribute))
+
+			// The floor's vertices come first
+			occlusion := occluded.Float1Attribute(meshops.AmbientOcclusionAttribute)
+			for i := range 4 {
+				assert.GreaterOrEqual(t, occlusion.At(i), tc.min)
+				assert.LessOrEqual(t, occlusion.At(i), tc.max)
+			}
+		})
+	}
+}
+
+func TestAmbientOcclusion_Deterministic(t *testing.T) {
+	mesh := square(0, true).Append(square(1, false))
+
+	a := meshops.AmbientOcclusion(mesh, "AO", 16, 0, 7)
+	b := meshops.AmbientOcclusion(mesh, "AO", 16, 0, 7)
+
+	assert.Equal(t, a.Float1Attribute("AO").At(0), b.Float1Attribute("AO").At(0))
+	assert.Less(t, a.Float1Attribute("AO").At(0), 1.)
+	assert.Greater(t, a.Float1Attribute("AO").At(0), 0.)
+}
+
+func TestAmbientOcclusionTransformer_RequiresTriangles(t *testing.T) {
+	_, err := meshops.AmbientOcclusionTransformer{}.Transform(modeling.EmptyPointcloud())
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
+
+func TestAmbientOcclusionContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := meshops.AmbientOcclusionContext(ctx, square(0, true), "AO", 16, 0, 7)
+	assert.ErrorIs(t, err, context.Canceled)
+}
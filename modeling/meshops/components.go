@@ -0,0 +1,267 @@
+package meshops
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+type ComponentsParameters struct {
+	// Position attribute used for welding, and measuring area and size.
+	// Defaults to position.
+	Attribute string
+
+	// When set, faces are considered connected if they share a position once
+	// rounded to WeldDecimalPlaces, not just when they share an index. The
+	// mesh's vertices are left untouched.
+	Weld              bool
+	WeldDecimalPlaces int
+
+	// Components with fewer primitives than this are dropped
+	MinPrimitives int
+
+	// Components with less surface area than this are dropped
+	MinArea float64
+
+	// Components whose bounding box diagonal is shorter than this are dropped
+	MinSize float64
+}
+
+func (cp ComponentsParameters) attribute() string {
+	return cp.Attribute
+}
+
+func (cp ComponentsParameters) requiresPositions() bool {
+	return cp.Weld || cp.MinArea > 0 || cp.MinSize > 0
+}
+
+func (cp ComponentsParameters) validate(m modeling.Mesh) error {
+	if m.Topology() != modeling.TriangleTopology && m.Topology() != modeling.QuadTopology {
+		return fmt.Errorf("finding components requires a triangle or quad topology, received: %s", m.Topology().String())
+	}
+
+	if cp.requiresPositions() {
+		return RequireV3Attribute(m, getAttribute(cp, modeling.PositionAttribute))
+	}
+	return nil
+}
+
+// keep reports whether the faces making up a component pass the filters
+func (cp ComponentsParameters) keep(m modeling.Mesh, faces []int) bool {
+	if len(faces) < cp.MinPrimitives {
+		return false
+	}
+
+	if !cp.requiresPositions() {
+		return true
+	}
+
+	positions := m.Float3Attribute(getAttribute(cp, modeling.PositionAttribute))
+	indices := m.Indices()
+	size := m.Topology().IndexSize()
+
+	area := 0.
+	points := make([]vector3.Float64, 0, len(faces)*size)
+	for _, f := range faces {
+		corners := make([]vector3.Float64, size)
+		for i := range corners {
+			corners[i] = positions.At(indices.At(f*size + i))
+		}
+		points = append(points, corners...)
+
+		// Fan triangulate, which covers quads as well
+		for i := 2; i < size; i++ {
+			area += corners[i-1].Sub(corners[0]).Cross(corners[i].Sub(corners[0])).Length() / 2
+		}
+	}
+
+	bounds := geometry.NewAABBFromPoints(points...)
+	return area >= cp.MinArea && bounds.Size().Length() >= cp.MinSize
+}
+
+// components finds the faces making up each connected component that
+// passes the filters, each listed in their original order
+func (cp ComponentsParameters) components(m modeling.Mesh) [][]int {
+	c := m.Connectivity()
+	if cp.Weld {
+		positions := m.Float3Attribute(getAttribute(cp, modeling.PositionAttribute))
+		lookup := make(map[vector3.Int]int)
+		welded := make([]int, positions.Len())
+		for i := range welded {
+			key := modeling.Vector3ToInt(positions.At(i), cp.WeldDecimalPlaces)
+			index, ok := lookup[key]
+			if !ok {
+				index = len(lookup)
+				lookup[key] = index
+			}
+			welded[i] = index
+		}
+
+		indices := m.Indices()
+		weldedIndices := make([]int, indices.Len())
+		for i := range weldedIndices {
+			weldedIndices[i] = welded[indices.At(i)]
+		}
+		c = modeling.NewMesh(m.Topology(), weldedIndices).Connectivity()
+	}
+
+	kept := make([][]int, 0)
+	for _, faces := range c.ConnectedComponents() {
+		if !cp.keep(m, faces) {
+			continue
+		}
+		sort.Ints(faces)
+		kept = append(kept, faces)
+	}
+	return kept
+}
+
+// extractFaces builds a mesh out of the faces provided, carrying over only
+// the vertices they reference
+func extractFaces(m modeling.Mesh, faces []int) modeling.Mesh {
+	size := m.Topology().IndexSize()
+	indices := m.Indices()
+
+	remap := make(map[int]int)
+	vertices := make([][]vertexWeight, 0)
+	extracted := make([]int, 0, len(faces)*size)
+	for _, f := range faces {
+		for i := range size {
+			v := indices.At(f*size + i)
+			index, ok := remap[v]
+			if !ok {
+				index = len(vertices)
+				remap[v] = index
+				vertices = append(vertices, []vertexWeight{{vertex: v, amount: 1}})
+			}
+			extracted = append(extracted, index)
+		}
+	}
+
+	return resampleVertices(m, extracted, vertices).RemapPrimitiveGroups(m, faces)
+}
+
+// SplitComponents breaks the mesh apart into one mesh per connected
+// component, where faces are connected if they share a vertex (or a
+// position, when welding). Components are returned in the order of their
+// first face within the original mesh, and those not passing the filters
+// provided are left out. Degenerate faces are dropped.
+func SplitComponents(m modeling.Mesh, params ComponentsParameters) ([]modeling.Mesh, error) {
+	if err := params.validate(m); err != nil {
+		return nil, err
+	}
+
+	components := params.components(m)
+	meshes := make([]modeling.Mesh, len(components))
+	for i, faces := range components {
+		meshes[i] = extractFaces(m, faces)
+	}
+	return meshes, nil
+}
+
+type FilterComponentsTransformer struct {
+	ComponentsParameters
+}
+
+func (fct FilterComponentsTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = fct.validate(m); err != nil {
+		return
+	}
+	return FilterComponents(m, fct.ComponentsParameters), nil
+}
+
+// FilterComponents removes every connected component of the mesh that
+// doesn't pass the filters provided, such as small floating pieces of noise
+// in a photogrammetry scan. Vertices no longer referenced are removed.
+func FilterComponents(m modeling.Mesh, params ComponentsParameters) modeling.Mesh {
+	check(params.validate(m))
+
+	size := m.Topology().IndexSize()
+	indices := m.Indices()
+
+	faces := make([]int, 0)
+	for _, component := range params.components(m) {
+		faces = append(faces, component...)
+	}
+	sort.Ints(faces)
+
+	kept := make([]int, 0, len(faces)*size)
+	for _, f := range faces {
+		for i := range size {
+			kept = append(kept, indices.At(f*size+i))
+		}
+	}
+
+	return RemovedUnreferencedVertices(m.SetIndices(kept).RemapPrimitiveGroups(m, faces))
+}
+
+type SplitComponentsNode = nodes.Struct[SplitComponentsNodeData]
+
+type SplitComponentsNodeData struct {
+	Mesh              nodes.Output[modeling.Mesh]
+	Attribute         nodes.Output[string]  `description:"Position attribute used for welding, area, and size"`
+	Weld              nodes.Output[bool]    `description:"Whether or not faces sharing a position, not just an index, are connected"`
+	WeldDecimalPlaces nodes.Output[int]     `description:"Decimal places positions are rounded to when welding"`
+	MinPrimitives     nodes.Output[int]     `description:"Components with fewer primitives than this are dropped"`
+	MinArea           nodes.Output[float64] `description:"Components with less surface area than this are dropped"`
+	MinSize           nodes.Output[float64] `description:"Components whose bounding box diagonal is shorter than this are dropped"`
+}
+
+func (scn SplitComponentsNodeData) parameters() ComponentsParameters {
+	return ComponentsParameters{
+		Attribute:         nodes.TryGetOutputValue(scn.Attribute, modeling.PositionAttribute),
+		Weld:              nodes.TryGetOutputValue(scn.Weld, false),
+		WeldDecimalPlaces: nodes.TryGetOutputValue(scn.WeldDecimalPlaces, 3),
+		MinPrimitives:     nodes.TryGetOutputValue(scn.MinPrimitives, 0),
+		MinArea:           nodes.TryGetOutputValue(scn.MinArea, 0.),
+		MinSize:           nodes.TryGetOutputValue(scn.MinSize, 0.),
+	}
+}
+
+func (scn SplitComponentsNodeData) Description() string {
+	return "Breaks a mesh apart into one mesh per connected component, optionally dropping small islands"
+}
+
+func (scn SplitComponentsNodeData) Out() nodes.StructOutput[[]modeling.Mesh] {
+	if scn.Mesh == nil {
+		return nodes.NewStructOutput[[]modeling.Mesh](nil)
+	}
+
+	meshes, err := SplitComponents(scn.Mesh.Value(), scn.parameters())
+	out := nodes.NewStructOutput(meshes)
+	out.LogError(err)
+	return out
+}
+
+type FilterComponentsNode = nodes.Struct[FilterComponentsNodeData]
+
+type FilterComponentsNodeData struct {
+	Mesh              nodes.Output[modeling.Mesh]
+	Attribute         nodes.Output[string]  `description:"Position attribute used for welding, area, and size"`
+	Weld              nodes.Output[bool]    `description:"Whether or not faces sharing a position, not just an index, are connected"`
+	WeldDecimalPlaces nodes.Output[int]     `description:"Decimal places positions are rounded to when welding"`
+	MinPrimitives     nodes.Output[int]     `description:"Components with fewer primitives than this are dropped"`
+	MinArea           nodes.Output[float64] `description:"Components with less surface area than this are dropped"`
+	MinSize           nodes.Output[float64] `description:"Components whose bounding box diagonal is shorter than this are dropped"`
+}
+
+func (fcn FilterComponentsNodeData) Description() string {
+	return "Removes connected components of a mesh that are too small, like floating noise in a scan"
+}
+
+func (fcn FilterComponentsNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if fcn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	filtered, err := FilterComponentsTransformer{
+		ComponentsParameters: SplitComponentsNodeData(fcn).parameters(),
+	}.Transform(fcn.Mesh.Value())
+	out := nodes.NewStructOutput(filtered)
+	out.LogError(err)
+	return out
+}
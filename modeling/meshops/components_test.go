@@ -0,0 +1,125 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitComponents(t *testing.T) {
+	// ARRANGE ================================================================
+	big := primitives.UVSphere(1, 16, 16)
+	small := primitives.UVSphere(0.1, 4, 4).Translate(vector3.New(3., 0., 0.))
+	mesh := big.Append(small)
+
+	// ACT ====================================================================
+	components, err := meshops.SplitComponents(mesh, meshops.ComponentsParameters{})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, components, 2)
+	assert.Equal(t, big.PrimitiveCount(), components[0].PrimitiveCount())
+	assert.Equal(t, big.AttributeLength(), components[0].AttributeLength())
+	assert.Equal(t, small.PrimitiveCount(), components[1].PrimitiveCount())
+	assert.Equal(t, small.AttributeLength(), components[1].AttributeLength())
+	assert.Equal(t,
+		small.Float3Attribute(modeling.PositionAttribute).At(0),
+		components[1].Float3Attribute(modeling.PositionAttribute).At(0),
+	)
+}
+
+func TestSplitComponents_Filters(t *testing.T) {
+	big := primitives.UVSphere(1, 16, 16)
+	small := primitives.UVSphere(0.1, 4, 4).Translate(vector3.New(3., 0., 0.))
+	mesh := big.Append(small)
+
+	tests := map[string]struct {
+		params   meshops.ComponentsParameters
+		expected int
+	}{
+		"no filter":      {params: meshops.ComponentsParameters{}, expected: 2},
+		"min primitives": {params: meshops.ComponentsParameters{MinPrimitives: small.PrimitiveCount() + 1}, expected: 1},
+		"min area":       {params: meshops.ComponentsParameters{MinArea: 1}, expected: 1},
+		"min size":       {params: meshops.ComponentsParameters{MinSize: 1}, expected: 1},
+		"everything":     {params: meshops.ComponentsParameters{MinArea: 100}, expected: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			components, err := meshops.SplitComponents(mesh, tc.params)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, len(components))
+		})
+	}
+}
+
+func TestSplitComponents_Weld(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphereUnwelded(1, 8, 8)
+
+	// ACT ====================================================================
+	unwelded, err := meshops.SplitComponents(mesh, meshops.ComponentsParameters{})
+	require.NoError(t, err)
+	welded, err := meshops.SplitComponents(mesh, meshops.ComponentsParameters{
+		Weld:              true,
+		WeldDecimalPlaces: 4,
+	})
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	assert.Greater(t, len(unwelded), 1)
+	require.Len(t, welded, 1)
+	assert.Equal(t, mesh.AttributeLength(), welded[0].AttributeLength())
+}
+
+func TestSplitComponents_RequiresTrianglesOrQuads(t *testing.T) {
+	_, err := meshops.SplitComponents(modeling.EmptyPointcloud(), meshops.ComponentsParameters{})
+	assert.EqualError(t, err, "finding components requires a triangle or quad topology, received: point")
+}
+
+func TestFilterComponentsTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	big := primitives.UVSphere(1, 16, 16)
+	small := primitives.UVSphere(0.1, 4, 4).Translate(vector3.New(3., 0., 0.))
+	mesh := small.Append(big)
+	transformer := meshops.FilterComponentsTransformer{
+		ComponentsParameters: meshops.ComponentsParameters{
+			MinSize: 1,
+		},
+	}
+
+	// ACT ====================================================================
+	filtered, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, big.PrimitiveCount(), filtered.PrimitiveCount())
+	assert.Equal(t, big.AttributeLength(), filtered.AttributeLength())
+}
+
+func TestFilterComponents_KeepsPrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	big := primitives.UVSphere(1, 16, 16).SetMaterial("big")
+	small := primitives.UVSphere(0.1, 4, 4).Translate(vector3.New(3., 0., 0.)).SetMaterial("small")
+	other := primitives.UVSphere(1, 8, 8).Translate(vector3.New(-3., 0., 0.))
+	mesh := big.Append(small).Append(other)
+
+	// ACT ====================================================================
+	filtered := meshops.FilterComponents(mesh, meshops.ComponentsParameters{MinArea: 1})
+	components, err := meshops.SplitComponents(mesh, meshops.ComponentsParameters{})
+
+	// ASSERT =================================================================
+	assert.Equal(t, []string{"big", ""}, filtered.MaterialSlots())
+	assert.Equal(t, big.PrimitiveCount(), len(filtered.PrimitiveGroups()[0].Primitives))
+
+	require.NoError(t, err)
+	require.Len(t, components, 3)
+	assert.Equal(t, []string{"big"}, components[0].MaterialSlots())
+	assert.Equal(t, []string{"small"}, components[1].MaterialSlots())
+	assert.Equal(t, []string{""}, components[2].MaterialSlots())
+}
@@ -0,0 +1,146 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type ConsistentWindingTransformer struct {
+	Attribute string
+}
+
+func (cwt ConsistentWindingTransformer) attribute() string {
+	return cwt.Attribute
+}
+
+func (cwt ConsistentWindingTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	attribute := getAttribute(cwt, modeling.PositionAttribute)
+
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, attribute); err != nil {
+		return
+	}
+
+	return ConsistentWinding(m, attribute), nil
+}
+
+// ConsistentWinding flips triangles so neighbors across every manifold edge
+// run along it in opposite directions, which is what it means for a surface
+// to be consistently wound. Each connected component is handled on its own.
+// Closed components are oriented so their triangles face outwards, based on
+// the signed volume they enclose, while open components keep whichever
+// winding the majority of their triangles already had.
+//
+// Unlike FlipTriangleWinding, triangles that are already consistent with
+// their neighbors are left alone.
+func ConsistentWinding(m modeling.Mesh, attribute string) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, attribute))
+
+	c := m.Connectivity()
+	positions := m.Float3Attribute(attribute)
+
+	triangles := faceTriangles(c)
+
+	visited := make([]bool, len(triangles))
+	changed := false
+	for seed := range triangles {
+		if visited[seed] || c.Degenerate(seed) {
+			continue
+		}
+
+		component := []int{seed}
+		visited[seed] = true
+		flipped := 0
+		closed := true
+		for i := 0; i < len(component); i++ {
+			tri := triangles[component[i]]
+			for j := range 3 {
+				a, b := tri[j], tri[(j+1)%3]
+				shared := c.EdgeFaces(modeling.NewEdge(a, b))
+				if len(shared) == 1 {
+					closed = false
+				}
+				if len(shared) != 2 {
+					continue
+				}
+
+				neighbor := shared[0]
+				if neighbor == component[i] {
+					neighbor = shared[1]
+				}
+				if visited[neighbor] {
+					continue
+				}
+
+				visited[neighbor] = true
+				component = append(component, neighbor)
+				if traverses(triangles[neighbor], a, b) {
+					n := triangles[neighbor]
+					triangles[neighbor] = [3]int{n[1], n[0], n[2]}
+					flipped++
+				}
+			}
+		}
+
+		flipAll := false
+		if closed {
+			// Six times the signed volume, only the sign matters
+			volume := 0.
+			for _, t := range component {
+				tri := triangles[t]
+				volume += positions.At(tri[0]).Dot(positions.At(tri[1]).Cross(positions.At(tri[2])))
+			}
+			flipAll = volume < 0
+		} else {
+			flipAll = flipped*2 > len(component)
+		}
+
+		if flipAll {
+			for _, t := range component {
+				tri := triangles[t]
+				triangles[t] = [3]int{tri[1], tri[0], tri[2]}
+			}
+			flipped = len(component) - flipped
+		}
+
+		if flipped > 0 {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return m
+	}
+
+	indices := make([]int, 0, len(triangles)*3)
+	for _, tri := range triangles {
+		indices = append(indices, tri[:]...)
+	}
+	return m.SetIndices(indices)
+}
+
+type ConsistentWindingNode = nodes.Struct[ConsistentWindingNodeData]
+
+type ConsistentWindingNodeData struct {
+	Mesh      nodes.Output[modeling.Mesh]
+	Attribute nodes.Output[string] `description:"Position attribute used to determine which way closed surfaces face"`
+}
+
+func (cwn ConsistentWindingNodeData) Description() string {
+	return "Flips triangles so the winding order is consistent across each connected piece of the mesh, with closed pieces facing outwards"
+}
+
+func (cwn ConsistentWindingNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if cwn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	return nodes.NewStructOutput(ConsistentWinding(
+		cwn.Mesh.Value(),
+		nodes.TryGetOutputValue(cwn.Attribute, modeling.PositionAttribute),
+	))
+}
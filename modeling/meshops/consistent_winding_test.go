@@ -0,0 +1,56 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistentWindingTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+	indices := iter.ReadFull(sphere.Indices())
+	for tri := 0; tri < len(indices); tri += 9 {
+		indices[tri], indices[tri+1] = indices[tri+1], indices[tri]
+	}
+	mesh := sphere.SetIndices(indices)
+	transformer := meshops.ConsistentWindingTransformer{}
+
+	// ACT ====================================================================
+	oriented, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Greater(t, meshops.Manifoldness(mesh).InconsistentEdges, 0)
+	assert.True(t, meshops.Manifoldness(oriented).Watertight())
+	assert.Equal(t, iter.ReadFull(sphere.Indices()), iter.ReadFull(oriented.Indices()))
+}
+
+func TestConsistentWindingTransformer_FacesOutwards(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+	inverted := meshops.FlipTriangleWinding(sphere)
+
+	// ACT ====================================================================
+	oriented := meshops.ConsistentWinding(inverted, modeling.PositionAttribute)
+
+	// ASSERT =================================================================
+	assert.Equal(t, iter.ReadFull(sphere.Indices()), iter.ReadFull(oriented.Indices()))
+}
+
+func TestConsistentWindingTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.ConsistentWindingTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -62,11 +62,13 @@ func CropFloat3Attribute(m modeling.Mesh, attr string, boundingBox geometry.AABB
 		v1[attr] = make([]float64, 0)
 	}
 
+	kept := make([]int, 0)
 	decidingAttribute := m.Float3Attribute(attr)
 	for i := 0; i < decidingAttribute.Len(); i++ {
 		if !boundingBox.Contains(decidingAttribute.At(i)) {
 			continue
 		}
+		kept = append(kept, i)
 
 		for _, attr := range m.Float4Attributes() {
 			v4[attr] = append(v4[attr], oldV4[attr].At(i))
@@ -85,7 +87,7 @@ func CropFloat3Attribute(m modeling.Mesh, attr string, boundingBox geometry.AABB
 		}
 	}
 
-	return modeling.NewPointCloud(v4, v3, v2, v1)
+	return modeling.NewPointCloud(v4, v3, v2, v1).RemapIntAttributes(m, kept)
 }
 
 type CropAttribute3DNode = nodes.Struct[CropAttribute3DNodeData]
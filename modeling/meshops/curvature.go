@@ -0,0 +1,242 @@
+package meshops
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	MeanCurvatureAttribute     = "MeanCurvature"
+	GaussianCurvatureAttribute = "GaussianCurvature"
+)
+
+// Curvature is estimated with the discrete operators from "Discrete
+// Differential-Geometry Operators for Triangulated 2-Manifolds" by Meyer et
+// al, integrating over each vertex's mixed voronoi area. Curvature is only
+// defined on the interior of a surface, so vertices along the mesh's
+// boundary, including any seams where vertices aren't shared, receive 0.
+
+// vertexCurvature accumulates the terms of both curvature operators around
+// a single vertex
+type vertexCurvature struct {
+	area      float64
+	laplacian vector3.Float64
+	angle     float64
+	normal    vector3.Float64
+}
+
+func cotangent(a, b vector3.Float64) float64 {
+	sin := a.Cross(b).Length()
+	if sin == 0 {
+		return 0
+	}
+	return a.Dot(b) / sin
+}
+
+func accumulateCurvature(m modeling.Mesh) ([]vertexCurvature, []bool) {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	indices := m.Indices()
+
+	vertices := make([]vertexCurvature, positions.Len())
+	boundary := make([]bool, positions.Len())
+
+	edgeCount := make(map[modeling.Edge]int)
+
+	for tri := 0; tri < indices.Len(); tri += 3 {
+		ids := [3]int{indices.At(tri), indices.At(tri + 1), indices.At(tri + 2)}
+		pts := [3]vector3.Float64{positions.At(ids[0]), positions.At(ids[1]), positions.At(ids[2])}
+
+		faceNormal := pts[1].Sub(pts[0]).Cross(pts[2].Sub(pts[0]))
+		area := faceNormal.Length() / 2
+		if area == 0 {
+			continue
+		}
+
+		for i := range 3 {
+			e := modeling.NewEdge(ids[i], ids[(i+1)%3])
+			edgeCount[e]++
+		}
+
+		obtuse := -1
+		for i := range 3 {
+			if pts[(i+1)%3].Sub(pts[i]).Dot(pts[(i+2)%3].Sub(pts[i])) < 0 {
+				obtuse = i
+			}
+		}
+
+		for i := range 3 {
+			p := pts[i]
+			q := pts[(i+1)%3]
+			r := pts[(i+2)%3]
+
+			// Cotangents of the angles opposite the edges leaving p
+			cotQ := cotangent(p.Sub(q), r.Sub(q))
+			cotR := cotangent(p.Sub(r), q.Sub(r))
+
+			v := &vertices[ids[i]]
+			v.laplacian = v.laplacian.
+				Add(p.Sub(q).Scale(cotR)).
+				Add(p.Sub(r).Scale(cotQ))
+
+			pq, pr := q.Sub(p), r.Sub(p)
+			v.angle += math.Acos(math.Max(-1, math.Min(1, pq.Normalized().Dot(pr.Normalized()))))
+			v.normal = v.normal.Add(faceNormal)
+
+			switch {
+			case obtuse == -1:
+				v.area += (pr.LengthSquared()*cotQ + pq.LengthSquared()*cotR) / 8
+			case obtuse == i:
+				v.area += area / 2
+			default:
+				v.area += area / 4
+			}
+		}
+	}
+
+	for e, count := range edgeCount {
+		if count != 2 {
+			boundary[e.A] = true
+			boundary[e.B] = true
+		}
+	}
+
+	return vertices, boundary
+}
+
+type MeanCurvatureTransformer struct {
+	// Attribute the curvature is written to, defaults to MeanCurvature
+	Attribute string
+}
+
+func (mct MeanCurvatureTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	attribute := mct.Attribute
+	if attribute == "" {
+		attribute = MeanCurvatureAttribute
+	}
+
+	return MeanCurvature(m, attribute), nil
+}
+
+// MeanCurvature writes the mean curvature of the surface at each vertex to
+// the float1 attribute specified, computed with the cotangent laplacian.
+// Curvature is positive where the surface bulges out along the direction
+// its faces wind counter-clockwise, like the outside of a sphere, where it
+// comes out to 1/radius.
+func MeanCurvature(m modeling.Mesh, attribute string) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	vertices, boundary := accumulateCurvature(m)
+	curvature := make([]float64, len(vertices))
+	for i, v := range vertices {
+		if boundary[i] || v.area == 0 || v.normal.LengthSquared() == 0 {
+			continue
+		}
+		curvature[i] = v.laplacian.Dot(v.normal.Normalized()) / (4 * v.area)
+	}
+
+	return m.SetFloat1Attribute(attribute, curvature)
+}
+
+type GaussianCurvatureTransformer struct {
+	// Attribute the curvature is written to, defaults to GaussianCurvature
+	Attribute string
+}
+
+func (gct GaussianCurvatureTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	attribute := gct.Attribute
+	if attribute == "" {
+		attribute = GaussianCurvatureAttribute
+	}
+
+	return GaussianCurvature(m, attribute), nil
+}
+
+// GaussianCurvature writes the gaussian curvature of the surface at each
+// vertex to the float1 attribute specified, computed from the angle deficit
+// of the faces surrounding it. Curvature is positive at peaks and pits,
+// negative at saddles, and 1/radius² across a sphere.
+func GaussianCurvature(m modeling.Mesh, attribute string) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	vertices, boundary := accumulateCurvature(m)
+	curvature := make([]float64, len(vertices))
+	for i, v := range vertices {
+		if boundary[i] || v.area == 0 {
+			continue
+		}
+		curvature[i] = ((2 * math.Pi) - v.angle) / v.area
+	}
+
+	return m.SetFloat1Attribute(attribute, curvature)
+}
+
+type MeanCurvatureNode = nodes.Struct[MeanCurvatureNodeData]
+
+type MeanCurvatureNodeData struct {
+	Mesh      nodes.Output[modeling.Mesh]
+	Attribute nodes.Output[string] `description:"Float1 attribute to write the curvature to, defaults to MeanCurvature"`
+}
+
+func (mcn MeanCurvatureNodeData) Description() string {
+	return "Estimates the mean curvature of the surface at each vertex"
+}
+
+func (mcn MeanCurvatureNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if mcn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := MeanCurvatureTransformer{
+		Attribute: nodes.TryGetOutputValue(mcn.Attribute, MeanCurvatureAttribute),
+	}.Transform(mcn.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
+
+type GaussianCurvatureNode = nodes.Struct[GaussianCurvatureNodeData]
+
+type GaussianCurvatureNodeData struct {
+	Mesh      nodes.Output[modeling.Mesh]
+	Attribute nodes.Output[string] `description:"Float1 attribute to write the curvature to, defaults to GaussianCurvature"`
+}
+
+func (gcn GaussianCurvatureNodeData) Description() string {
+	return "Estimates the gaussian curvature of the surface at each vertex"
+}
+
+func (gcn GaussianCurvatureNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if gcn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := GaussianCurvatureTransformer{
+		Attribute: nodes.TryGetOutputValue(gcn.Attribute, GaussianCurvatureAttribute),
+	}.Transform(gcn.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
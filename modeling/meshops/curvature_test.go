@@ -0,0 +1,52 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurvature_Sphere(t *testing.T) {
+	// ARRANGE ================================================================
+	radius := 2.
+	sphere := primitives.UVSphere(radius, 64, 64)
+
+	// ACT ====================================================================
+	curved, err := meshops.MeanCurvatureTransformer{}.Transform(sphere)
+	require.NoError(t, err)
+	curved, err = meshops.GaussianCurvatureTransformer{}.Transform(curved)
+	require.NoError(t, err)
+
+	// ASSERT =================================================================
+	mean := curved.Float1Attribute(meshops.MeanCurvatureAttribute)
+	gaussian := curved.Float1Attribute(meshops.GaussianCurvatureAttribute)
+
+	// Skip the poles, whose fans of skinny triangles skew the estimate
+	for i := 1; i < mean.Len()-1; i++ {
+		require.InDelta(t, 1/radius, mean.At(i), 0.02, "vertex %d", i)
+		require.InDelta(t, 1/(radius*radius), gaussian.At(i), 0.02, "vertex %d", i)
+	}
+}
+
+func TestCurvature_FlatBoundary(t *testing.T) {
+	mesh := square(0, true)
+
+	curved := meshops.GaussianCurvature(meshops.MeanCurvature(mesh, "H"), "K")
+
+	for i := range 4 {
+		assert.Zero(t, curved.Float1Attribute("H").At(i))
+		assert.Zero(t, curved.Float1Attribute("K").At(i))
+	}
+}
+
+func TestCurvatureTransformer_RequiresTriangles(t *testing.T) {
+	_, err := meshops.MeanCurvatureTransformer{}.Transform(modeling.EmptyPointcloud())
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+
+	_, err = meshops.GaussianCurvatureTransformer{}.Transform(modeling.EmptyPointcloud())
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -0,0 +1,469 @@
+package meshops
+
+import (
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	holeRefinementPasses = 20
+	holeRelaxationPasses = 50
+	holeFairingPasses    = 100
+
+	// Nodes leave large holes open by default, as triangulating a hole takes
+	// cubic time in the number of edges bordering it
+	nodeMaxHoleEdges = 300
+)
+
+type FillHolesTransformer struct {
+	Attribute string
+
+	// Holes bordered by more edges than this are left open. Zero or less
+	// fills every hole. Triangulating a hole takes cubic time in the number
+	// of edges bordering it.
+	MaxHoleEdges int
+}
+
+func (fht FillHolesTransformer) attribute() string {
+	return fht.Attribute
+}
+
+func (fht FillHolesTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	attribute := getAttribute(fht, modeling.PositionAttribute)
+
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, attribute); err != nil {
+		return
+	}
+
+	return FillHoles(m, attribute, fht.MaxHoleEdges), nil
+}
+
+// FillHoles closes every loop of boundary edges in the mesh with a patch of
+// new triangles, wound to match the triangles around the hole.
+//
+// Each hole is first triangulated by minimizing the largest dihedral angle
+// between neighboring triangles, breaking ties by area. The patch is then
+// refined with new vertices until its density matches the edges around the
+// hole, and those vertices are faired so the patch smoothly spans the hole.
+// Attributes of new vertices are blended from the hole's border.
+//
+// Implementation follows "Filling Holes in Meshes" by Peter Liepa.
+// Connectivity is taken from the mesh's indices as is, so meshes split along
+// attribute seams should be welded with WeldByFloat3Attribute beforehand.
+func FillHoles(m modeling.Mesh, attribute string, maxHoleEdges int) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, attribute))
+
+	c := m.Connectivity()
+	positions := m.Float3Attribute(attribute)
+
+	vertices := make([][]vertexWeight, m.AttributeLength())
+	for i := range vertices {
+		vertices[i] = []vertexWeight{{vertex: i, amount: 1}}
+	}
+	newPositions := make([]vector3.Float64, 0)
+
+	indices := iter.ReadFull(m.Indices())
+
+	// Patches take on the primitive group of a triangle bordering the hole
+	sources := make([]int, m.PrimitiveCount())
+	for i := range sources {
+		sources[i] = i
+	}
+
+	filled := false
+	for _, loop := range boundaryLoops(c) {
+		if maxHoleEdges > 0 && len(loop) > maxHoleEdges {
+			continue
+		}
+
+		if !simpleLoop(loop) {
+			continue
+		}
+
+		patch := newHolePatch(c, positions, loop)
+		patch.refine()
+		patch.fair()
+
+		local := make([]int, len(patch.positions))
+		for i := range patch.positions {
+			if i < len(loop) {
+				local[i] = loop[i]
+				continue
+			}
+			local[i] = len(vertices)
+			vertices = append(vertices, patch.weights[i])
+			newPositions = append(newPositions, patch.positions[i])
+		}
+
+		border := c.EdgeFaces(modeling.NewEdge(loop[0], loop[1]))[0]
+		for _, tri := range patch.triangles {
+			indices = append(indices, local[tri[0]], local[tri[1]], local[tri[2]])
+			sources = append(sources, border)
+		}
+		filled = true
+	}
+
+	if !filled {
+		return m
+	}
+
+	result := resampleVertices(m, indices, vertices).RemapPrimitiveGroups(m, sources)
+	if len(newPositions) == 0 {
+		return result
+	}
+
+	offset := m.AttributeLength()
+	return result.ModifyFloat3Attribute(attribute, func(i int, v vector3.Float64) vector3.Float64 {
+		if i < offset {
+			return v
+		}
+		return newPositions[i-offset]
+	})
+}
+
+func simpleLoop(loop []int) bool {
+	seen := make(map[int]bool, len(loop))
+	for _, v := range loop {
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// holePatch is the set of triangles filling a single hole. The first
+// vertices of the patch are the hole's border, in loop order.
+type holePatch struct {
+	border    int
+	positions []vector3.Float64
+	weights   [][]vertexWeight
+	density   []float64
+	triangles [][3]int
+}
+
+func triangleNormal(a, b, c vector3.Float64) vector3.Float64 {
+	n := b.Sub(a).Cross(c.Sub(a))
+	if n.Length() == 0 {
+		return n
+	}
+	return n.Normalized()
+}
+
+func dihedral(a, b vector3.Float64) float64 {
+	return math.Acos(math.Max(-1, math.Min(1, a.Dot(b))))
+}
+
+func newHolePatch(c *modeling.Connectivity, positions *iter.ArrayIterator[vector3.Float64], loop []int) *holePatch {
+	n := len(loop)
+	patch := &holePatch{
+		border:    n,
+		positions: make([]vector3.Float64, n),
+		weights:   make([][]vertexWeight, n),
+		density:   make([]float64, n),
+	}
+
+	// Normal of the mesh triangle on the other side of each border edge,
+	// where edge k runs from loop[k] to loop[k+1]
+	borderNormals := make([]vector3.Float64, n)
+	for k, v := range loop {
+		patch.positions[k] = positions.At(v)
+		patch.weights[k] = []vertexWeight{{vertex: v, amount: 1}}
+
+		next := loop[(k+1)%n]
+		tri := c.Face(c.EdgeFaces(modeling.NewEdge(v, next))[0])
+		borderNormals[k] = triangleNormal(positions.At(tri[0]), positions.At(tri[1]), positions.At(tri[2]))
+	}
+
+	for k := range loop {
+		previous := patch.positions[(k+n-1)%n].Distance(patch.positions[k])
+		next := patch.positions[k].Distance(patch.positions[(k+1)%n])
+		patch.density[k] = (previous + next) / 2
+	}
+
+	patch.triangles = patch.triangulate(borderNormals)
+	return patch
+}
+
+// triangulate finds the triangulation of the hole's border that minimizes
+// the largest dihedral angle between neighboring triangles, and then the
+// total area, using dynamic programming
+func (hp *holePatch) triangulate(borderNormals []vector3.Float64) [][3]int {
+	n := hp.border
+	angle := make([][]float64, n)
+	area := make([][]float64, n)
+	best := make([][]int, n)
+	for i := range n {
+		angle[i] = make([]float64, n)
+		area[i] = make([]float64, n)
+		best[i] = make([]int, n)
+	}
+
+	// Normal of the triangle spanning i and j within their sub polygon,
+	// falling back to the mesh across the border when they're neighbors
+	spanning := func(i, j int) vector3.Float64 {
+		if j == i+1 {
+			return borderNormals[i]
+		}
+		return triangleNormal(hp.positions[i], hp.positions[best[i][j]], hp.positions[j])
+	}
+
+	const epsilon = 1e-9
+	for length := 2; length < n; length++ {
+		for i := 0; i+length < n; i++ {
+			j := i + length
+			bestAngle, bestArea := math.Inf(1), math.Inf(1)
+			for m := i + 1; m < j; m++ {
+				pi, pm, pj := hp.positions[i], hp.positions[m], hp.positions[j]
+				normal := triangleNormal(pi, pm, pj)
+
+				worst := math.Max(angle[i][m], angle[m][j])
+				worst = math.Max(worst, dihedral(normal, spanning(i, m)))
+				worst = math.Max(worst, dihedral(normal, spanning(m, j)))
+				if i == 0 && j == n-1 {
+					worst = math.Max(worst, dihedral(normal, borderNormals[n-1]))
+				}
+				total := area[i][m] + area[m][j] + pm.Sub(pi).Cross(pj.Sub(pi)).Length()/2
+
+				if worst < bestAngle-epsilon || (math.Abs(worst-bestAngle) <= epsilon && total < bestArea) {
+					bestAngle, bestArea = worst, total
+					best[i][j] = m
+				}
+			}
+			angle[i][j] = bestAngle
+			area[i][j] = bestArea
+		}
+	}
+
+	triangles := make([][3]int, 0, n-2)
+	stack := [][2]int{{0, n - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		i, j := span[0], span[1]
+		if j-i < 2 {
+			continue
+		}
+
+		m := best[i][j]
+		triangles = append(triangles, [3]int{i, m, j})
+		stack = append(stack, [2]int{i, m}, [2]int{m, j})
+	}
+	return triangles
+}
+
+func (hp *holePatch) addVertex(tri [3]int) int {
+	weights := make([]vertexWeight, 0, 3)
+	for _, corner := range tri {
+	next:
+		for _, w := range hp.weights[corner] {
+			for i := range weights {
+				if weights[i].vertex == w.vertex {
+					weights[i].amount += w.amount / 3
+					continue next
+				}
+			}
+			weights = append(weights, vertexWeight{vertex: w.vertex, amount: w.amount / 3})
+		}
+	}
+
+	hp.positions = append(hp.positions, hp.positions[tri[0]].
+		Add(hp.positions[tri[1]]).
+		Add(hp.positions[tri[2]]).
+		DivByConstant(3))
+	hp.weights = append(hp.weights, weights)
+	hp.density = append(hp.density, (hp.density[tri[0]]+hp.density[tri[1]]+hp.density[tri[2]])/3)
+	return len(hp.positions) - 1
+}
+
+// refine splits triangles at their centroid until the patch's vertex density
+// matches that of the hole's border, relaxing edges after every pass
+func (hp *holePatch) refine() {
+	for range holeRefinementPasses {
+		split := false
+		count := len(hp.triangles)
+		for t := 0; t < count; t++ {
+			tri := hp.triangles[t]
+			centroid := hp.positions[tri[0]].Add(hp.positions[tri[1]]).Add(hp.positions[tri[2]]).DivByConstant(3)
+			density := (hp.density[tri[0]] + hp.density[tri[1]] + hp.density[tri[2]]) / 3
+
+			sparse := true
+			for _, corner := range tri {
+				distance := math.Sqrt2 * centroid.Distance(hp.positions[corner])
+				if distance <= density || distance <= hp.density[corner] {
+					sparse = false
+					break
+				}
+			}
+			if !sparse {
+				continue
+			}
+
+			c := hp.addVertex(tri)
+			hp.triangles[t] = [3]int{tri[0], tri[1], c}
+			hp.triangles = append(hp.triangles, [3]int{tri[1], tri[2], c}, [3]int{tri[2], tri[0], c})
+			split = true
+		}
+
+		if !split {
+			return
+		}
+
+		for range holeRelaxationPasses {
+			if !hp.relax() {
+				break
+			}
+		}
+	}
+}
+
+func (hp *holePatch) angle(corner, a, b int) float64 {
+	return dihedral(
+		hp.positions[a].Sub(hp.positions[corner]).Normalized(),
+		hp.positions[b].Sub(hp.positions[corner]).Normalized(),
+	)
+}
+
+// relax flips interior edges of the patch that aren't locally Delaunay,
+// reporting whether any edge was flipped
+func (hp *holePatch) relax() bool {
+	edgeTriangles := make(map[modeling.Edge][]int)
+	for t, tri := range hp.triangles {
+		for i := range 3 {
+			e := modeling.NewEdge(tri[i], tri[(i+1)%3])
+			edgeTriangles[e] = append(edgeTriangles[e], t)
+		}
+	}
+
+	flipped := false
+	modified := make(map[int]bool)
+	for t := range hp.triangles {
+		for i := range 3 {
+			tri := hp.triangles[t]
+			e := modeling.NewEdge(tri[i], tri[(i+1)%3])
+			if hp.flip(e, edgeTriangles, modified) {
+				flipped = true
+			}
+		}
+	}
+	return flipped
+}
+
+// flip swaps the edge for the one connecting the vertices opposite of it if
+// that makes the two triangles on either side locally Delaunay
+func (hp *holePatch) flip(e modeling.Edge, edgeTriangles map[modeling.Edge][]int, modified map[int]bool) bool {
+	tris := edgeTriangles[e]
+	if len(tris) != 2 || modified[tris[0]] || modified[tris[1]] {
+		return false
+	}
+
+	t1, t2 := tris[0], tris[1]
+	a, b := e.A, e.B
+	if !traverses(hp.triangles[t1], a, b) {
+		a, b = b, a
+	}
+	c := opposite(hp.triangles[t1], a, b)
+	d := opposite(hp.triangles[t2], a, b)
+
+	// Never connect two border vertices, the mesh may already join them
+	if c < hp.border && d < hp.border {
+		return false
+	}
+	if _, ok := edgeTriangles[modeling.NewEdge(c, d)]; ok {
+		return false
+	}
+
+	if hp.angle(c, a, b)+hp.angle(d, a, b) <= math.Pi+1e-9 {
+		return false
+	}
+
+	first := [3]int{a, d, c}
+	second := [3]int{d, b, c}
+	normal := hp.normal(hp.triangles[t1]).Add(hp.normal(hp.triangles[t2]))
+	if hp.normal(first).Dot(normal) <= 0 || hp.normal(second).Dot(normal) <= 0 {
+		return false
+	}
+
+	hp.triangles[t1] = first
+	hp.triangles[t2] = second
+	modified[t1] = true
+	modified[t2] = true
+	edgeTriangles[modeling.NewEdge(c, d)] = []int{t1, t2}
+	return true
+}
+
+func (hp *holePatch) normal(tri [3]int) vector3.Float64 {
+	return triangleNormal(hp.positions[tri[0]], hp.positions[tri[1]], hp.positions[tri[2]])
+}
+
+func opposite(tri [3]int, a, b int) int {
+	for _, v := range tri {
+		if v != a && v != b {
+			return v
+		}
+	}
+	return -1
+}
+
+// fair repeatedly moves each of the patch's new vertices to the center of
+// its neighbors, leaving the border fixed, approximating a membrane
+// stretched across the hole
+func (hp *holePatch) fair() {
+	if len(hp.positions) == hp.border {
+		return
+	}
+
+	neighbors := make([]map[int]struct{}, len(hp.positions))
+	for i := range neighbors {
+		neighbors[i] = make(map[int]struct{})
+	}
+	for _, tri := range hp.triangles {
+		for i := range 3 {
+			neighbors[tri[i]][tri[(i+1)%3]] = struct{}{}
+			neighbors[tri[(i+1)%3]][tri[i]] = struct{}{}
+		}
+	}
+
+	for range holeFairingPasses {
+		for v := hp.border; v < len(hp.positions); v++ {
+			center := vector3.Zero[float64]()
+			for n := range neighbors[v] {
+				center = center.Add(hp.positions[n])
+			}
+			hp.positions[v] = center.DivByConstant(float64(len(neighbors[v])))
+		}
+	}
+}
+
+type FillHolesNode = nodes.Struct[FillHolesNodeData]
+
+type FillHolesNodeData struct {
+	Mesh         nodes.Output[modeling.Mesh]
+	Attribute    nodes.Output[string] `description:"Position attribute used to shape the patches"`
+	MaxHoleEdges nodes.Output[int]    `description:"Holes bordered by more edges than this are left open (defaults to 300), 0 fills every hole"`
+}
+
+func (fhn FillHolesNodeData) Description() string {
+	return "Closes the holes in a triangle mesh with smooth patches of new triangles, leaving holes bordered by more than 300 edges open unless told otherwise"
+}
+
+func (fhn FillHolesNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if fhn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	return nodes.NewStructOutput(FillHoles(
+		fhn.Mesh.Value(),
+		nodes.TryGetOutputValue(fhn.Attribute, modeling.PositionAttribute),
+		nodes.TryGetOutputValue(fhn.MaxHoleEdges, nodeMaxHoleEdges),
+	))
+}
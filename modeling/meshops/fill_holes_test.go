@@ -0,0 +1,79 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// removeTriangles drops the first count triangles from the mesh
+func removeTriangles(m modeling.Mesh, count int) modeling.Mesh {
+	return m.SetIndices(iter.ReadFull(m.Indices())[count*3:])
+}
+
+func TestFillHolesTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+	mesh := removeTriangles(sphere, 48)
+	transformer := meshops.FillHolesTransformer{}
+
+	// ACT ====================================================================
+	filled, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.False(t, meshops.Manifoldness(mesh).Watertight())
+	assert.True(t, meshops.Manifoldness(filled).Watertight())
+	assert.Greater(t, filled.AttributeLength(), mesh.AttributeLength())
+
+	filled.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.LessOrEqual(t, v.Length(), 1.0001)
+	})
+}
+
+func TestFillHolesTransformer_MaxHoleEdges(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := removeTriangles(primitives.UVSphere(1, 16, 16), 48)
+	transformer := meshops.FillHolesTransformer{
+		MaxHoleEdges: 4,
+	}
+
+	// ACT ====================================================================
+	filled, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, mesh.PrimitiveCount(), filled.PrimitiveCount())
+}
+
+func TestFillHolesTransformer_SingleTriangleHole(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+	mesh := removeTriangles(sphere, 1)
+
+	// ACT ====================================================================
+	filled := meshops.FillHoles(mesh, modeling.PositionAttribute, 0)
+
+	// ASSERT =================================================================
+	assert.Equal(t, sphere.PrimitiveCount(), filled.PrimitiveCount())
+	assert.Equal(t, sphere.AttributeLength(), filled.AttributeLength())
+	assert.True(t, meshops.Manifoldness(filled).Watertight())
+}
+
+func TestFillHolesTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.FillHolesTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -7,6 +7,37 @@ import (
 	"github.com/EliCDavis/vector/vector4"
 )
 
+// keepPrimitives rebuilds the mesh from only the primitives whose vertices
+// are all kept, with each primitive staying in the material slot it started
+// in
+func keepPrimitives(m modeling.Mesh, verticesToKeep []bool) modeling.Mesh {
+	indices := m.Indices()
+	size := m.Topology().IndexSize()
+
+	finalIndices := make([]int, 0)
+	sources := make([]int, 0)
+	for p := 0; p < m.PrimitiveCount(); p++ {
+		keep := true
+		for i := p * size; i < (p+1)*size; i++ {
+			if !verticesToKeep[indices.At(i)] {
+				keep = false
+				break
+			}
+		}
+
+		if !keep {
+			continue
+		}
+
+		for i := p * size; i < (p+1)*size; i++ {
+			finalIndices = append(finalIndices, indices.At(i))
+		}
+		sources = append(sources, p)
+	}
+
+	return RemovedUnreferencedVertices(m.SetIndices(finalIndices).RemapPrimitiveGroups(m, sources))
+}
+
 type FilterFloat1Transformer struct {
 	Attribute string
 	Filter    func(v float64) bool
@@ -24,23 +55,15 @@ func FilterFloat1(m modeling.Mesh, attribute string, filter func(v float64) bool
 	check(RequireV1Attribute(m, attribute))
 
 	vertices := m.Float1Attribute(attribute)
-	verticeToKeep := make(map[int]struct{}, 0)
+	verticeToKeep := make([]bool, vertices.Len())
 
 	for i := 0; i < vertices.Len(); i++ {
 		if filter(vertices.At(i)) {
-			verticeToKeep[i] = struct{}{}
-		}
-	}
-
-	indices := m.Indices()
-	finalIndices := make([]int, 0)
-	for i := 0; i < indices.Len(); i++ {
-		if _, ok := verticeToKeep[indices.At(i)]; ok {
-			finalIndices = append(finalIndices, i)
+			verticeToKeep[i] = true
 		}
 	}
 
-	return RemovedUnreferencedVertices(m.SetIndices(finalIndices))
+	return keepPrimitives(m, verticeToKeep)
 }
 
 // FLOAT 2 ====================================================================
@@ -62,23 +85,15 @@ func FilterFloat2(m modeling.Mesh, attribute string, filter func(v vector2.Float
 	check(RequireV2Attribute(m, attribute))
 
 	vertices := m.Float2Attribute(attribute)
-	verticeToKeep := make(map[int]struct{}, 0)
+	verticeToKeep := make([]bool, vertices.Len())
 
 	for i := 0; i < vertices.Len(); i++ {
 		if filter(vertices.At(i)) {
-			verticeToKeep[i] = struct{}{}
-		}
-	}
-
-	indices := m.Indices()
-	finalIndices := make([]int, 0)
-	for i := 0; i < indices.Len(); i++ {
-		if _, ok := verticeToKeep[indices.At(i)]; ok {
-			finalIndices = append(finalIndices, i)
+			verticeToKeep[i] = true
 		}
 	}
 
-	return RemovedUnreferencedVertices(m.SetIndices(finalIndices))
+	return keepPrimitives(m, verticeToKeep)
 }
 
 // FLOAT 3 ====================================================================
@@ -108,15 +123,7 @@ func FilterFloat3(m modeling.Mesh, attribute string, filter func(v vector3.Float
 		}
 	}
 
-	indices := m.Indices()
-	finalIndices := make([]int, 0)
-	for i := 0; i < indices.Len(); i++ {
-		if verticeToKeep[indices.At(i)] {
-			finalIndices = append(finalIndices, i)
-		}
-	}
-
-	return RemovedUnreferencedVertices(m.SetIndices(finalIndices))
+	return keepPrimitives(m, verticeToKeep)
 }
 
 // FLOAT 4 ====================================================================
@@ -138,21 +145,13 @@ func FilterFloat4(m modeling.Mesh, attribute string, filter func(v vector4.Float
 	check(RequireV4Attribute(m, attribute))
 
 	vertices := m.Float4Attribute(attribute)
-	verticeToKeep := make(map[int]struct{}, 0)
+	verticeToKeep := make([]bool, vertices.Len())
 
 	for i := 0; i < vertices.Len(); i++ {
 		if filter(vertices.At(i)) {
-			verticeToKeep[i] = struct{}{}
-		}
-	}
-
-	indices := m.Indices()
-	finalIndices := make([]int, 0)
-	for i := 0; i < indices.Len(); i++ {
-		if _, ok := verticeToKeep[indices.At(i)]; ok {
-			finalIndices = append(finalIndices, i)
+			verticeToKeep[i] = true
 		}
 	}
 
-	return RemovedUnreferencedVertices(m.SetIndices(finalIndices))
+	return keepPrimitives(m, verticeToKeep)
 }
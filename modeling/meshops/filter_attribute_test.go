@@ -102,3 +102,28 @@ func TestFilterFloat4(t *testing.T) {
 	assert.Equal(t, vector4.New[float64](2, 0, 0, 0), transformed.Float4Attribute("test").At(0))
 	assert.Equal(t, 0, transformed.Indices().At(0))
 }
+
+func TestFilterFloat1_KeepsPrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.NewTriangleMesh([]int{
+		0, 1, 2,
+		1, 3, 2,
+		2, 3, 4,
+	}).
+		SetFloat1Attribute("keep", []float64{1, 1, 1, 1, 0}).
+		SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "a", Primitives: []int{0, 2}},
+			{Material: "b", Primitives: []int{1}},
+		})
+
+	// ACT ====================================================================
+	filtered := meshops.FilterFloat1(mesh, "keep", func(v float64) bool { return v == 1 })
+
+	// ASSERT =================================================================
+	assert.Equal(t, 2, filtered.PrimitiveCount())
+	assert.Equal(t, 4, filtered.AttributeLength())
+	assert.Equal(t, []modeling.PrimitiveGroup{
+		{Material: "a", Primitives: []int{0}},
+		{Material: "b", Primitives: []int{1}},
+	}, filtered.PrimitiveGroups())
+}
@@ -85,5 +85,12 @@ func (ca3dn ColorGradingLutNodeData) Out() nodes.StructOutput[modeling.Mesh] {
 		return nodes.NewStructOutput(ca3dn.Mesh.Value())
 	}
 
-	return nodes.NewStructOutput(ColorGradingLut(ca3dn.Mesh.Value(), lut.Value(), attr))
+	m := ca3dn.Mesh.Value()
+	if err := meshops.RequireV3Attribute(m, attr); err != nil {
+		out := nodes.NewStructOutput(m)
+		out.LogError(err)
+		return out
+	}
+
+	return nodes.NewStructOutput(ColorGradingLut(m, img, attr))
 }
@@ -0,0 +1,72 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/remesh"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type IsotropicRemeshTransformer struct {
+	TargetEdgeLength float64
+	Iterations       int
+	FeatureAngle     float64
+}
+
+func (irt IsotropicRemeshTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return remesh.Isotropic(m, remesh.Parameters{
+		TargetEdgeLength: irt.TargetEdgeLength,
+		Iterations:       irt.Iterations,
+		FeatureAngle:     irt.FeatureAngle,
+	})
+}
+
+// IsotropicRemesh rebuilds the surface out of evenly sized, well shaped
+// triangles with edges close to the target length. Passing 0 for the target
+// edge length uses the mesh's average edge length, and passing 0 for the
+// feature angle disables sharp feature preservation.
+func IsotropicRemesh(m modeling.Mesh, targetEdgeLength float64, iterations int, featureAngle float64) modeling.Mesh {
+	remeshed, err := IsotropicRemeshTransformer{
+		TargetEdgeLength: targetEdgeLength,
+		Iterations:       iterations,
+		FeatureAngle:     featureAngle,
+	}.Transform(m)
+	check(err)
+	return remeshed
+}
+
+type IsotropicRemeshNode = nodes.Struct[IsotropicRemeshNodeData]
+
+type IsotropicRemeshNodeData struct {
+	Mesh             nodes.Output[modeling.Mesh]
+	TargetEdgeLength nodes.Output[float64] `description:"Edge length to work towards, defaults to the mesh's average edge length"`
+	Iterations       nodes.Output[int]     `description:"Number of remeshing passes to run"`
+	FeatureAngle     nodes.Output[float64] `description:"Edges sharper than this angle (in radians) are preserved, 0 disables feature preservation"`
+}
+
+func (irn IsotropicRemeshNodeData) Description() string {
+	return "Rebuilds a triangle mesh out of evenly sized, well shaped triangles, projected back onto the original surface"
+}
+
+func (irn IsotropicRemeshNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if irn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := IsotropicRemeshTransformer{
+		TargetEdgeLength: nodes.TryGetOutputValue(irn.TargetEdgeLength, 0.),
+		Iterations:       nodes.TryGetOutputValue(irn.Iterations, remesh.DefaultIterations),
+		FeatureAngle:     nodes.TryGetOutputValue(irn.FeatureAngle, 0.),
+	}.Transform(irn.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
@@ -0,0 +1,39 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsotropicRemeshTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphere(1, 16, 16)
+	transformer := meshops.IsotropicRemeshTransformer{
+		TargetEdgeLength: 0.3,
+	}
+
+	// ACT ====================================================================
+	remeshed, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Greater(t, remeshed.PrimitiveCount(), 0)
+	assert.Less(t, remeshed.PrimitiveCount(), mesh.PrimitiveCount())
+}
+
+func TestIsotropicRemeshTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.IsotropicRemeshTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -1,6 +1,7 @@
 package meshops
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/EliCDavis/polyform/modeling"
@@ -33,6 +34,18 @@ func LaplacianSmooth(m modeling.Mesh, attribute string, iterations int, smoothin
 		panic(fmt.Errorf("attempting to apply laplacian smoothing to a mesh without the attribute: %s", attribute))
 	}
 
+	smoothed, _ := LaplacianSmoothContext(context.Background(), m, attribute, iterations, smoothingFactor)
+	return smoothed
+}
+
+// LaplacianSmoothContext is LaplacianSmooth, but checks for cancellation
+// between iterations, returning the context's error if it's done before
+// smoothing finishes.
+func LaplacianSmoothContext(ctx context.Context, m modeling.Mesh, attribute string, iterations int, smoothingFactor float64) (modeling.Mesh, error) {
+	if err := RequireV3Attribute(m, attribute); err != nil {
+		return m, err
+	}
+
 	lut := m.VertexNeighborTable()
 
 	oldVertices := m.Float3Attribute(attribute)
@@ -42,6 +55,10 @@ func LaplacianSmooth(m modeling.Mesh, attribute string, iterations int, smoothin
 	}
 
 	for i := 0; i < iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return m, err
+		}
+
 		for vi, vertex := range vertices {
 			var sum vector3.Float64
 
@@ -57,7 +74,7 @@ func LaplacianSmooth(m modeling.Mesh, attribute string, iterations int, smoothin
 		}
 	}
 
-	return m.SetFloat3Attribute(attribute, vertices)
+	return m.SetFloat3Attribute(attribute, vertices), nil
 }
 
 func LaplacianSmoothAlongAxis(m modeling.Mesh, attribute string, iterations int, smoothingFactor float64, axis vector3.Float64) modeling.Mesh {
@@ -104,15 +121,19 @@ type LaplacianSmoothNodeData struct {
 	SmoothingFactor nodes.Output[float64]
 }
 
-func (lp LaplacianSmoothNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+func (lp LaplacianSmoothNodeData) Out(ctx context.Context) nodes.StructOutput[modeling.Mesh] {
 	if lp.Mesh == nil {
 		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
 	}
 
-	return nodes.NewStructOutput(LaplacianSmooth(
+	smoothed, err := LaplacianSmoothContext(
+		ctx,
 		lp.Mesh.Value(),
 		nodes.TryGetOutputValue(lp.Attribute, modeling.PositionAttribute),
 		nodes.TryGetOutputValue(lp.Iterations, 10),
 		nodes.TryGetOutputValue(lp.SmoothingFactor, 0.1),
-	))
+	)
+	out := nodes.NewStructOutput(smoothed)
+	out.LogError(err)
+	return out
 }
@@ -0,0 +1,258 @@
+package meshops
+
+import (
+	"fmt"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+// faceTriangles copies the faces out of the connectivity so they can be
+// rewound or reindexed
+func faceTriangles(c *modeling.Connectivity) [][3]int {
+	out := make([][3]int, c.FaceCount())
+	for f := range out {
+		copy(out[f][:], c.Face(f))
+	}
+	return out
+}
+
+// traverses reports whether the triangle runs from a to b along one of its
+// edges
+func traverses(tri [3]int, a, b int) bool {
+	for i := range 3 {
+		if tri[i] == a && tri[(i+1)%3] == b {
+			return true
+		}
+	}
+	return false
+}
+
+// vertexFans groups the triangles around each vertex into fans, where two
+// triangles belong to the same fan if they share a manifold edge running
+// through the vertex. A vertex on a manifold surface has exactly one fan.
+func vertexFans(c *modeling.Connectivity) [][][]int {
+	fans := make([][][]int, c.VertexCount())
+	for v := range fans {
+		tris := c.VertexFaces(v)
+		visited := make(map[int]bool, len(tris))
+		for _, start := range tris {
+			if visited[start] {
+				continue
+			}
+
+			fan := []int{start}
+			visited[start] = true
+			for i := 0; i < len(fan); i++ {
+				h := c.FaceHalfEdge(fan[i])
+				for range 3 {
+					he := c.HalfEdge(h)
+					touches := he.Vertex == v || c.Destination(h) == v
+					h = he.Next
+
+					if !touches || he.Twin == -1 {
+						continue
+					}
+
+					neighbor := c.HalfEdge(he.Twin).Face
+					if !visited[neighbor] {
+						visited[neighbor] = true
+						fan = append(fan, neighbor)
+					}
+				}
+			}
+			fans[v] = append(fans[v], fan)
+		}
+	}
+	return fans
+}
+
+// boundaryLoops walks the edges that belong to a single triangle, returning
+// each closed loop they form. Loops are wound opposite of the triangles
+// bordering them, which is the order the triangles filling the hole need to
+// follow to match their neighbors.
+func boundaryLoops(c *modeling.Connectivity) [][]int {
+	outgoing := make(map[int][]int)
+	starts := make([]int, 0)
+	for h := range c.HalfEdgeCount() {
+		he := c.HalfEdge(h)
+		if c.Degenerate(he.Face) {
+			continue
+		}
+
+		destination := c.Destination(h)
+		if !c.IsBoundaryEdge(modeling.NewEdge(he.Vertex, destination)) {
+			continue
+		}
+
+		outgoing[destination] = append(outgoing[destination], he.Vertex)
+		starts = append(starts, destination)
+	}
+
+	loops := make([][]int, 0)
+	for _, start := range starts {
+		for len(outgoing[start]) > 0 {
+			loop := []int{start}
+			current := start
+			closed := false
+			for {
+				next := outgoing[current]
+				if len(next) == 0 {
+					break
+				}
+				outgoing[current] = next[1:]
+				current = next[0]
+
+				if current == start {
+					closed = true
+					break
+				}
+				loop = append(loop, current)
+			}
+
+			if closed && len(loop) > 2 {
+				loops = append(loops, loop)
+			}
+		}
+	}
+	return loops
+}
+
+// ManifoldSummary describes how far a triangle mesh is from being a closed,
+// consistently wound, 2-manifold surface
+type ManifoldSummary struct {
+	Triangles           int
+	DegenerateTriangles int // Triangles that reference the same vertex more than once
+	Components          int // Groups of vertices connected by edges
+	BoundaryEdges       int // Edges belonging to a single triangle
+	BoundaryLoops       int // Closed loops of boundary edges, or holes
+	NonManifoldEdges    int // Edges shared by more than two triangles
+	NonManifoldVertices int // Vertices whose triangles don't form a single fan
+	InconsistentEdges   int // Edges whose two triangles run along it in the same direction
+}
+
+// Watertight reports whether the mesh is a closed, consistently wound
+// 2-manifold surface
+func (ms ManifoldSummary) Watertight() bool {
+	return ms.Triangles > 0 &&
+		ms.BoundaryEdges == 0 &&
+		ms.NonManifoldEdges == 0 &&
+		ms.NonManifoldVertices == 0 &&
+		ms.InconsistentEdges == 0
+}
+
+func (ms ManifoldSummary) String() string {
+	return fmt.Sprintf(
+		"triangles: %d, degenerate triangles: %d, components: %d, boundary edges: %d, boundary loops: %d, non-manifold edges: %d, non-manifold vertices: %d, inconsistent edges: %d, watertight: %t",
+		ms.Triangles,
+		ms.DegenerateTriangles,
+		ms.Components,
+		ms.BoundaryEdges,
+		ms.BoundaryLoops,
+		ms.NonManifoldEdges,
+		ms.NonManifoldVertices,
+		ms.InconsistentEdges,
+		ms.Watertight(),
+	)
+}
+
+// Manifoldness summarizes the problems keeping a triangle mesh from being
+// watertight. Connectivity is taken from the mesh's indices as is, so meshes
+// split along attribute seams should be welded with WeldByFloat3Attribute
+// beforehand.
+func Manifoldness(m modeling.Mesh) ManifoldSummary {
+	check(RequireTopology(m, modeling.TriangleTopology))
+
+	c := m.Connectivity()
+	summary := ManifoldSummary{
+		Triangles:     m.PrimitiveCount(),
+		BoundaryEdges: len(c.BoundaryEdges()),
+		BoundaryLoops: len(boundaryLoops(c)),
+	}
+
+	for f := range c.FaceCount() {
+		if c.Degenerate(f) {
+			summary.DegenerateTriangles++
+		}
+	}
+
+	for _, e := range c.Edges() {
+		halfs := c.EdgeHalfEdges(e)
+		switch {
+		case len(halfs) > 2:
+			summary.NonManifoldEdges++
+
+		case len(halfs) == 2 && c.HalfEdge(halfs[0]).Vertex == c.HalfEdge(halfs[1]).Vertex:
+			summary.InconsistentEdges++
+		}
+	}
+
+	for _, fans := range vertexFans(c) {
+		if len(fans) > 1 {
+			summary.NonManifoldVertices++
+		}
+	}
+
+	// Degenerate triangles still connect the vertices they reference, so
+	// components are counted from the mesh rather than the connectivity
+	lut := m.VertexNeighborTable()
+	visited := make(map[int]bool, len(lut))
+	for v := range lut {
+		if visited[v] {
+			continue
+		}
+
+		summary.Components++
+		visited[v] = true
+		queue := []int{v}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for n := range lut.Lookup(current) {
+				if !visited[n] {
+					visited[n] = true
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+
+	return summary
+}
+
+type ManifoldSummaryNode = nodes.Struct[ManifoldSummaryNodeData]
+
+type ManifoldSummaryNodeData struct {
+	Mesh nodes.Output[modeling.Mesh]
+}
+
+func (msn ManifoldSummaryNodeData) Description() string {
+	return "Reports the holes, non-manifold geometry, and inconsistent winding keeping a triangle mesh from being watertight"
+}
+
+func (msn ManifoldSummaryNodeData) summary() ManifoldSummary {
+	if msn.Mesh == nil {
+		return ManifoldSummary{}
+	}
+	return Manifoldness(msn.Mesh.Value())
+}
+
+func (msn ManifoldSummaryNodeData) Watertight() nodes.StructOutput[bool] {
+	return nodes.NewStructOutput(msn.summary().Watertight())
+}
+
+func (msn ManifoldSummaryNodeData) Summary() nodes.StructOutput[string] {
+	return nodes.NewStructOutput(msn.summary().String())
+}
+
+func (msn ManifoldSummaryNodeData) BoundaryLoops() nodes.StructOutput[int] {
+	return nodes.NewStructOutput(msn.summary().BoundaryLoops)
+}
+
+func (msn ManifoldSummaryNodeData) NonManifoldEdges() nodes.StructOutput[int] {
+	return nodes.NewStructOutput(msn.summary().NonManifoldEdges)
+}
+
+func (msn ManifoldSummaryNodeData) NonManifoldVertices() nodes.StructOutput[int] {
+	return nodes.NewStructOutput(msn.summary().NonManifoldVertices)
+}
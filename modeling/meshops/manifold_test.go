@@ -0,0 +1,92 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifoldness_ClosedMesh(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphere(1, 16, 16)
+
+	// ACT ====================================================================
+	summary := meshops.Manifoldness(mesh)
+
+	// ASSERT =================================================================
+	assert.True(t, summary.Watertight())
+	assert.Equal(t, 1, summary.Components)
+	assert.Equal(t, 0, summary.BoundaryEdges)
+	assert.Equal(t, 0, summary.BoundaryLoops)
+}
+
+func TestManifoldness_Problems(t *testing.T) {
+	tests := map[string]struct {
+		indices  []int
+		expected meshops.ManifoldSummary
+	}{
+		"single triangle": {
+			indices: []int{0, 1, 2},
+			expected: meshops.ManifoldSummary{
+				Triangles:     1,
+				Components:    1,
+				BoundaryEdges: 3,
+				BoundaryLoops: 1,
+			},
+		},
+		"bowtie": {
+			indices: []int{0, 1, 2, 0, 3, 4},
+			expected: meshops.ManifoldSummary{
+				Triangles:           2,
+				Components:          1,
+				BoundaryEdges:       6,
+				BoundaryLoops:       2,
+				NonManifoldVertices: 1,
+			},
+		},
+		"inconsistent winding": {
+			indices: []int{0, 1, 2, 0, 1, 3},
+			expected: meshops.ManifoldSummary{
+				Triangles:         2,
+				Components:        1,
+				BoundaryEdges:     4,
+				InconsistentEdges: 1,
+			},
+		},
+		"three triangles on one edge": {
+			indices: []int{0, 1, 2, 1, 0, 3, 0, 1, 4},
+			expected: meshops.ManifoldSummary{
+				Triangles:           3,
+				Components:          1,
+				BoundaryEdges:       6,
+				BoundaryLoops:       1,
+				NonManifoldEdges:    1,
+				NonManifoldVertices: 2,
+			},
+		},
+		"degenerate": {
+			indices: []int{0, 1, 2, 3, 3, 4},
+			expected: meshops.ManifoldSummary{
+				Triangles:           2,
+				DegenerateTriangles: 1,
+				Components:          2,
+				BoundaryEdges:       3,
+				BoundaryLoops:       1,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mesh := modeling.NewTriangleMesh(tc.indices).
+				SetFloat3Attribute(modeling.PositionAttribute, make([]vector3.Float64, 5))
+			summary := meshops.Manifoldness(mesh)
+			assert.Equal(t, tc.expected, summary)
+			assert.False(t, summary.Watertight())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/simplify"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type QuadricDecimationTransformer struct {
+	TargetTriangleCount int
+	MaxError            float64
+	BoundaryWeight      float64
+}
+
+func (qdt QuadricDecimationTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return QuadricDecimation(m, qdt.TargetTriangleCount, qdt.MaxError, qdt.BoundaryWeight), nil
+}
+
+// QuadricDecimation reduces the number of triangles in the mesh until either
+// the target triangle count is reached, or any further edge collapse would
+// introduce more error than allowed. Passing 0 for either limit disables it.
+func QuadricDecimation(m modeling.Mesh, targetTriangleCount int, maxError, boundaryWeight float64) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	return simplify.QuadricDecimation(m, simplify.QuadricDecimationParameters{
+		TargetTriangleCount: targetTriangleCount,
+		MaxError:            maxError,
+		BoundaryWeight:      boundaryWeight,
+	})
+}
+
+type QuadricDecimationNode = nodes.Struct[QuadricDecimationNodeData]
+
+type QuadricDecimationNodeData struct {
+	Mesh                nodes.Output[modeling.Mesh]
+	TargetTriangleCount nodes.Output[int]
+	MaxError            nodes.Output[float64]
+	BoundaryWeight      nodes.Output[float64]
+}
+
+func (qdn QuadricDecimationNodeData) Description() string {
+	return "Reduces the triangle count of a mesh through quadric error metric edge collapses, preserving boundaries and attribute seams"
+}
+
+func (qdn QuadricDecimationNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if qdn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	return nodes.NewStructOutput(QuadricDecimation(
+		qdn.Mesh.Value(),
+		nodes.TryGetOutputValue(qdn.TargetTriangleCount, 0),
+		nodes.TryGetOutputValue(qdn.MaxError, 0.),
+		nodes.TryGetOutputValue(qdn.BoundaryWeight, 0.),
+	))
+}
@@ -0,0 +1,40 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuadricDecimationTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphere(1, 16, 16)
+	transformer := meshops.QuadricDecimationTransformer{
+		TargetTriangleCount: 100,
+	}
+
+	// ACT ====================================================================
+	decimated, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, decimated.PrimitiveCount(), 100)
+	assert.Greater(t, decimated.PrimitiveCount(), 0)
+}
+
+func TestQuadricDecimationTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.QuadricDecimationTransformer{
+		TargetTriangleCount: 100,
+	}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
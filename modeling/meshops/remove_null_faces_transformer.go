@@ -40,6 +40,7 @@ func RemoveNullFaces3D(m modeling.Mesh, attribute string, minArea float64) model
 
 	indices := m.Indices()
 	trisToKeep := make([]int, 0)
+	kept := make([]int, 0)
 	for i := 0; i < m.PrimitiveCount(); i++ {
 		tri := m.Tri(i)
 		area := tri.Area3D(attribute)
@@ -50,6 +51,7 @@ func RemoveNullFaces3D(m modeling.Mesh, attribute string, minArea float64) model
 				tri.P2(),
 				tri.P3(),
 			)
+			kept = append(kept, i)
 		}
 	}
 
@@ -58,5 +60,5 @@ func RemoveNullFaces3D(m modeling.Mesh, attribute string, minArea float64) model
 		return m
 	}
 
-	return RemovedUnreferencedVertices(m.SetIndices(trisToKeep))
+	return RemovedUnreferencedVertices(m.SetIndices(trisToKeep).RemapPrimitiveGroups(m, kept))
 }
@@ -43,10 +43,13 @@ func RemovedUnreferencedVertices(m modeling.Mesh) modeling.Mesh {
 	}
 
 	shiftBy := make([]int, m.AttributeLength())
+	sources := make([]int, 0, len(shiftBy))
 	skipped := 0
 	for i := range shiftBy {
 		if !used[i] {
 			skipped++
+		} else {
+			sources = append(sources, i)
 		}
 		shiftBy[i] = skipped
 	}
@@ -66,5 +69,7 @@ func RemovedUnreferencedVertices(m modeling.Mesh) modeling.Mesh {
 		SetFloat4Data(finalV4Data).
 		SetFloat3Data(finalV3Data).
 		SetFloat2Data(finalV2Data).
-		SetFloat1Data(finalV1Data)
+		SetFloat1Data(finalV1Data).
+		RemapIntAttributes(m, sources).
+		CopyPrimitiveGroups(m)
 }
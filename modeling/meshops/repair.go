@@ -0,0 +1,78 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type RepairTransformer struct {
+	Attribute string
+
+	// Vertices whose positions match when rounded to this many decimal
+	// places are merged together before repairing
+	WeldDecimalPlaces int
+
+	// Holes bordered by more edges than this are left open. Zero or less
+	// fills every hole.
+	MaxHoleEdges int
+}
+
+func (rt RepairTransformer) attribute() string {
+	return rt.Attribute
+}
+
+func (rt RepairTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	attribute := getAttribute(rt, modeling.PositionAttribute)
+
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, attribute); err != nil {
+		return
+	}
+
+	return Repair(m, attribute, rt.WeldDecimalPlaces, rt.MaxHoleEdges), nil
+}
+
+// Repair attempts to make a triangle mesh watertight. Vertices are welded
+// by position (see WeldByFloat3Attribute, which also drops degenerate
+// triangles), non-manifold vertices are split apart, winding is made
+// consistent across each connected component, and finally any remaining
+// holes are filled. Welding merges vertices along attribute seams, keeping
+// the attributes of whichever vertex came first.
+func Repair(m modeling.Mesh, attribute string, weldDecimalPlaces, maxHoleEdges int) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, attribute))
+
+	welded := m.WeldByFloat3Attribute(attribute, weldDecimalPlaces)
+	split := SplitNonManifoldVertices(welded)
+	oriented := ConsistentWinding(split, attribute)
+	return FillHoles(oriented, attribute, maxHoleEdges)
+}
+
+type RepairNode = nodes.Struct[RepairNodeData]
+
+type RepairNodeData struct {
+	Mesh              nodes.Output[modeling.Mesh]
+	Attribute         nodes.Output[string] `description:"Position attribute to weld and repair by"`
+	WeldDecimalPlaces nodes.Output[int]    `description:"Vertices matching when rounded to this many decimal places are merged"`
+	MaxHoleEdges      nodes.Output[int]    `description:"Holes bordered by more edges than this are left open (defaults to 300), 0 fills every hole"`
+}
+
+func (rn RepairNodeData) Description() string {
+	return "Welds, splits non-manifold vertices, fixes winding, and fills holes in an attempt to make a triangle mesh watertight, leaving holes bordered by more than 300 edges open unless told otherwise"
+}
+
+func (rn RepairNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if rn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	return nodes.NewStructOutput(Repair(
+		rn.Mesh.Value(),
+		nodes.TryGetOutputValue(rn.Attribute, modeling.PositionAttribute),
+		nodes.TryGetOutputValue(rn.WeldDecimalPlaces, 3),
+		nodes.TryGetOutputValue(rn.MaxHoleEdges, nodeMaxHoleEdges),
+	))
+}
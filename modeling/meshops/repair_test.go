@@ -0,0 +1,42 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := meshops.FlipTriangleWinding(removeTriangles(primitives.UVSphereUnwelded(1, 16, 16), 20))
+	transformer := meshops.RepairTransformer{
+		WeldDecimalPlaces: 4,
+	}
+
+	// ACT ====================================================================
+	repaired, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.False(t, meshops.Manifoldness(mesh).Watertight())
+
+	summary := meshops.Manifoldness(repaired)
+	assert.True(t, summary.Watertight(), summary.String())
+	assert.Equal(t, 1, summary.Components)
+}
+
+func TestRepairTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.RepairTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -44,6 +44,8 @@ func SliceByPlaneWithAttribute(m modeling.Mesh, plane geometry.Plane, attribute
 
 	belowPlaneIndices := make([]int, 0)
 	abovePlaneIndices := make([]int, 0)
+	belowPlaneTris := make([]int, 0)
+	abovePlaneTris := make([]int, 0)
 
 	// Mark which tris belong in retained or clipped
 	for t := 0; t < numFaces; t++ {
@@ -55,8 +57,10 @@ func SliceByPlaneWithAttribute(m modeling.Mesh, plane geometry.Plane, attribute
 
 		if !aClip && !bClip && !cClip {
 			belowPlaneIndices = append(belowPlaneIndices, tri.P1(), tri.P2(), tri.P3())
+			belowPlaneTris = append(belowPlaneTris, t)
 		} else if aClip && bClip && cClip {
 			abovePlaneIndices = append(abovePlaneIndices, tri.P1(), tri.P2(), tri.P3())
+			abovePlaneTris = append(abovePlaneTris, t)
 		} else {
 
 			lineIntersections := make([]geometry.Line3D, 0, 2)
@@ -87,13 +91,17 @@ func SliceByPlaneWithAttribute(m modeling.Mesh, plane geometry.Plane, attribute
 		SetFloat4Data(v4Data).
 		SetFloat3Data(v3Data).
 		SetFloat2Data(v2Data).
-		SetFloat1Data(v1Data)
+		SetFloat1Data(v1Data).
+		CopyIntAttributes(m).
+		RemapPrimitiveGroups(m, abovePlaneTris)
 
 	below := modeling.NewMesh(m.Topology(), belowPlaneIndices).
 		SetFloat4Data(v4Data).
 		SetFloat3Data(v3Data).
 		SetFloat2Data(v2Data).
-		SetFloat1Data(v1Data)
+		SetFloat1Data(v1Data).
+		CopyIntAttributes(m).
+		RemapPrimitiveGroups(m, belowPlaneTris)
 
 	return RemovedUnreferencedVertices(above), RemovedUnreferencedVertices(below)
 }
@@ -0,0 +1,79 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type SplitNonManifoldVerticesTransformer struct{}
+
+func (snmvt SplitNonManifoldVerticesTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	return SplitNonManifoldVertices(m), nil
+}
+
+// SplitNonManifoldVertices duplicates every vertex whose triangles form more
+// than one fan (such as two cones touching at their tips), giving each fan
+// its own copy of the vertex. Since fans are only joined across edges shared
+// by exactly two triangles, this also pulls apart non-manifold edges.
+func SplitNonManifoldVertices(m modeling.Mesh) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+
+	c := m.Connectivity()
+	fans := vertexFans(c)
+
+	vertexCount := m.AttributeLength()
+
+	triangles := faceTriangles(c)
+
+	vertices := make([][]vertexWeight, vertexCount)
+	for i := range vertices {
+		vertices[i] = []vertexWeight{{vertex: i, amount: 1}}
+	}
+
+	split := false
+	for v, vertexFans := range fans {
+		for _, fan := range vertexFans[min(len(vertexFans), 1):] {
+			split = true
+			duplicate := len(vertices)
+			vertices = append(vertices, []vertexWeight{{vertex: v, amount: 1}})
+			for _, t := range fan {
+				for i, index := range triangles[t] {
+					if index == v {
+						triangles[t][i] = duplicate
+					}
+				}
+			}
+		}
+	}
+
+	if !split {
+		return m
+	}
+
+	indices := make([]int, 0, len(triangles)*3)
+	for _, tri := range triangles {
+		indices = append(indices, tri[:]...)
+	}
+	return resampleVertices(m, indices, vertices).CopyPrimitiveGroups(m)
+}
+
+type SplitNonManifoldVerticesNode = nodes.Struct[SplitNonManifoldVerticesNodeData]
+
+type SplitNonManifoldVerticesNodeData struct {
+	Mesh nodes.Output[modeling.Mesh]
+}
+
+func (snmvn SplitNonManifoldVerticesNodeData) Description() string {
+	return "Duplicates vertices shared by otherwise disconnected fans of triangles, so every vertex sits on a single sheet of the surface"
+}
+
+func (snmvn SplitNonManifoldVerticesNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if snmvn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+	return nodes.NewStructOutput(SplitNonManifoldVertices(snmvn.Mesh.Value()))
+}
@@ -0,0 +1,72 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitNonManifoldVerticesTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.NewTriangleMesh([]int{0, 1, 2, 0, 3, 4}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(-1., 0., 0.),
+			vector3.New(-1., -1., 0.),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0.5, 0.5),
+			vector2.New(1., 0.5),
+			vector2.New(1., 1.),
+			vector2.New(0., 0.5),
+			vector2.New(0., 0.),
+		})
+	transformer := meshops.SplitNonManifoldVerticesTransformer{}
+
+	// ACT ====================================================================
+	split, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 6, split.AttributeLength())
+	assert.Equal(t, 0, meshops.Manifoldness(split).NonManifoldVertices)
+	assert.Equal(t, 2, meshops.Manifoldness(split).Components)
+
+	indices := split.Indices()
+	positions := split.Float3Attribute(modeling.PositionAttribute)
+	uvs := split.Float2Attribute(modeling.TexCoordAttribute)
+	assert.NotEqual(t, indices.At(0), indices.At(3))
+	assert.Equal(t, positions.At(indices.At(0)), positions.At(indices.At(3)))
+	assert.Equal(t, uvs.At(indices.At(0)), uvs.At(indices.At(3)))
+}
+
+func TestSplitNonManifoldVerticesTransformer_Manifold(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.NewTriangleMesh([]int{0, 1, 2, 2, 1, 3}).
+		SetFloat3Attribute(modeling.PositionAttribute, make([]vector3.Float64, 4))
+
+	// ACT ====================================================================
+	split := meshops.SplitNonManifoldVertices(mesh)
+
+	// ASSERT =================================================================
+	assert.Equal(t, 4, split.AttributeLength())
+}
+
+func TestSplitNonManifoldVerticesTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.SplitNonManifoldVerticesTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
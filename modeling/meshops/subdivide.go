@@ -0,0 +1,130 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/subdivide"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type CatmullClarkTransformer struct {
+	Levels      int
+	CreaseAngle float64
+	KeepQuads   bool
+}
+
+func (cct CatmullClarkTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if m.Topology() != modeling.QuadTopology {
+		if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+			return
+		}
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return subdivide.CatmullClark(m, subdivide.Parameters{
+		Levels:      cct.Levels,
+		CreaseAngle: cct.CreaseAngle,
+		KeepQuads:   cct.KeepQuads,
+	})
+}
+
+// CatmullClark subdivides a triangle or quad mesh the number of levels
+// specified, triangulating the resulting quads. Edges whose faces meet at
+// an angle greater than the crease angle (in radians) are kept sharp, with
+// 0 disabling creasing.
+func CatmullClark(m modeling.Mesh, levels int, creaseAngle float64) modeling.Mesh {
+	subdivided, err := CatmullClarkTransformer{
+		Levels:      levels,
+		CreaseAngle: creaseAngle,
+	}.Transform(m)
+	check(err)
+	return subdivided
+}
+
+type LoopSubdivisionTransformer struct {
+	Levels      int
+	CreaseAngle float64
+}
+
+func (lst LoopSubdivisionTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return subdivide.Loop(m, subdivide.Parameters{
+		Levels:      lst.Levels,
+		CreaseAngle: lst.CreaseAngle,
+	})
+}
+
+// LoopSubdivision subdivides a triangle mesh the number of levels
+// specified. Edges whose faces meet at an angle greater than the crease
+// angle (in radians) are kept sharp, with 0 disabling creasing.
+func LoopSubdivision(m modeling.Mesh, levels int, creaseAngle float64) modeling.Mesh {
+	subdivided, err := LoopSubdivisionTransformer{
+		Levels:      levels,
+		CreaseAngle: creaseAngle,
+	}.Transform(m)
+	check(err)
+	return subdivided
+}
+
+type CatmullClarkNode = nodes.Struct[CatmullClarkNodeData]
+
+type CatmullClarkNodeData struct {
+	Mesh        nodes.Output[modeling.Mesh]
+	Levels      nodes.Output[int]     `description:"Number of times to subdivide the mesh, defaults to 1, at most 6"`
+	CreaseAngle nodes.Output[float64] `description:"Edges sharper than this angle (in radians) are kept sharp, 0 disables creasing"`
+}
+
+func (ccn CatmullClarkNodeData) Description() string {
+	return "Smooths a triangle or quad mesh using Catmull-Clark subdivision"
+}
+
+func (ccn CatmullClarkNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if ccn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := CatmullClarkTransformer{
+		Levels:      nodes.TryGetOutputValue(ccn.Levels, 1),
+		CreaseAngle: nodes.TryGetOutputValue(ccn.CreaseAngle, 0.),
+	}.Transform(ccn.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
+
+type LoopSubdivisionNode = nodes.Struct[LoopSubdivisionNodeData]
+
+type LoopSubdivisionNodeData struct {
+	Mesh        nodes.Output[modeling.Mesh]
+	Levels      nodes.Output[int]     `description:"Number of times to subdivide the mesh, defaults to 1, at most 6"`
+	CreaseAngle nodes.Output[float64] `description:"Edges sharper than this angle (in radians) are kept sharp, 0 disables creasing"`
+}
+
+func (lsn LoopSubdivisionNodeData) Description() string {
+	return "Smooths a triangle mesh using Loop subdivision"
+}
+
+func (lsn LoopSubdivisionNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if lsn.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := LoopSubdivisionTransformer{
+		Levels:      nodes.TryGetOutputValue(lsn.Levels, 1),
+		CreaseAngle: nodes.TryGetOutputValue(lsn.CreaseAngle, 0.),
+	}.Transform(lsn.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
@@ -0,0 +1,53 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatmullClarkTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UnitCube()
+	transformer := meshops.CatmullClarkTransformer{Levels: 1}
+
+	// ACT ====================================================================
+	subdivided, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, modeling.TriangleTopology, subdivided.Topology())
+
+	// Each triangle becomes 3 quads, each split into 2 triangles
+	assert.Equal(t, mesh.PrimitiveCount()*6, subdivided.PrimitiveCount())
+}
+
+func TestLoopSubdivisionTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UnitCube()
+	transformer := meshops.LoopSubdivisionTransformer{Levels: 2}
+
+	// ACT ====================================================================
+	subdivided, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, mesh.PrimitiveCount()*16, subdivided.PrimitiveCount())
+	assert.True(t, subdivided.HasFloat3Attribute(modeling.NormalAttribute))
+}
+
+func TestLoopSubdivisionTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.LoopSubdivisionTransformer{Levels: 1}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -12,6 +12,18 @@ func init() {
 	refutil.RegisterType[CropAttribute3DNode](factory)
 	refutil.RegisterType[CenterAttribute3DNode](factory)
 	refutil.RegisterType[LaplacianSmoothNode](factory)
+	refutil.RegisterType[QuadricDecimationNode](factory)
+	refutil.RegisterType[IsotropicRemeshNode](factory)
+	refutil.RegisterType[UnwrapUVsNode](factory)
+	refutil.RegisterType[CatmullClarkNode](factory)
+	refutil.RegisterType[LoopSubdivisionNode](factory)
+	refutil.RegisterType[FillHolesNode](factory)
+	refutil.RegisterType[SplitNonManifoldVerticesNode](factory)
+	refutil.RegisterType[ConsistentWindingNode](factory)
+	refutil.RegisterType[RepairNode](factory)
+	refutil.RegisterType[ManifoldSummaryNode](factory)
+	refutil.RegisterType[SplitComponentsNode](factory)
+	refutil.RegisterType[FilterComponentsNode](factory)
 
 	refutil.RegisterType[CombineNode](factory)
 
@@ -22,5 +34,9 @@ func init() {
 	refutil.RegisterType[ScaleAttribute3DNode](factory)
 	refutil.RegisterType[ScaleAttributeAlongNormalNode](factory)
 
+	refutil.RegisterType[AmbientOcclusionNode](factory)
+	refutil.RegisterType[MeanCurvatureNode](factory)
+	refutil.RegisterType[GaussianCurvatureNode](factory)
+
 	generator.RegisterTypes(factory)
 }
@@ -38,8 +38,10 @@ func Unweld(m modeling.Mesh) modeling.Mesh {
 
 	originalIndices := m.Indices()
 	indices := make([]int, originalIndices.Len())
+	sources := make([]int, originalIndices.Len())
 	for i := 0; i < len(indices); i++ {
 		indices[i] = i
+		sources[i] = originalIndices.At(i)
 		for _, atr := range m.Float4Attributes() {
 			data := m.Float4Attribute(atr)
 			unweldedV4Data[atr] = append(unweldedV4Data[atr], data.At(originalIndices.At(i)))
@@ -66,5 +68,7 @@ func Unweld(m modeling.Mesh) modeling.Mesh {
 		SetFloat4Data(unweldedV4Data).
 		SetFloat3Data(unweldedV3Data).
 		SetFloat2Data(unweldedV2Data).
-		SetFloat1Data(unweldedV1Data)
+		SetFloat1Data(unweldedV1Data).
+		RemapIntAttributes(m, sources).
+		CopyPrimitiveGroups(m)
 }
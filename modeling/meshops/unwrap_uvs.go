@@ -0,0 +1,81 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/unwrap"
+	"github.com/EliCDavis/polyform/nodes"
+)
+
+type UnwrapUVsTransformer struct {
+	SeamAngle     float64
+	MaxChartAngle float64
+	Padding       float64
+	Attribute     string
+}
+
+func (uut UnwrapUVsTransformer) Transform(m modeling.Mesh) (results modeling.Mesh, err error) {
+	if err = RequireTopology(m, modeling.TriangleTopology); err != nil {
+		return
+	}
+
+	if err = RequireV3Attribute(m, modeling.PositionAttribute); err != nil {
+		return
+	}
+
+	return unwrap.Unwrap(m, unwrap.Parameters{
+		SeamAngle:     uut.SeamAngle,
+		MaxChartAngle: uut.MaxChartAngle,
+		Padding:       uut.Padding,
+		Attribute:     uut.Attribute,
+	})
+}
+
+// UnwrapUVs generates a texture atlas for the mesh, cutting it into charts
+// along its sharp edges, flattening each chart with a least squares
+// conformal map, and packing the charts into the unit square. The resulting
+// UVs are written to the attribute specified. Passing 0 for any of the
+// angles or the padding uses the defaults found in the unwrap package.
+func UnwrapUVs(m modeling.Mesh, attribute string, seamAngle, maxChartAngle, padding float64) modeling.Mesh {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, modeling.PositionAttribute))
+
+	unwrapped, err := unwrap.Unwrap(m, unwrap.Parameters{
+		SeamAngle:     seamAngle,
+		MaxChartAngle: maxChartAngle,
+		Padding:       padding,
+		Attribute:     attribute,
+	})
+	check(err)
+	return unwrapped
+}
+
+type UnwrapUVsNode = nodes.Struct[UnwrapUVsNodeData]
+
+type UnwrapUVsNodeData struct {
+	Mesh          nodes.Output[modeling.Mesh]
+	Attribute     nodes.Output[string]  `description:"Attribute to write the UVs to, defaults to the texture coordinate attribute"`
+	SeamAngle     nodes.Output[float64] `description:"Edges sharper than this angle (in radians) are cut into seams"`
+	MaxChartAngle nodes.Output[float64] `description:"Max angle (in radians) a face's normal can deviate from the face its chart was grown from"`
+	Padding       nodes.Output[float64] `description:"Space left around each chart, as a fraction of the atlas' size"`
+}
+
+func (uun UnwrapUVsNodeData) Description() string {
+	return "Automatically generates UVs for a mesh by segmenting it into charts, flattening each with a least squares conformal map, and packing them into an atlas"
+}
+
+func (uun UnwrapUVsNodeData) Out() nodes.StructOutput[modeling.Mesh] {
+	if uun.Mesh == nil {
+		return nodes.NewStructOutput(modeling.EmptyMesh(modeling.TriangleTopology))
+	}
+
+	out, err := UnwrapUVsTransformer{
+		Attribute:     nodes.TryGetOutputValue(uun.Attribute, modeling.TexCoordAttribute),
+		SeamAngle:     nodes.TryGetOutputValue(uun.SeamAngle, 0.),
+		MaxChartAngle: nodes.TryGetOutputValue(uun.MaxChartAngle, 0.),
+		Padding:       nodes.TryGetOutputValue(uun.Padding, 0.),
+	}.Transform(uun.Mesh.Value())
+
+	result := nodes.NewStructOutput(out)
+	result.LogError(err)
+	return result
+}
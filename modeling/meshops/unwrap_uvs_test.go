@@ -0,0 +1,37 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnwrapUVsTransformer(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UnitCube()
+	transformer := meshops.UnwrapUVsTransformer{}
+
+	// ACT ====================================================================
+	unwrapped, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.True(t, unwrapped.HasFloat2Attribute(modeling.TexCoordAttribute))
+	assert.Equal(t, mesh.PrimitiveCount(), unwrapped.PrimitiveCount())
+}
+
+func TestUnwrapUVsTransformer_RequiresTriangles(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := modeling.EmptyPointcloud()
+	transformer := meshops.UnwrapUVsTransformer{}
+
+	// ACT ====================================================================
+	_, err := transformer.Transform(mesh)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, err, meshops.ErrRequireTriangleTopology)
+}
@@ -95,3 +95,87 @@ func readAllFloat2Data(m modeling.Mesh) map[string][]vector2.Float64 {
 func readAllFloat1Data(m modeling.Mesh) map[string][]float64 {
 	return readAllFloatXData(m.Float1Attributes(), func(s string) *iter.ArrayIterator[float64] { return m.Float1Attribute(s) })
 }
+
+type vertexWeight struct {
+	vertex int
+	amount float64
+}
+
+// dominantVertex returns the original vertex contributing the most to the
+// weighted sum, or -1 if there are no weights
+func dominantVertex(weights []vertexWeight) int {
+	vertex := -1
+	amount := 0.
+	for _, w := range weights {
+		if vertex == -1 || w.amount > amount {
+			vertex = w.vertex
+			amount = w.amount
+		}
+	}
+	return vertex
+}
+
+// resampleVertices builds a mesh out of the indices provided, where each
+// vertex is a weighted sum of the original mesh's vertices. Normals are
+// renormalized after blending. Integer attributes are taken from whichever
+// original vertex carries the most weight.
+func resampleVertices(m modeling.Mesh, indices []int, vertices [][]vertexWeight) modeling.Mesh {
+	sources := make([]int, len(vertices))
+	for i, weights := range vertices {
+		sources[i] = dominantVertex(weights)
+	}
+	result := modeling.NewMesh(m.Topology(), indices).RemapIntAttributes(m, sources)
+
+	for attr, data := range readAllFloat4Data(m) {
+		values := make([]vector4.Float64, len(vertices))
+		for i, weights := range vertices {
+			v := vector4.Zero[float64]()
+			for _, w := range weights {
+				v = v.Add(data[w.vertex].Scale(w.amount))
+			}
+			values[i] = v
+		}
+		result = result.SetFloat4Attribute(attr, values)
+	}
+
+	for attr, data := range readAllFloat3Data(m) {
+		values := make([]vector3.Float64, len(vertices))
+		for i, weights := range vertices {
+			v := vector3.Zero[float64]()
+			for _, w := range weights {
+				v = v.Add(data[w.vertex].Scale(w.amount))
+			}
+			if attr == modeling.NormalAttribute && len(weights) > 1 && v.Length() > 0 {
+				v = v.Normalized()
+			}
+			values[i] = v
+		}
+		result = result.SetFloat3Attribute(attr, values)
+	}
+
+	for attr, data := range readAllFloat2Data(m) {
+		values := make([]vector2.Float64, len(vertices))
+		for i, weights := range vertices {
+			v := vector2.Zero[float64]()
+			for _, w := range weights {
+				v = v.Add(data[w.vertex].Scale(w.amount))
+			}
+			values[i] = v
+		}
+		result = result.SetFloat2Attribute(attr, values)
+	}
+
+	for attr, data := range readAllFloat1Data(m) {
+		values := make([]float64, len(vertices))
+		for i, weights := range vertices {
+			v := 0.
+			for _, w := range weights {
+				v += data[w.vertex] * w.amount
+			}
+			values[i] = v
+		}
+		result = result.SetFloat1Attribute(attr, values)
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package meshops
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+)
+
+// Volume computes the volume enclosed by a closed triangle mesh by summing
+// the signed volumes of the tetrahedrons formed by each triangle and the
+// origin. Meshes wound inside out have a negative volume.
+func Volume(m modeling.Mesh, attribute string) float64 {
+	check(RequireTopology(m, modeling.TriangleTopology))
+	check(RequireV3Attribute(m, attribute))
+
+	positions := m.Float3Attribute(attribute)
+	indices := m.Indices()
+	total := 0.
+	for i := 0; i < indices.Len(); i += 3 {
+		a := positions.At(indices.At(i))
+		b := positions.At(indices.At(i + 1))
+		c := positions.At(indices.At(i + 2))
+		total += a.Dot(b.Cross(c))
+	}
+	return total / 6
+}
@@ -0,0 +1,24 @@
+package meshops_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolume(t *testing.T) {
+	cube := primitives.UnitCube().Translate(vector3.New(3., -2., 1.))
+
+	assert.InDelta(t, 1., meshops.Volume(cube, modeling.PositionAttribute), 1e-9)
+	assert.InDelta(t, -1., meshops.Volume(meshops.FlipTriangleWinding(cube), modeling.PositionAttribute), 1e-9)
+}
+
+func TestVolume_RequiresTriangles(t *testing.T) {
+	assert.Panics(t, func() {
+		meshops.Volume(modeling.EmptyPointcloud(), modeling.PositionAttribute)
+	})
+}
@@ -0,0 +1,206 @@
+package modeling
+
+import (
+	"fmt"
+)
+
+// PrimitiveGroup is a set of primitives within a mesh that are all rendered
+// with the same material
+type PrimitiveGroup struct {
+	// Name of the material slot the primitives are assigned to
+	Material string
+
+	// Indices of the primitives belonging to the group, in ascending order
+	Primitives []int
+}
+
+// HasPrimitiveGroups reports whether or not the mesh's primitives have been
+// assigned to material slots. Meshes without primitive groups are treated as
+// having every primitive in a single unnamed slot.
+func (m Mesh) HasPrimitiveGroups() bool {
+	return m.primitiveMaterials != nil
+}
+
+// MaterialSlots returns the names of every material slot used by the mesh,
+// in the order of the first primitive assigned to each
+func (m Mesh) MaterialSlots() []string {
+	if m.primitiveMaterials == nil {
+		return []string{""}
+	}
+	slots := make([]string, len(m.materials))
+	copy(slots, m.materials)
+	return slots
+}
+
+// PrimitiveMaterial returns the name of the material slot the primitive is
+// assigned to
+func (m Mesh) PrimitiveMaterial(primitive int) string {
+	if m.primitiveMaterials == nil {
+		return ""
+	}
+	return m.materials[m.primitiveMaterials[primitive]]
+}
+
+// PrimitiveGroups returns the primitives assigned to each material slot, in
+// the same order as MaterialSlots
+func (m Mesh) PrimitiveGroups() []PrimitiveGroup {
+	count := m.primitiveCount()
+	if count == 0 {
+		return nil
+	}
+
+	if m.primitiveMaterials == nil {
+		primitives := make([]int, count)
+		for i := range primitives {
+			primitives[i] = i
+		}
+		return []PrimitiveGroup{{Primitives: primitives}}
+	}
+
+	groups := make([]PrimitiveGroup, len(m.materials))
+	for i, material := range m.materials {
+		groups[i].Material = material
+	}
+
+	for primitive, slot := range m.primitiveMaterials {
+		groups[slot].Primitives = append(groups[slot].Primitives, primitive)
+	}
+	return groups
+}
+
+// SetPrimitiveGroups assigns primitives to material slots. Groups sharing a
+// material name are merged, and primitives not found in any group are
+// assigned to an unnamed slot.
+func (m Mesh) SetPrimitiveGroups(groups []PrimitiveGroup) Mesh {
+	count := m.primitiveCount()
+	names := make([]string, count)
+	assigned := make([]bool, count)
+	for _, group := range groups {
+		for _, primitive := range group.Primitives {
+			if primitive < 0 || primitive >= count {
+				panic(fmt.Errorf("primitive group %q references primitive %d, but the mesh only contains %d", group.Material, primitive, count))
+			}
+
+			if assigned[primitive] {
+				panic(fmt.Errorf("primitive %d is assigned to multiple groups", primitive))
+			}
+
+			assigned[primitive] = true
+			names[primitive] = group.Material
+		}
+	}
+	return m.setPrimitiveMaterials(names)
+}
+
+// SetMaterial assigns every primitive in the mesh to a single material slot
+func (m Mesh) SetMaterial(material string) Mesh {
+	names := make([]string, m.primitiveCount())
+	for i := range names {
+		names[i] = material
+	}
+	return m.setPrimitiveMaterials(names)
+}
+
+// ClearPrimitiveGroups removes all material slot assignments from the mesh
+func (m Mesh) ClearPrimitiveGroups() Mesh {
+	m.materials = nil
+	m.primitiveMaterials = nil
+	return m
+}
+
+// CopyPrimitiveGroups assigns the mesh's primitives to the same material
+// slots as the primitives found in the source mesh. Both meshes are required
+// to have the same number of primitives.
+func (m Mesh) CopyPrimitiveGroups(src Mesh) Mesh {
+	if src.primitiveMaterials == nil {
+		return m.ClearPrimitiveGroups()
+	}
+
+	if m.primitiveCount() != len(src.primitiveMaterials) {
+		panic(fmt.Errorf("can not copy primitive groups from a mesh with %d primitives to a mesh with %d", len(src.primitiveMaterials), m.primitiveCount()))
+	}
+
+	m.materials = src.materials
+	m.primitiveMaterials = src.primitiveMaterials
+	return m
+}
+
+// RemapPrimitiveGroups assigns each of the mesh's primitives to the material
+// slot of the source mesh's primitive it originated from, where sources[i]
+// is the primitive within the source mesh that primitive i was built from.
+// Primitives with a negative source are assigned to an unnamed slot.
+func (m Mesh) RemapPrimitiveGroups(src Mesh, sources []int) Mesh {
+	if src.primitiveMaterials == nil {
+		return m.ClearPrimitiveGroups()
+	}
+
+	if m.primitiveCount() != len(sources) {
+		panic(fmt.Errorf("expected a source for each of the mesh's %d primitives, received %d", m.primitiveCount(), len(sources)))
+	}
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		if source >= 0 {
+			names[i] = src.PrimitiveMaterial(source)
+		}
+	}
+	return m.setPrimitiveMaterials(names)
+}
+
+// primitiveCount is the number of primitives the mesh contains, treating
+// empty line strips as having none
+func (m Mesh) primitiveCount() int {
+	return max(m.PrimitiveCount(), 0)
+}
+
+// primitiveMaterialNames returns the name of the material slot for every
+// primitive in the mesh
+func (m Mesh) primitiveMaterialNames() []string {
+	names := make([]string, m.primitiveCount())
+	if m.primitiveMaterials == nil {
+		return names
+	}
+
+	for i, slot := range m.primitiveMaterials {
+		names[i] = m.materials[slot]
+	}
+	return names
+}
+
+// setPrimitiveMaterials builds the material slots from the name assigned to
+// each primitive, ordering slots by their first use
+func (m Mesh) setPrimitiveMaterials(names []string) Mesh {
+	lookup := make(map[string]int)
+	materials := make([]string, 0)
+	primitiveMaterials := make([]int, len(names))
+	for i, name := range names {
+		slot, ok := lookup[name]
+		if !ok {
+			slot = len(materials)
+			lookup[name] = slot
+			materials = append(materials, name)
+		}
+		primitiveMaterials[i] = slot
+	}
+
+	m.materials = materials
+	m.primitiveMaterials = primitiveMaterials
+	return m
+}
+
+// appendPrimitiveGroups combines the primitive groups of two meshes being
+// appended together, with material slots merged by name
+func appendPrimitiveGroups(result, a, b Mesh) Mesh {
+	if a.primitiveMaterials == nil && b.primitiveMaterials == nil {
+		return result
+	}
+
+	names := append(a.primitiveMaterialNames(), b.primitiveMaterialNames()...)
+
+	// Strips and loops don't have a primitive count that's additive
+	if len(names) != result.primitiveCount() {
+		return result
+	}
+
+	return result.setPrimitiveMaterials(names)
+}
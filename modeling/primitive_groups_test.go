@@ -0,0 +1,128 @@
+package modeling_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupTestQuad() modeling.Mesh {
+	return modeling.NewTriangleMesh([]int{0, 1, 2, 0, 2, 3}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(1., 0., 0.),
+		})
+}
+
+func TestPrimitiveGroups_NoGroups(t *testing.T) {
+	m := groupTestQuad()
+
+	assert.False(t, m.HasPrimitiveGroups())
+	assert.Equal(t, []string{""}, m.MaterialSlots())
+	assert.Equal(t, "", m.PrimitiveMaterial(1))
+	assert.Equal(t, []modeling.PrimitiveGroup{{Primitives: []int{0, 1}}}, m.PrimitiveGroups())
+	assert.Nil(t, modeling.EmptyMesh(modeling.TriangleTopology).PrimitiveGroups())
+}
+
+func TestPrimitiveGroups_SetPrimitiveGroups(t *testing.T) {
+	m := groupTestQuad().
+		Append(groupTestQuad()).
+		SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "wood", Primitives: []int{3}},
+			{Material: "metal", Primitives: []int{1}},
+			{Material: "wood", Primitives: []int{0}},
+		})
+
+	assert.True(t, m.HasPrimitiveGroups())
+	assert.Equal(t, []string{"wood", "metal", ""}, m.MaterialSlots())
+	assert.Equal(t, []modeling.PrimitiveGroup{
+		{Material: "wood", Primitives: []int{0, 3}},
+		{Material: "metal", Primitives: []int{1}},
+		{Material: "", Primitives: []int{2}},
+	}, m.PrimitiveGroups())
+
+	assert.False(t, m.ClearPrimitiveGroups().HasPrimitiveGroups())
+
+	assert.Panics(t, func() {
+		groupTestQuad().SetPrimitiveGroups([]modeling.PrimitiveGroup{{Material: "wood", Primitives: []int{2}}})
+	})
+	assert.Panics(t, func() {
+		groupTestQuad().SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "wood", Primitives: []int{0}},
+			{Material: "metal", Primitives: []int{0}},
+		})
+	})
+}
+
+func TestPrimitiveGroups_Append(t *testing.T) {
+	m := groupTestQuad().SetMaterial("wood").
+		Append(groupTestQuad()).
+		Append(groupTestQuad().SetMaterial("metal")).
+		Append(groupTestQuad().SetMaterial("wood"))
+
+	assert.Equal(t, []string{"wood", "", "metal"}, m.MaterialSlots())
+	assert.Equal(t, []modeling.PrimitiveGroup{
+		{Material: "wood", Primitives: []int{0, 1, 6, 7}},
+		{Material: "", Primitives: []int{2, 3}},
+		{Material: "metal", Primitives: []int{4, 5}},
+	}, m.PrimitiveGroups())
+
+	assert.False(t, groupTestQuad().Append(groupTestQuad()).HasPrimitiveGroups())
+}
+
+func TestPrimitiveGroups_SurviveMeshOperations(t *testing.T) {
+	m := groupTestQuad().SetPrimitiveGroups([]modeling.PrimitiveGroup{
+		{Material: "wood", Primitives: []int{0}},
+		{Material: "metal", Primitives: []int{1}},
+	})
+
+	assert.Equal(t, m.PrimitiveGroups(), m.Translate(vector3.New(1., 2., 3.)).PrimitiveGroups())
+	assert.Equal(t, m.PrimitiveGroups(), m.SetFloat1Attribute("x", []float64{1, 2, 3, 4}).PrimitiveGroups())
+	assert.Equal(t, m.PrimitiveGroups(), m.SetIndices([]int{0, 2, 1, 0, 3, 2}).PrimitiveGroups())
+	assert.False(t, m.SetIndices([]int{0, 1, 2}).HasPrimitiveGroups())
+	assert.False(t, m.ToPointCloud().HasPrimitiveGroups())
+}
+
+func TestPrimitiveGroups_WeldDropsDegenerateTriangles(t *testing.T) {
+	m := modeling.NewTriangleMesh([]int{0, 1, 2, 0, 1, 3, 0, 2, 4}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(0., 1., 0.),
+			vector3.New(1., 0., 0.),
+		}).
+		SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "wood", Primitives: []int{0}},
+			{Material: "glass", Primitives: []int{1}},
+			{Material: "metal", Primitives: []int{2}},
+		})
+
+	welded := m.WeldByFloat3Attribute(modeling.PositionAttribute, 3)
+
+	assert.Equal(t, 2, welded.PrimitiveCount())
+	assert.Equal(t, []string{"wood", "metal"}, welded.MaterialSlots())
+}
+
+func TestPrimitiveGroups_Remap(t *testing.T) {
+	src := groupTestQuad().SetPrimitiveGroups([]modeling.PrimitiveGroup{
+		{Material: "wood", Primitives: []int{0}},
+		{Material: "metal", Primitives: []int{1}},
+	})
+
+	m := modeling.NewTriangleMesh([]int{0, 1, 2, 0, 2, 3, 1, 2, 3}).
+		RemapPrimitiveGroups(src, []int{1, -1, 1})
+
+	assert.Equal(t, []modeling.PrimitiveGroup{
+		{Material: "metal", Primitives: []int{0, 2}},
+		{Material: "", Primitives: []int{1}},
+	}, m.PrimitiveGroups())
+
+	assert.Panics(t, func() {
+		m.CopyPrimitiveGroups(src)
+	})
+}
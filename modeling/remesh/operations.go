@@ -0,0 +1,243 @@
+package remesh
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// split inserts a new vertex at the middle of the edge, dividing each
+// triangle on either side of it in two
+func (s *surface) split(e modeling.Edge) {
+	tris := s.edgeTriangles(e.A, e.B)
+	m := s.addVertex(s.positions[e.A].Add(s.positions[e.B]).Scale(0.5))
+
+	for _, t := range tris {
+		tri := s.triangles[t]
+		s.triangles[t] = replace(tri, e.B, m)
+		s.incident[m] = append(s.incident[m], t)
+		s.addTriangle(replace(tri, e.A, m))
+	}
+
+	if s.constrained[e] {
+		delete(s.constrained, e)
+		s.constrained[modeling.NewEdge(e.A, m)] = true
+		s.constrained[modeling.NewEdge(m, e.B)] = true
+	}
+}
+
+// collapseTarget decides which way the edge should collapse so constrained
+// edges are preserved, returning the vertex removed, the vertex kept, and
+// where the kept vertex ends up. Returns false if the edge can't collapse
+// without damaging a constraint.
+func (s *surface) collapseTarget(e modeling.Edge) (int, int, vector3.Float64, bool) {
+	ca := s.constraints(e.A)
+	cb := s.constraints(e.B)
+
+	if s.constrained[e] {
+		// Only slide along the constraint, never pulling in a corner
+		switch {
+		case ca == 2:
+			return e.A, e.B, s.positions[e.B], true
+		case cb == 2:
+			return e.B, e.A, s.positions[e.A], true
+		}
+		return 0, 0, vector3.Zero[float64](), false
+	}
+
+	switch {
+	case ca == 0 && cb == 0:
+		return e.A, e.B, s.positions[e.A].Add(s.positions[e.B]).Scale(0.5), true
+	case ca == 0:
+		return e.A, e.B, s.positions[e.B], true
+	case cb == 0:
+		return e.B, e.A, s.positions[e.A], true
+	}
+	return 0, 0, vector3.Zero[float64](), false
+}
+
+// canCollapse checks whether merging the vertex removed into the vertex
+// kept keeps the surface manifold, doesn't introduce edges longer than the
+// max length provided, and doesn't flip any triangles over
+func (s *surface) canCollapse(removed, kept int, p vector3.Float64, maxLength float64) bool {
+	shared := s.edgeTriangles(removed, kept)
+	if len(shared) == 0 {
+		return false
+	}
+
+	// Link condition, the only neighbors the two vertices can share are the
+	// ones opposite the edge
+	keptNeighbors := s.neighbors(kept)
+	common := 0
+	for _, n := range s.neighbors(removed) {
+		for _, kn := range keptNeighbors {
+			if n == kn {
+				common++
+				break
+			}
+		}
+	}
+	if common != len(shared) {
+		return false
+	}
+
+	for _, t := range shared {
+		if len(s.neighbors(opposite(s.triangles[t], modeling.NewEdge(removed, kept)))) <= 3 {
+			return false
+		}
+	}
+
+	for _, v := range [...]int{removed, kept} {
+		for _, t := range s.prune(v) {
+			tri := s.triangles[t]
+			if contains(tri, removed) && contains(tri, kept) {
+				continue
+			}
+
+			before := s.cross(tri)
+			corners := [3]vector3.Float64{}
+			for i, c := range tri {
+				corners[i] = s.positions[c]
+				if c == removed || c == kept {
+					corners[i] = p
+					continue
+				}
+
+				if corners[i].Distance(p) > maxLength {
+					return false
+				}
+			}
+
+			after := corners[1].Sub(corners[0]).Cross(corners[2].Sub(corners[0]))
+			if after.Dot(before) <= 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *surface) collapse(removed, kept int, p vector3.Float64) {
+	for _, n := range s.neighbors(removed) {
+		e := modeling.NewEdge(removed, n)
+		if !s.constrained[e] {
+			continue
+		}
+		delete(s.constrained, e)
+		if n != kept {
+			s.constrained[modeling.NewEdge(kept, n)] = true
+		}
+	}
+
+	for _, t := range s.prune(removed) {
+		tri := s.triangles[t]
+		if contains(tri, kept) {
+			s.alive[t] = false
+			continue
+		}
+		s.triangles[t] = replace(tri, removed, kept)
+		s.incident[kept] = append(s.incident[kept], t)
+	}
+
+	s.incident[removed] = nil
+	s.positions[kept] = p
+}
+
+func valenceTarget(boundary bool) int {
+	if boundary {
+		return 4
+	}
+	return 6
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// flip swaps the edge for the one connecting the two vertices opposite of
+// it, if doing so brings the valence of the four vertices involved closer to
+// ideal (6 for interior vertices, 4 for those on a constraint)
+func (s *surface) flip(e modeling.Edge) bool {
+	if s.constrained[e] {
+		return false
+	}
+
+	tris := s.edgeTriangles(e.A, e.B)
+	if len(tris) != 2 {
+		return false
+	}
+
+	// Orient things so x -> y runs along the first triangle's winding
+	t1, t2 := tris[0], tris[1]
+	x, y := e.A, e.B
+	tri1 := s.triangles[t1]
+	for i, v := range tri1 {
+		if v == e.B && tri1[(i+1)%3] == e.A {
+			x, y = e.B, e.A
+		}
+	}
+	c := opposite(tri1, e)
+	d := opposite(s.triangles[t2], e)
+	if c == d || len(s.edgeTriangles(c, d)) > 0 {
+		return false
+	}
+
+	valence := func(v int) (int, int) {
+		return len(s.neighbors(v)), valenceTarget(s.constraints(v) > 0)
+	}
+
+	deviation, flipped := 0, 0
+	for _, v := range [...]struct {
+		vertex int
+		change int
+	}{{x, -1}, {y, -1}, {c, 1}, {d, 1}} {
+		current, target := valence(v.vertex)
+		deviation += abs(current - target)
+		flipped += abs(current + v.change - target)
+	}
+	if flipped >= deviation {
+		return false
+	}
+
+	a := [3]int{c, x, d}
+	b := [3]int{d, y, c}
+	normal := s.cross(tri1).Add(s.cross(s.triangles[t2]))
+	if s.cross(a).Dot(normal) <= 0 || s.cross(b).Dot(normal) <= 0 {
+		return false
+	}
+
+	s.triangles[t1] = a
+	s.triangles[t2] = b
+	s.incident[d] = append(s.incident[d], t1)
+	s.incident[c] = append(s.incident[c], t2)
+	return true
+}
+
+// relax moves every unconstrained vertex towards the center of its
+// neighbors, only along the surface's tangent plane
+func (s *surface) relax() {
+	updated := make([]vector3.Float64, len(s.positions))
+	copy(updated, s.positions)
+
+	for v, p := range s.positions {
+		neighbors := s.neighbors(v)
+		if len(neighbors) == 0 || s.constraints(v) > 0 {
+			continue
+		}
+
+		center := vector3.Zero[float64]()
+		for _, n := range neighbors {
+			center = center.Add(s.positions[n])
+		}
+		center = center.DivByConstant(float64(len(neighbors)))
+
+		normal := s.vertexNormal(v)
+		offset := center.Sub(p)
+		updated[v] = p.Add(offset.Sub(normal.Scale(normal.Dot(offset))))
+	}
+
+	s.positions = updated
+}
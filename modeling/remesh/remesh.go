@@ -0,0 +1,282 @@
+package remesh
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/trees"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+const DefaultIterations = 5
+
+type Parameters struct {
+	// Edge length the remesher works towards. Edges longer than 4/3 of this
+	// are split, and edges shorter than 4/5 of it are collapsed. Defaults to
+	// the mesh's average edge length when 0 or less.
+	TargetEdgeLength float64
+
+	// Number of split, collapse, flip, and relaxation passes to run.
+	// Defaults to DefaultIterations when 0 or less.
+	Iterations int
+
+	// Edges where the faces on either side meet at an angle greater than
+	// this (in radians) are treated as sharp features, and are preserved
+	// just like boundaries are. Zero or less disables feature detection.
+	FeatureAngle float64
+}
+
+func averageEdgeLength(s *surface) float64 {
+	edges := s.edges()
+	if len(edges) == 0 {
+		return 0
+	}
+
+	total := 0.
+	for _, e := range edges {
+		total += s.positions[e.A].Distance(s.positions[e.B])
+	}
+	return total / float64(len(edges))
+}
+
+// Isotropic remeshes the surface so its triangles are as close to
+// equilateral, with edges of the target length, as possible. Each iteration
+// splits long edges, collapses short ones, flips edges to even out vertex
+// valence, and relaxes vertices along the surface before projecting them
+// back onto the original mesh.
+//
+// Vertices that share the same position are welded together. Boundaries,
+// non-manifold edges, and sharp features are preserved. All attributes are
+// resampled from the closest point on the original mesh, meaning
+// discontinuities like UV seams are not kept.
+//
+// Implementation follows "A Remeshing Approach to Multiresolution Modeling"
+// by Botsch and Kobbelt.
+func Isotropic(m modeling.Mesh, params Parameters) (modeling.Mesh, error) {
+	if m.Topology() != modeling.TriangleTopology {
+		return m, fmt.Errorf("remeshing requires a triangle topology, received: %s", m.Topology().String())
+	}
+
+	if m.PrimitiveCount() == 0 {
+		return m, nil
+	}
+
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return m, fmt.Errorf("remeshing requires the mesh to have the vector3 attribute: '%s'", modeling.PositionAttribute)
+	}
+
+	s := newSurface(m, params.FeatureAngle)
+
+	target := params.TargetEdgeLength
+	if target <= 0 {
+		target = averageEdgeLength(s)
+	}
+	if target <= 0 {
+		return m, nil
+	}
+
+	iterations := params.Iterations
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+
+	tree := m.OctTree()
+	high := target * 4 / 3
+	low := target * 4 / 5
+
+	for range iterations {
+		for _, e := range s.edges() {
+			if s.positions[e.A].Distance(s.positions[e.B]) > high && len(s.edgeTriangles(e.A, e.B)) > 0 {
+				s.split(e)
+			}
+		}
+
+		for _, e := range s.edges() {
+			if s.positions[e.A].Distance(s.positions[e.B]) >= low || len(s.edgeTriangles(e.A, e.B)) == 0 {
+				continue
+			}
+
+			removed, kept, p, ok := s.collapseTarget(e)
+			if ok && s.canCollapse(removed, kept, p, high) {
+				s.collapse(removed, kept, p)
+			}
+		}
+
+		for _, e := range s.edges() {
+			s.flip(e)
+		}
+
+		s.relax()
+		for v, p := range s.positions {
+			if len(s.incident[v]) == 0 {
+				continue
+			}
+			_, s.positions[v] = tree.ClosestPoint(p)
+		}
+	}
+
+	return s.toMesh(m, tree), nil
+}
+
+// barycentric coordinates of the point within the triangle, clamped so
+// they never extrapolate outside of it
+func barycentric(p, a, b, c vector3.Float64) vector3.Float64 {
+	v0 := b.Sub(a)
+	v1 := c.Sub(a)
+	v2 := p.Sub(a)
+	d00 := v0.Dot(v0)
+	d01 := v0.Dot(v1)
+	d11 := v1.Dot(v1)
+	d20 := v2.Dot(v0)
+	d21 := v2.Dot(v1)
+	denominator := d00*d11 - d01*d01
+	if denominator == 0 {
+		return vector3.New(1., 0., 0.)
+	}
+
+	v := math.Max(0, (d11*d20-d01*d21)/denominator)
+	w := math.Max(0, (d00*d21-d01*d20)/denominator)
+	u := math.Max(0, 1-v-w)
+	total := u + v + w
+	return vector3.New(u/total, v/total, w/total)
+}
+
+// toMesh builds the final mesh out of the surface's living triangles,
+// resampling every attribute from the original mesh
+func (s *surface) toMesh(original modeling.Mesh, tree *trees.OctTree) modeling.Mesh {
+	remap := make(map[int]int)
+	indices := make([]int, 0)
+	sources := make([]int, 0)
+	for t, tri := range s.triangles {
+		if !s.alive[t] {
+			continue
+		}
+
+		for _, v := range tri {
+			index, ok := remap[v]
+			if !ok {
+				index = len(sources)
+				remap[v] = index
+				sources = append(sources, v)
+			}
+			indices = append(indices, index)
+		}
+	}
+
+	originalIndices := original.Indices()
+	originalPositions := original.Float3Attribute(modeling.PositionAttribute)
+
+	type sample struct {
+		corners [3]int
+		weights vector3.Float64
+	}
+	samples := make([]sample, len(sources))
+	positions := make([]vector3.Float64, len(sources))
+	for i, v := range sources {
+		p := s.positions[v]
+		positions[i] = p
+
+		primitive, _ := tree.ClosestPoint(p)
+		corners := [3]int{
+			originalIndices.At(primitive * 3),
+			originalIndices.At(primitive*3 + 1),
+			originalIndices.At(primitive*3 + 2),
+		}
+		samples[i] = sample{
+			corners: corners,
+			weights: barycentric(
+				p,
+				originalPositions.At(corners[0]),
+				originalPositions.At(corners[1]),
+				originalPositions.At(corners[2]),
+			),
+		}
+	}
+
+	// Integer attributes can't be blended, so vertices take them from the
+	// corner of the original triangle they're closest to
+	nearest := make([]int, len(samples))
+	for i, s := range samples {
+		nearest[i] = s.corners[0]
+		if s.weights.Y() > s.weights.X() && s.weights.Y() >= s.weights.Z() {
+			nearest[i] = s.corners[1]
+		} else if s.weights.Z() > s.weights.X() && s.weights.Z() > s.weights.Y() {
+			nearest[i] = s.corners[2]
+		}
+	}
+
+	result := modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		RemapIntAttributes(original, nearest)
+
+	// Triangles take on the primitive group of the original triangle
+	// closest to their center
+	if original.HasPrimitiveGroups() {
+		primitives := make([]int, len(indices)/3)
+		for t := range primitives {
+			center := positions[indices[t*3]].
+				Add(positions[indices[t*3+1]]).
+				Add(positions[indices[t*3+2]]).
+				DivByConstant(3)
+			primitives[t], _ = tree.ClosestPoint(center)
+		}
+		result = result.RemapPrimitiveGroups(original, primitives)
+	}
+
+	for _, attr := range original.Float4Attributes() {
+		data := original.Float4Attribute(attr)
+		values := make([]vector4.Float64, len(samples))
+		for i, s := range samples {
+			values[i] = data.At(s.corners[0]).Scale(s.weights.X()).
+				Add(data.At(s.corners[1]).Scale(s.weights.Y())).
+				Add(data.At(s.corners[2]).Scale(s.weights.Z()))
+		}
+		result = result.SetFloat4Attribute(attr, values)
+	}
+
+	for _, attr := range original.Float3Attributes() {
+		if attr == modeling.PositionAttribute {
+			continue
+		}
+
+		data := original.Float3Attribute(attr)
+		values := make([]vector3.Float64, len(samples))
+		for i, s := range samples {
+			values[i] = data.At(s.corners[0]).Scale(s.weights.X()).
+				Add(data.At(s.corners[1]).Scale(s.weights.Y())).
+				Add(data.At(s.corners[2]).Scale(s.weights.Z()))
+
+			if attr == modeling.NormalAttribute && values[i].Length() > 0 {
+				values[i] = values[i].Normalized()
+			}
+		}
+		result = result.SetFloat3Attribute(attr, values)
+	}
+
+	for _, attr := range original.Float2Attributes() {
+		data := original.Float2Attribute(attr)
+		values := make([]vector2.Float64, len(samples))
+		for i, s := range samples {
+			values[i] = data.At(s.corners[0]).Scale(s.weights.X()).
+				Add(data.At(s.corners[1]).Scale(s.weights.Y())).
+				Add(data.At(s.corners[2]).Scale(s.weights.Z()))
+		}
+		result = result.SetFloat2Attribute(attr, values)
+	}
+
+	for _, attr := range original.Float1Attributes() {
+		data := original.Float1Attribute(attr)
+		values := make([]float64, len(samples))
+		for i, s := range samples {
+			values[i] = data.At(s.corners[0])*s.weights.X() +
+				data.At(s.corners[1])*s.weights.Y() +
+				data.At(s.corners[2])*s.weights.Z()
+		}
+		result = result.SetFloat1Attribute(attr, values)
+	}
+
+	return result
+}
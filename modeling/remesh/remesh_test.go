@@ -0,0 +1,137 @@
+package remesh_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/modeling/remesh"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func edgeLengths(m modeling.Mesh) []float64 {
+	lengths := make([]float64, 0)
+	m.ScanPrimitives(func(i int, p modeling.Primitive) {
+		tri := p.(modeling.Tri)
+		a := tri.P1Vec3Attr(modeling.PositionAttribute)
+		b := tri.P2Vec3Attr(modeling.PositionAttribute)
+		c := tri.P3Vec3Attr(modeling.PositionAttribute)
+		lengths = append(lengths, a.Distance(b), b.Distance(c), c.Distance(a))
+	})
+	return lengths
+}
+
+func TestIsotropic_Sphere(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 24, 24)
+	target := 0.2
+
+	// ACT ====================================================================
+	remeshed, err := remesh.Isotropic(sphere, remesh.Parameters{
+		TargetEdgeLength: target,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Greater(t, remeshed.PrimitiveCount(), 0)
+
+	lengths := edgeLengths(remeshed)
+	mean := 0.
+	for _, l := range lengths {
+		mean += l
+	}
+	mean /= float64(len(lengths))
+	assert.InDelta(t, target, mean, target*0.2)
+
+	for _, l := range lengths {
+		assert.Less(t, l, target*2)
+		assert.Greater(t, l, target*0.25)
+	}
+
+	// Vertices are projected back onto the original surface, which sits
+	// just inside of the unit sphere
+	remeshed.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 1., v.Length(), 0.02)
+	})
+
+	expected := meshops.Volume(sphere, modeling.PositionAttribute)
+	assert.InDelta(t, expected, meshops.Volume(remeshed, modeling.PositionAttribute), expected*0.025)
+
+	require.True(t, remeshed.HasFloat3Attribute(modeling.NormalAttribute))
+	remeshed.ScanFloat3Attribute(modeling.NormalAttribute, func(i int, n vector3.Float64) {
+		assert.InDelta(t, 1., n.Length(), 1e-6)
+	})
+}
+
+func TestIsotropic_Boundary(t *testing.T) {
+	// ARRANGE ================================================================
+	size := 4
+	positions := make([]vector3.Float64, 0)
+	for z := 0; z <= size; z++ {
+		for x := 0; x <= size; x++ {
+			positions = append(positions, vector3.New(float64(x)/float64(size), 0, float64(z)/float64(size)))
+		}
+	}
+
+	indices := make([]int, 0)
+	for z := 0; z < size; z++ {
+		for x := 0; x < size; x++ {
+			bl := z*(size+1) + x
+			tl := bl + size + 1
+			indices = append(indices, bl, tl, tl+1, bl, tl+1, bl+1)
+		}
+	}
+	plane := modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
+
+	// ACT ====================================================================
+	remeshed, err := remesh.Isotropic(plane, remesh.Parameters{
+		TargetEdgeLength: 0.1,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Greater(t, remeshed.PrimitiveCount(), plane.PrimitiveCount())
+
+	area := 0.
+	remeshed.ScanPrimitives(func(i int, p modeling.Primitive) {
+		tri := p.(modeling.Tri)
+		area += tri.Area3D(modeling.PositionAttribute)
+
+		// Winding is kept consistent with the original plane
+		a := tri.P1Vec3Attr(modeling.PositionAttribute)
+		n := tri.P2Vec3Attr(modeling.PositionAttribute).Sub(a).Cross(tri.P3Vec3Attr(modeling.PositionAttribute).Sub(a))
+		assert.Greater(t, n.Y(), 0.)
+	})
+	// The plane keeps its corners, as anything cut off would take area with it
+	assert.InDelta(t, 1., area, 1e-9)
+
+	remeshed.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 0., v.Y(), 1e-9)
+	})
+}
+
+func TestIsotropic_Features(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := primitives.UnitCube()
+
+	// ACT ====================================================================
+	remeshed, err := remesh.Isotropic(cube, remesh.Parameters{
+		TargetEdgeLength: 0.15,
+		FeatureAngle:     math.Pi / 4,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Greater(t, remeshed.PrimitiveCount(), cube.PrimitiveCount())
+	assert.InDelta(t, 1., meshops.Volume(remeshed, modeling.PositionAttribute), 1e-6)
+}
+
+func TestIsotropic_RequiresTriangles(t *testing.T) {
+	_, err := remesh.Isotropic(modeling.EmptyPointcloud(), remesh.Parameters{})
+	assert.EqualError(t, err, "remeshing requires a triangle topology, received: point")
+}
@@ -0,0 +1,218 @@
+package remesh
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// surface is a mutable triangle mesh that keeps track of which triangles
+// each vertex belongs to, so that local edits (splits, collapses, and
+// flips) can be made in place
+type surface struct {
+	positions []vector3.Float64
+	triangles [][3]int
+	alive     []bool
+
+	// triangles each vertex belongs to, including dead ones that haven't
+	// been pruned yet
+	incident [][]int
+
+	// edges that must be preserved: boundaries, non-manifold edges, and
+	// sharp features
+	constrained map[modeling.Edge]bool
+}
+
+// newSurface builds a surface from the mesh, welding vertices that share
+// the exact same position and dropping degenerate triangles
+func newSurface(m modeling.Mesh, featureAngle float64) *surface {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	s := &surface{
+		positions:   make([]vector3.Float64, positions.Len()),
+		triangles:   make([][3]int, 0, m.PrimitiveCount()),
+		incident:    make([][]int, positions.Len()),
+		constrained: make(map[modeling.Edge]bool),
+	}
+
+	for i := range s.positions {
+		s.positions[i] = positions.At(i)
+	}
+	welded := modeling.WeldPositions(s.positions)
+
+	indices := m.Indices()
+	for i := 0; i < indices.Len(); i += 3 {
+		tri := [3]int{welded[indices.At(i)], welded[indices.At(i+1)], welded[indices.At(i+2)]}
+		if tri[0] == tri[1] || tri[1] == tri[2] || tri[2] == tri[0] {
+			continue
+		}
+		s.addTriangle(tri)
+	}
+
+	edgeTriangles := make(map[modeling.Edge][]int)
+	for t, tri := range s.triangles {
+		for i := range 3 {
+			e := modeling.NewEdge(tri[i], tri[(i+1)%3])
+			edgeTriangles[e] = append(edgeTriangles[e], t)
+		}
+	}
+
+	minDot := -2.
+	if featureAngle > 0 {
+		minDot = math.Cos(featureAngle)
+	}
+
+	for e, tris := range edgeTriangles {
+		if len(tris) != 2 {
+			s.constrained[e] = true
+			continue
+		}
+
+		if s.normal(tris[0]).Dot(s.normal(tris[1])) < minDot {
+			s.constrained[e] = true
+		}
+	}
+
+	return s
+}
+
+func (s *surface) addTriangle(tri [3]int) int {
+	t := len(s.triangles)
+	s.triangles = append(s.triangles, tri)
+	s.alive = append(s.alive, true)
+	for _, v := range tri {
+		s.incident[v] = append(s.incident[v], t)
+	}
+	return t
+}
+
+func (s *surface) addVertex(p vector3.Float64) int {
+	s.positions = append(s.positions, p)
+	s.incident = append(s.incident, nil)
+	return len(s.positions) - 1
+}
+
+// prune drops dead triangles from the vertex's incident list
+func (s *surface) prune(v int) []int {
+	tris := s.incident[v][:0]
+	for _, t := range s.incident[v] {
+		if s.alive[t] && contains(s.triangles[t], v) {
+			tris = append(tris, t)
+		}
+	}
+	s.incident[v] = tris
+	return tris
+}
+
+func contains(tri [3]int, v int) bool {
+	return tri[0] == v || tri[1] == v || tri[2] == v
+}
+
+func (s *surface) cross(tri [3]int) vector3.Float64 {
+	p0 := s.positions[tri[0]]
+	return s.positions[tri[1]].Sub(p0).Cross(s.positions[tri[2]].Sub(p0))
+}
+
+func (s *surface) normal(t int) vector3.Float64 {
+	n := s.cross(s.triangles[t])
+	if n.Length() == 0 {
+		return n
+	}
+	return n.Normalized()
+}
+
+// edgeTriangles returns every triangle containing both vertices
+func (s *surface) edgeTriangles(a, b int) []int {
+	tris := make([]int, 0, 2)
+	for _, t := range s.prune(a) {
+		if contains(s.triangles[t], b) {
+			tris = append(tris, t)
+		}
+	}
+	return tris
+}
+
+func (s *surface) neighbors(v int) []int {
+	out := make([]int, 0, 8)
+	for _, t := range s.prune(v) {
+		for _, n := range s.triangles[t] {
+			if n == v {
+				continue
+			}
+
+			found := false
+			for _, existing := range out {
+				if existing == n {
+					found = true
+					break
+				}
+			}
+			if !found {
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// constraints counts the constrained edges running through the vertex
+func (s *surface) constraints(v int) int {
+	count := 0
+	for _, n := range s.neighbors(v) {
+		if s.constrained[modeling.NewEdge(v, n)] {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *surface) vertexNormal(v int) vector3.Float64 {
+	n := vector3.Zero[float64]()
+	for _, t := range s.prune(v) {
+		n = n.Add(s.cross(s.triangles[t]))
+	}
+	if n.Length() == 0 {
+		return n
+	}
+	return n.Normalized()
+}
+
+func (s *surface) edges() []modeling.Edge {
+	seen := make(map[modeling.Edge]struct{})
+	out := make([]modeling.Edge, 0, len(s.triangles)*3/2)
+	for t, tri := range s.triangles {
+		if !s.alive[t] {
+			continue
+		}
+
+		for i := range 3 {
+			e := modeling.NewEdge(tri[i], tri[(i+1)%3])
+			if _, ok := seen[e]; ok {
+				continue
+			}
+			seen[e] = struct{}{}
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// replace swaps the vertex from with to within the triangle
+func replace(tri [3]int, from, to int) [3]int {
+	for i, v := range tri {
+		if v == from {
+			tri[i] = to
+		}
+	}
+	return tri
+}
+
+// opposite returns the vertex of the triangle not on the edge
+func opposite(tri [3]int, e modeling.Edge) int {
+	for _, v := range tri {
+		if v != e.A && v != e.B {
+			return v
+		}
+	}
+	return -1
+}
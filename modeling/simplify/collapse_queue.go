@@ -0,0 +1,32 @@
+package simplify
+
+type collapseItem struct {
+	a, b               int
+	versionA, versionB int
+	cost               float64
+}
+
+type collapseQueue []collapseItem
+
+func (pq collapseQueue) Len() int { return len(pq) }
+
+func (pq collapseQueue) Less(i, j int) bool {
+	return pq[i].cost < pq[j].cost
+}
+
+func (pq collapseQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+}
+
+func (pq *collapseQueue) Push(x any) {
+	item := x.(collapseItem)
+	*pq = append(*pq, item)
+}
+
+func (pq *collapseQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[0 : n-1]
+	return item
+}
@@ -1,9 +1,15 @@
 package simplify
 
 import (
+	"container/heap"
+	"fmt"
+	"math"
+
 	"github.com/EliCDavis/polyform/math/mat"
 	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
 	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
 )
 
 func QuadricVector(a mat.Matrix4x4) vector3.Float64 {
@@ -23,6 +29,760 @@ func QuadricErrorForVector(a mat.Matrix4x4, v vector3.Float64) float64 {
 		v.X()*a.X03 + v.Y()*a.X13 + v.Z()*a.X23 + a.X33)
 }
 
-func QuadricDecimation(m modeling.Mesh) modeling.Mesh {
-	return m
+// PlaneQuadric builds the fundamental error quadric for the plane
+// ax + by + cz + d = 0, where (a, b, c) is the plane's unit normal
+func PlaneQuadric(normal vector3.Float64, d float64) mat.Matrix4x4 {
+	a, b, c := normal.X(), normal.Y(), normal.Z()
+	return mat.Matrix4x4{
+		X00: a * a, X01: a * b, X02: a * c, X03: a * d,
+		X10: b * a, X11: b * b, X12: b * c, X13: b * d,
+		X20: c * a, X21: c * b, X22: c * c, X23: c * d,
+		X30: d * a, X31: d * b, X32: d * c, X33: d * d,
+	}
+}
+
+func scaleQuadric(q mat.Matrix4x4, s float64) mat.Matrix4x4 {
+	return mat.Matrix4x4{
+		X00: q.X00 * s, X01: q.X01 * s, X02: q.X02 * s, X03: q.X03 * s,
+		X10: q.X10 * s, X11: q.X11 * s, X12: q.X12 * s, X13: q.X13 * s,
+		X20: q.X20 * s, X21: q.X21 * s, X22: q.X22 * s, X23: q.X23 * s,
+		X30: q.X30 * s, X31: q.X31 * s, X32: q.X32 * s, X33: q.X33 * s,
+	}
+}
+
+type QuadricDecimationParameters struct {
+	// Stop collapsing edges once the mesh contains this many triangles or
+	// fewer. A value of 0 or less is ignored.
+	TargetTriangleCount int
+
+	// Stop collapsing edges once the cheapest collapse available would
+	// introduce more than this much quadric error (roughly the sum of squared
+	// distances to the original surface). A value of 0 or less is ignored.
+	MaxError float64
+
+	// Weight applied to the constraint planes placed along open boundaries.
+	// Higher values make boundaries hold their shape more rigidly. Defaults
+	// to 1000 when left at 0.
+	BoundaryWeight float64
+}
+
+type vertexKind int
+
+const (
+	// Vertex whose one-ring is a closed disk, free to move anywhere
+	interiorVertex vertexKind = iota
+
+	// Vertex on an open boundary, may only slide along the boundary
+	boundaryVertex
+
+	// Vertex on an attribute seam, or in non-manifold configuration, that
+	// is never removed
+	lockedVertex
+)
+
+type decimator struct {
+	positions []vector3.Float64
+	v1Data    map[string][]float64
+	v2Data    map[string][]vector2.Float64
+	v3Data    map[string][]vector3.Float64
+	v4Data    map[string][]vector4.Float64
+
+	faces        [][3]int
+	faceSources  []int // Primitive within the original mesh each face came from
+	faceRemoved  []bool
+	liveFaces    int
+	vertexFaces  [][]int
+	vertexGone   []bool
+	vertexKinds  []vertexKind
+	vertexGroups []int
+	versions     []int
+	quadrics     []mat.Matrix4x4
+}
+
+// QuadricDecimation reduces the triangle count of the mesh by repeatedly
+// collapsing the edge that introduces the least amount of quadric error, as
+// described by Garland and Heckbert in "Surface Simplification Using Quadric
+// Error Metrics".
+//
+// Open boundaries are preserved by only allowing their vertices to slide along
+// the boundary itself. Vertices that share a position and every other
+// attribute with another vertex are welded together before simplifying, while
+// those that differ (UV seams, hard normals, etc) are never removed, keeping
+// seams intact. All
+// float1 through float4 attributes are interpolated along each collapsed edge.
+func QuadricDecimation(m modeling.Mesh, params QuadricDecimationParameters) modeling.Mesh {
+	if m.Topology() != modeling.TriangleTopology {
+		panic(fmt.Errorf("quadric decimation requires a triangle topology, recieved: %s", m.Topology().String()))
+	}
+
+	if !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		panic(fmt.Errorf("quadric decimation requires the mesh to have the vector3 attribute: '%s'", modeling.PositionAttribute))
+	}
+
+	if params.TargetTriangleCount <= 0 && params.MaxError <= 0 {
+		return m
+	}
+
+	if params.TargetTriangleCount > 0 && m.PrimitiveCount() <= params.TargetTriangleCount {
+		return m
+	}
+
+	d := newDecimator(m, params)
+	d.run(params)
+	return d.mesh(m).RemapPrimitiveGroups(m, d.liveFaceSources())
+}
+
+func copyAttributeData[T any](attributes []string, get func(string) []T) map[string][]T {
+	out := make(map[string][]T, len(attributes))
+	for _, attr := range attributes {
+		data := get(attr)
+		cpy := make([]T, len(data))
+		copy(cpy, data)
+		out[attr] = cpy
+	}
+	return out
+}
+
+func readAttribute[T any](length func() int, at func(int) T) []T {
+	data := make([]T, length())
+	for i := range data {
+		data[i] = at(i)
+	}
+	return data
+}
+
+func newDecimator(m modeling.Mesh, params QuadricDecimationParameters) *decimator {
+	vertexCount := m.AttributeLength()
+
+	d := &decimator{
+		v1Data: copyAttributeData(m.Float1Attributes(), func(s string) []float64 {
+			it := m.Float1Attribute(s)
+			return readAttribute(it.Len, it.At)
+		}),
+		v2Data: copyAttributeData(m.Float2Attributes(), func(s string) []vector2.Float64 {
+			it := m.Float2Attribute(s)
+			return readAttribute(it.Len, it.At)
+		}),
+		v3Data: copyAttributeData(m.Float3Attributes(), func(s string) []vector3.Float64 {
+			it := m.Float3Attribute(s)
+			return readAttribute(it.Len, it.At)
+		}),
+		v4Data: copyAttributeData(m.Float4Attributes(), func(s string) []vector4.Float64 {
+			it := m.Float4Attribute(s)
+			return readAttribute(it.Len, it.At)
+		}),
+		vertexFaces:  make([][]int, vertexCount),
+		vertexGone:   make([]bool, vertexCount),
+		vertexKinds:  make([]vertexKind, vertexCount),
+		vertexGroups: make([]int, vertexCount),
+		versions:     make([]int, vertexCount),
+	}
+	d.positions = d.v3Data[modeling.PositionAttribute]
+
+	// Vertices that share an exact position belong to the same group.
+	// Vertices within a group whose attributes all match are welded together,
+	// so groups left with more than one distinct vertex indicate an attribute
+	// seam.
+	groupLookup := make(map[vector3.Float64]int)
+	groupVertices := make([][]int, 0)
+	welded := make([]int, vertexCount)
+	identical := d.vertexMatcher(m)
+	for i, p := range d.positions {
+		group, ok := groupLookup[p]
+		if !ok {
+			group = len(groupVertices)
+			groupLookup[p] = group
+			groupVertices = append(groupVertices, nil)
+		}
+		d.vertexGroups[i] = group
+
+		welded[i] = i
+		for _, other := range groupVertices[group] {
+			if identical(i, other) {
+				welded[i] = other
+				break
+			}
+		}
+
+		if welded[i] == i {
+			groupVertices[group] = append(groupVertices[group], i)
+		} else {
+			d.vertexGone[i] = true
+		}
+	}
+	d.quadrics = make([]mat.Matrix4x4, len(groupVertices))
+
+	indices := m.Indices()
+	indexEdges := make(map[modeling.Edge]int)
+	groupEdges := make(map[modeling.Edge]int)
+	for i := 0; i < indices.Len(); i += 3 {
+		face := [3]int{welded[indices.At(i)], welded[indices.At(i+1)], welded[indices.At(i+2)]}
+		if face[0] == face[1] || face[1] == face[2] || face[0] == face[2] {
+			continue
+		}
+
+		faceIndex := len(d.faces)
+		d.faces = append(d.faces, face)
+		d.faceSources = append(d.faceSources, i/3)
+		for j := 0; j < 3; j++ {
+			a, b := face[j], face[(j+1)%3]
+			d.vertexFaces[a] = append(d.vertexFaces[a], faceIndex)
+			indexEdges[modeling.NewEdge(a, b)]++
+			groupEdges[modeling.NewEdge(d.vertexGroups[a], d.vertexGroups[b])]++
+		}
+
+		normal, planeD, ok := d.facePlane(face)
+		if !ok {
+			continue
+		}
+
+		q := PlaneQuadric(normal, planeD)
+		for _, v := range face {
+			g := d.vertexGroups[v]
+			d.quadrics[g] = d.quadrics[g].Add(q)
+		}
+	}
+	d.faceRemoved = make([]bool, len(d.faces))
+	d.liveFaces = len(d.faces)
+
+	boundaryWeight := params.BoundaryWeight
+	if boundaryWeight <= 0 {
+		boundaryWeight = 1000
+	}
+
+	boundaryEdgeCount := make([]int, vertexCount)
+	for i, face := range d.faces {
+		for j := 0; j < 3; j++ {
+			a, b := face[j], face[(j+1)%3]
+			count := indexEdges[modeling.NewEdge(a, b)]
+
+			if count > 2 {
+				d.vertexKinds[a] = lockedVertex
+				d.vertexKinds[b] = lockedVertex
+				continue
+			}
+
+			if count != 1 {
+				continue
+			}
+
+			// An edge only referenced once by index, but shared by multiple
+			// faces once positions are taken into account, is a seam.
+			if groupEdges[modeling.NewEdge(d.vertexGroups[a], d.vertexGroups[b])] != 1 {
+				d.vertexKinds[a] = lockedVertex
+				d.vertexKinds[b] = lockedVertex
+				continue
+			}
+
+			boundaryEdgeCount[a]++
+			boundaryEdgeCount[b]++
+			if d.vertexKinds[a] == interiorVertex {
+				d.vertexKinds[a] = boundaryVertex
+			}
+			if d.vertexKinds[b] == interiorVertex {
+				d.vertexKinds[b] = boundaryVertex
+			}
+
+			// Constrain the boundary with a plane perpendicular to the face
+			// running through the boundary edge.
+			faceNormal, _, ok := d.facePlane(d.faces[i])
+			if !ok {
+				continue
+			}
+			edge := d.positions[b].Sub(d.positions[a])
+			constraintNormal := edge.Cross(faceNormal)
+			if constraintNormal.Length() == 0 {
+				continue
+			}
+			constraintNormal = constraintNormal.Normalized()
+			q := scaleQuadric(
+				PlaneQuadric(constraintNormal, -constraintNormal.Dot(d.positions[a])),
+				boundaryWeight,
+			)
+			d.quadrics[d.vertexGroups[a]] = d.quadrics[d.vertexGroups[a]].Add(q)
+			d.quadrics[d.vertexGroups[b]] = d.quadrics[d.vertexGroups[b]].Add(q)
+		}
+	}
+
+	for v := range d.vertexKinds {
+		if len(groupVertices[d.vertexGroups[v]]) > 1 {
+			d.vertexKinds[v] = lockedVertex
+		}
+
+		// More than two boundary edges meeting at a single vertex is a
+		// non-manifold "bowtie" that we don't attempt to simplify
+		if boundaryEdgeCount[v] > 2 {
+			d.vertexKinds[v] = lockedVertex
+		}
+	}
+
+	return d
+}
+
+func attributesMatch[T comparable](data map[string][]T, a, b int) bool {
+	for _, values := range data {
+		if values[a] != values[b] {
+			return false
+		}
+	}
+	return true
+}
+
+func intAttributesMatch[T comparable](attributes []string, get func(string) []T) func(a, b int) bool {
+	data := make(map[string][]T, len(attributes))
+	for _, attr := range attributes {
+		data[attr] = get(attr)
+	}
+	return func(a, b int) bool {
+		return attributesMatch(data, a, b)
+	}
+}
+
+// vertexMatcher builds a check for whether every attribute of two vertices,
+// float and int alike, holds exactly the same value
+func (d decimator) vertexMatcher(m modeling.Mesh) func(a, b int) bool {
+	int1 := intAttributesMatch(m.Int1Attributes(), func(s string) []int {
+		it := m.Int1Attribute(s)
+		return readAttribute(it.Len, it.At)
+	})
+	int2 := intAttributesMatch(m.Int2Attributes(), func(s string) []vector2.Int {
+		it := m.Int2Attribute(s)
+		return readAttribute(it.Len, it.At)
+	})
+	int3 := intAttributesMatch(m.Int3Attributes(), func(s string) []vector3.Int {
+		it := m.Int3Attribute(s)
+		return readAttribute(it.Len, it.At)
+	})
+	int4 := intAttributesMatch(m.Int4Attributes(), func(s string) []vector4.Int {
+		it := m.Int4Attribute(s)
+		return readAttribute(it.Len, it.At)
+	})
+
+	return func(a, b int) bool {
+		return attributesMatch(d.v1Data, a, b) &&
+			attributesMatch(d.v2Data, a, b) &&
+			attributesMatch(d.v3Data, a, b) &&
+			attributesMatch(d.v4Data, a, b) &&
+			int1(a, b) && int2(a, b) && int3(a, b) && int4(a, b)
+	}
+}
+
+func (d decimator) facePlane(face [3]int) (vector3.Float64, float64, bool) {
+	p1 := d.positions[face[0]]
+	p2 := d.positions[face[1]]
+	p3 := d.positions[face[2]]
+	normal := p2.Sub(p1).Cross(p3.Sub(p1))
+	length := normal.Length()
+	if length == 0 || math.IsNaN(length) {
+		return vector3.Zero[float64](), 0, false
+	}
+	normal = normal.DivByConstant(length)
+	return normal, -normal.Dot(p1), true
+}
+
+// collapse describes merging the vertex "remove" into the vertex "keep", with
+// "keep" taking on the new position and attributes interpolated at "t" along
+// the edge from keep to remove
+type collapse struct {
+	keep, remove int
+	position     vector3.Float64
+	t            float64
+	interpolate  bool
+	cost         float64
+}
+
+func (d decimator) bestPosition(q mat.Matrix4x4, a, b int) (vector3.Float64, float64) {
+	pa := d.positions[a]
+	pb := d.positions[b]
+
+	// Only attempt to solve for the optimal position if the quadric is
+	// invertable
+	solvable := mat.Matrix4x4{
+		X00: q.X00, X01: q.X01, X02: q.X02, X03: q.X03,
+		X10: q.X10, X11: q.X11, X12: q.X12, X13: q.X13,
+		X20: q.X20, X21: q.X21, X22: q.X22, X23: q.X23,
+		X30: 0, X31: 0, X32: 0, X33: 1,
+	}
+	if math.Abs(solvable.Determinant()) > 1e-12 {
+		optimal := QuadricVector(q)
+		if !optimal.ContainsNaN() {
+			// Keep the vertex near the edge so attribute interpolation makes
+			// sense
+			dir := pb.Sub(pa)
+			lenSq := dir.Dot(dir)
+			if lenSq > 0 {
+				t := optimal.Sub(pa).Dot(dir) / lenSq
+				if t >= 0 && t <= 1 {
+					return optimal, t
+				}
+			}
+		}
+	}
+
+	candidates := []float64{0, 0.5, 1}
+	bestT := 0.
+	bestCost := math.Inf(1)
+	for _, t := range candidates {
+		p := pa.Add(pb.Sub(pa).Scale(t))
+		cost := QuadricErrorForVector(q, p)
+		if cost < bestCost {
+			bestCost = cost
+			bestT = t
+		}
+	}
+	return pa.Add(pb.Sub(pa).Scale(bestT)), bestT
+}
+
+func (d decimator) edgeFaceCount(a, b int) int {
+	count := 0
+	for _, f := range d.vertexFaces[a] {
+		if d.faceRemoved[f] {
+			continue
+		}
+		face := d.faces[f]
+		if face[0] == b || face[1] == b || face[2] == b {
+			count++
+		}
+	}
+	return count
+}
+
+func (d decimator) neighbors(v int) map[int]struct{} {
+	out := make(map[int]struct{})
+	for _, f := range d.vertexFaces[v] {
+		if d.faceRemoved[f] {
+			continue
+		}
+		for _, n := range d.faces[f] {
+			if n != v {
+				out[n] = struct{}{}
+			}
+		}
+	}
+	return out
+}
+
+// candidate determines the cheapest legal collapse for the edge between a
+// and b
+func (d decimator) candidate(a, b int) (collapse, bool) {
+	kindA := d.vertexKinds[a]
+	kindB := d.vertexKinds[b]
+	q := d.quadrics[d.vertexGroups[a]]
+	if d.vertexGroups[a] != d.vertexGroups[b] {
+		q = q.Add(d.quadrics[d.vertexGroups[b]])
+	}
+
+	if kindA == interiorVertex && kindB == interiorVertex {
+		pos, t := d.bestPosition(q, a, b)
+		return collapse{
+			keep:        a,
+			remove:      b,
+			position:    pos,
+			t:           t,
+			interpolate: true,
+			cost:        QuadricErrorForVector(q, pos),
+		}, true
+	}
+
+	halfEdge := func(keep, remove int) collapse {
+		return collapse{
+			keep:     keep,
+			remove:   remove,
+			position: d.positions[keep],
+			cost:     QuadricErrorForVector(q, d.positions[keep]),
+		}
+	}
+
+	if kindA == interiorVertex {
+		return halfEdge(b, a), true
+	}
+
+	if kindB == interiorVertex {
+		return halfEdge(a, b), true
+	}
+
+	// Both vertices are on a boundary or locked. Only allow sliding a
+	// boundary vertex along a boundary edge
+	if d.edgeFaceCount(a, b) != 1 {
+		return collapse{}, false
+	}
+
+	if kindA == boundaryVertex && kindB == boundaryVertex {
+		intoA := halfEdge(a, b)
+		intoB := halfEdge(b, a)
+		if intoB.cost < intoA.cost {
+			return intoB, true
+		}
+		return intoA, true
+	}
+
+	if kindA == boundaryVertex {
+		return halfEdge(b, a), true
+	}
+
+	if kindB == boundaryVertex {
+		return halfEdge(a, b), true
+	}
+
+	return collapse{}, false
+}
+
+// valid ensures the collapse keeps the mesh manifold and doesn't fold any
+// triangles over on themselves
+func (d decimator) valid(c collapse) bool {
+	sharedFaces := d.edgeFaceCount(c.keep, c.remove)
+	if sharedFaces == 0 {
+		return false
+	}
+
+	// Link condition
+	keepNeighbors := d.neighbors(c.keep)
+	common := 0
+	for n := range d.neighbors(c.remove) {
+		if _, ok := keepNeighbors[n]; ok {
+			common++
+		}
+	}
+	if common != sharedFaces {
+		return false
+	}
+
+	return !d.flips(c.remove, c) && !d.flips(c.keep, c)
+}
+
+func (d decimator) flips(v int, c collapse) bool {
+	for _, f := range d.vertexFaces[v] {
+		if d.faceRemoved[f] {
+			continue
+		}
+
+		face := d.faces[f]
+		containsBoth := false
+		for _, fv := range face {
+			if fv == c.keep || fv == c.remove {
+				if fv != v {
+					containsBoth = true
+				}
+			}
+		}
+		if containsBoth {
+			continue
+		}
+
+		before := [3]vector3.Float64{}
+		after := [3]vector3.Float64{}
+		for i, fv := range face {
+			before[i] = d.positions[fv]
+			after[i] = before[i]
+			if fv == c.keep || fv == c.remove {
+				after[i] = c.position
+			}
+		}
+
+		oldNormal := before[1].Sub(before[0]).Cross(before[2].Sub(before[0]))
+		newNormal := after[1].Sub(after[0]).Cross(after[2].Sub(after[0]))
+		if newNormal.Length() == 0 {
+			return true
+		}
+
+		if oldNormal.Dot(newNormal) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func lerpData[T any](data map[string][]T, a, b int, t float64, lerp func(a, b T, t float64) T) {
+	for _, vals := range data {
+		vals[a] = lerp(vals[a], vals[b], t)
+	}
+}
+
+func (d *decimator) apply(c collapse) {
+	if c.interpolate {
+		lerpData(d.v1Data, c.keep, c.remove, c.t, func(a, b, t float64) float64 {
+			return a + ((b - a) * t)
+		})
+		lerpData(d.v2Data, c.keep, c.remove, c.t, func(a, b vector2.Float64, t float64) vector2.Float64 {
+			return a.Add(b.Sub(a).Scale(t))
+		})
+		lerpData(d.v3Data, c.keep, c.remove, c.t, func(a, b vector3.Float64, t float64) vector3.Float64 {
+			return a.Add(b.Sub(a).Scale(t))
+		})
+		lerpData(d.v4Data, c.keep, c.remove, c.t, func(a, b vector4.Float64, t float64) vector4.Float64 {
+			return a.Add(b.Sub(a).Scale(t))
+		})
+
+		if normals, ok := d.v3Data[modeling.NormalAttribute]; ok {
+			if n := normals[c.keep]; n.Length() > 0 {
+				normals[c.keep] = n.Normalized()
+			}
+		}
+	}
+	d.positions[c.keep] = c.position
+
+	keepFaces := d.vertexFaces[c.keep][:0]
+	for _, f := range d.vertexFaces[c.keep] {
+		if !d.faceRemoved[f] {
+			keepFaces = append(keepFaces, f)
+		}
+	}
+
+	for _, f := range d.vertexFaces[c.remove] {
+		if d.faceRemoved[f] {
+			continue
+		}
+
+		face := d.faces[f]
+		if face[0] == c.keep || face[1] == c.keep || face[2] == c.keep {
+			d.faceRemoved[f] = true
+			d.liveFaces--
+			continue
+		}
+
+		for i := range face {
+			if face[i] == c.remove {
+				d.faces[f][i] = c.keep
+			}
+		}
+		keepFaces = append(keepFaces, f)
+	}
+
+	// Drop faces that just got removed from the kept vertex's fan
+	cleaned := keepFaces[:0]
+	for _, f := range keepFaces {
+		if !d.faceRemoved[f] {
+			cleaned = append(cleaned, f)
+		}
+	}
+	d.vertexFaces[c.keep] = cleaned
+	d.vertexFaces[c.remove] = nil
+
+	keepGroup := d.vertexGroups[c.keep]
+	removeGroup := d.vertexGroups[c.remove]
+	if keepGroup != removeGroup {
+		d.quadrics[keepGroup] = d.quadrics[keepGroup].Add(d.quadrics[removeGroup])
+	}
+
+	d.vertexGone[c.remove] = true
+	d.versions[c.keep]++
+	d.versions[c.remove]++
+}
+
+func (d *decimator) push(queue *collapseQueue, a, b int) {
+	c, ok := d.candidate(a, b)
+	if !ok {
+		return
+	}
+	heap.Push(queue, collapseItem{
+		a:        a,
+		b:        b,
+		versionA: d.versions[a],
+		versionB: d.versions[b],
+		cost:     c.cost,
+	})
+}
+
+func (d *decimator) run(params QuadricDecimationParameters) {
+	queue := &collapseQueue{}
+	seen := make(map[modeling.Edge]struct{})
+	for _, face := range d.faces {
+		for j := 0; j < 3; j++ {
+			key := modeling.NewEdge(face[j], face[(j+1)%3])
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			d.push(queue, key.A, key.B)
+		}
+	}
+
+	for queue.Len() > 0 {
+		if params.TargetTriangleCount > 0 && d.liveFaces <= params.TargetTriangleCount {
+			return
+		}
+
+		item := heap.Pop(queue).(collapseItem)
+		if d.vertexGone[item.a] || d.vertexGone[item.b] {
+			continue
+		}
+
+		if d.versions[item.a] != item.versionA || d.versions[item.b] != item.versionB {
+			continue
+		}
+
+		if params.MaxError > 0 && item.cost > params.MaxError {
+			return
+		}
+
+		c, ok := d.candidate(item.a, item.b)
+		if !ok || !d.valid(c) {
+			continue
+		}
+
+		d.apply(c)
+
+		for n := range d.neighbors(c.keep) {
+			d.push(queue, c.keep, n)
+		}
+	}
+}
+
+func compactData[T any](data map[string][]T, remap []int, count int) map[string][]T {
+	out := make(map[string][]T, len(data))
+	for attr, vals := range data {
+		compacted := make([]T, count)
+		for i, v := range vals {
+			if remap[i] >= 0 {
+				compacted[remap[i]] = v
+			}
+		}
+		out[attr] = compacted
+	}
+	return out
+}
+
+// liveFaceSources returns the original primitive of every face that
+// survived decimation, in the same order mesh builds them
+func (d decimator) liveFaceSources() []int {
+	sources := make([]int, 0, d.liveFaces)
+	for f, source := range d.faceSources {
+		if !d.faceRemoved[f] {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// mesh builds the decimated mesh out of the surviving faces. Kept vertices
+// retain their original integer attributes, as those can't be blended.
+func (d decimator) mesh(original modeling.Mesh) modeling.Mesh {
+	remap := make([]int, len(d.vertexGone))
+	for i := range remap {
+		remap[i] = -1
+	}
+
+	count := 0
+	sources := make([]int, 0)
+	indices := make([]int, 0, d.liveFaces*3)
+	for f, face := range d.faces {
+		if d.faceRemoved[f] {
+			continue
+		}
+		for _, v := range face {
+			if remap[v] == -1 {
+				remap[v] = count
+				sources = append(sources, v)
+				count++
+			}
+			indices = append(indices, remap[v])
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat1Data(compactData(d.v1Data, remap, count)).
+		SetFloat2Data(compactData(d.v2Data, remap, count)).
+		SetFloat3Data(compactData(d.v3Data, remap, count)).
+		SetFloat4Data(compactData(d.v4Data, remap, count)).
+		RemapIntAttributes(original, sources)
 }
@@ -0,0 +1,158 @@
+package simplify_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/modeling/simplify"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+)
+
+func grid(size int) modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	uvs := make([]vector2.Float64, 0)
+	for y := 0; y <= size; y++ {
+		for x := 0; x <= size; x++ {
+			positions = append(positions, vector3.New(float64(x), float64(y), 0.))
+			uvs = append(uvs, vector2.New(float64(x)/float64(size), float64(y)/float64(size)))
+		}
+	}
+
+	indices := make([]int, 0)
+	row := size + 1
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			bl := (y * row) + x
+			br := bl + 1
+			tl := bl + row
+			tr := tl + 1
+			indices = append(indices, bl, br, tr, bl, tr, tl)
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat2Attribute(modeling.TexCoordAttribute, uvs)
+}
+
+func TestQuadricVector(t *testing.T) {
+	// Three orthogonal planes meeting at (1, 2, 3)
+	q := simplify.PlaneQuadric(vector3.New(1., 0., 0.), -1).
+		Add(simplify.PlaneQuadric(vector3.New(0., 1., 0.), -2)).
+		Add(simplify.PlaneQuadric(vector3.New(0., 0., 1.), -3))
+
+	v := simplify.QuadricVector(q)
+	assert.InDelta(t, 1., v.X(), 1e-9)
+	assert.InDelta(t, 2., v.Y(), 1e-9)
+	assert.InDelta(t, 3., v.Z(), 1e-9)
+	assert.InDelta(t, 0., simplify.QuadricErrorForVector(q, v), 1e-9)
+	assert.InDelta(t, 1., simplify.QuadricErrorForVector(q, vector3.New(1., 2., 4.)), 1e-9)
+}
+
+func TestQuadricDecimation_FlatGridPreservesBoundaryAndUVs(t *testing.T) {
+	mesh := grid(10)
+
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{
+		TargetTriangleCount: 20,
+	})
+
+	assert.LessOrEqual(t, decimated.PrimitiveCount(), 20)
+	assert.Greater(t, decimated.PrimitiveCount(), 0)
+
+	bounds := decimated.BoundingBox(modeling.PositionAttribute)
+	assert.Equal(t, vector3.New(0., 0., 0.), bounds.Min())
+	assert.Equal(t, vector3.New(10., 10., 0.), bounds.Max())
+
+	uvs := decimated.Float2Attribute(modeling.TexCoordAttribute)
+	decimated.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 0., v.Z(), 1e-9)
+
+		// UVs map linearly onto the grid, so the interpolated UV should
+		// still match the vertex's position
+		uv := uvs.At(i)
+		assert.InDelta(t, v.X()/10, uv.X(), 1e-9)
+		assert.InDelta(t, v.Y()/10, uv.Y(), 1e-9)
+	})
+}
+
+func TestQuadricDecimation_MaxErrorOnFlatGrid(t *testing.T) {
+	mesh := grid(6)
+
+	// Everything is coplanar, so collapsing is free
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{
+		MaxError: 1e-6,
+	})
+	assert.Less(t, decimated.PrimitiveCount(), mesh.PrimitiveCount())
+}
+
+func TestQuadricDecimation_Sphere(t *testing.T) {
+	mesh := primitives.UVSphere(1, 20, 20)
+	start := mesh.PrimitiveCount()
+
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{
+		TargetTriangleCount: start / 4,
+	})
+
+	assert.LessOrEqual(t, decimated.PrimitiveCount(), start/4)
+	assert.Equal(t, decimated.AttributeLength(), decimated.Float3Attribute(modeling.NormalAttribute).Len())
+	decimated.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 1., v.Length(), 0.1)
+	})
+}
+
+func TestQuadricDecimation_NoopWithoutLimits(t *testing.T) {
+	mesh := grid(3)
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{})
+	assert.Equal(t, mesh.PrimitiveCount(), decimated.PrimitiveCount())
+}
+
+func TestQuadricDecimation_UnweldedSphere(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := primitives.UVSphereUnwelded(1, 20, 20)
+	start := mesh.PrimitiveCount()
+
+	// ACT ====================================================================
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{
+		TargetTriangleCount: start / 4,
+	})
+
+	// ASSERT =================================================================
+	assert.LessOrEqual(t, decimated.PrimitiveCount(), start/4)
+	decimated.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 1., v.Length(), 0.1)
+	})
+}
+
+func TestQuadricDecimation_KeepsSeamsWithDifferingAttributes(t *testing.T) {
+	// ARRANGE ================================================================
+	// Two grids sitting side by side, sharing positions along x = 4 but with
+	// UVs that disagree there
+	left := grid(4)
+	right := grid(4).
+		Translate(vector3.New(4., 0., 0.)).
+		ModifyFloat2Attribute(modeling.TexCoordAttribute, func(i int, v vector2.Float64) vector2.Float64 {
+			return v.Add(vector2.New(5., 5.))
+		})
+	mesh := left.Append(right)
+
+	// ACT ====================================================================
+	decimated := simplify.QuadricDecimation(mesh, simplify.QuadricDecimationParameters{
+		TargetTriangleCount: 4,
+	})
+
+	// ASSERT =================================================================
+	assert.Less(t, decimated.PrimitiveCount(), mesh.PrimitiveCount())
+	seam := make(map[vector3.Float64]int)
+	decimated.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		if v.X() == 4 {
+			seam[v]++
+		}
+	})
+	assert.Len(t, seam, 5)
+	for _, count := range seam {
+		assert.Equal(t, 2, count)
+	}
+}
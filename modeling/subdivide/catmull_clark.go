@@ -0,0 +1,158 @@
+package subdivide
+
+import (
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func centroid(positions []vector3.Float64, face []int) vector3.Float64 {
+	sum := vector3.Zero[float64]()
+	for _, v := range face {
+		sum = sum.Add(positions[v])
+	}
+	return sum.DivByConstant(float64(len(face)))
+}
+
+func catmullClark(c cage, creaseAngle float64) cage {
+	t := newTopology(c, creaseAngle)
+
+	centroids := make([]vector3.Float64, len(c.faces))
+	for f, face := range c.faces {
+		centroids[f] = centroid(c.positions, face)
+	}
+
+	edgePoints := make(map[modeling.Edge]vector3.Float64, len(t.edges))
+	for e, info := range t.edges {
+		sum := c.positions[e.A].Add(c.positions[e.B])
+		if info.crease {
+			edgePoints[e] = sum.Scale(0.5)
+			continue
+		}
+		edgePoints[e] = sum.Add(centroids[info.faces[0]]).Add(centroids[info.faces[1]]).Scale(0.25)
+	}
+
+	out := cage{
+		positions: make([]vector3.Float64, len(c.positions)),
+		stencils:  c.stencils,
+		faces:     make([][]int, 0, len(c.faces)*4),
+		sources:   make([]int, 0, len(c.faces)*4),
+		creases:   make(map[modeling.Edge]bool),
+	}
+
+	for v, p := range c.positions {
+		if t.welded[v] != v {
+			continue
+		}
+
+		if creased, ok := t.creaseRule(c, v); ok {
+			out.positions[v] = creased
+			continue
+		}
+
+		edges := t.vertexEdges[v]
+		faces := t.vertexFaces[v]
+		n := float64(len(edges))
+		if len(edges) < 3 || len(faces) == 0 {
+			out.positions[v] = p
+			continue
+		}
+
+		f := vector3.Zero[float64]()
+		for _, face := range faces {
+			f = f.Add(centroids[face])
+		}
+		f = f.DivByConstant(float64(len(faces)))
+
+		r := vector3.Zero[float64]()
+		for _, e := range edges {
+			r = r.Add(c.positions[e.A].Add(c.positions[e.B]).Scale(0.5))
+		}
+		r = r.DivByConstant(n)
+
+		out.positions[v] = f.Add(r.Scale(2)).Add(p.Scale(n - 3)).DivByConstant(n)
+	}
+
+	for v := range c.positions {
+		out.positions[v] = out.positions[t.welded[v]]
+	}
+
+	edgeVertices := make(map[modeling.Edge]int)
+	edgeVertex := func(a, b int) int {
+		key := modeling.NewEdge(a, b)
+		if index, ok := edgeVertices[key]; ok {
+			return index
+		}
+
+		index := len(out.positions)
+		out.positions = append(out.positions, edgePoints[modeling.NewEdge(t.welded[a], t.welded[b])])
+		out.stencils = append(out.stencils, blend(c.stencils[a], c.stencils[b]))
+		edgeVertices[key] = index
+		return index
+	}
+
+	for f, face := range c.faces {
+		facePoint := len(out.positions)
+		out.positions = append(out.positions, centroids[f])
+		corners := make([]stencil, len(face))
+		for i, v := range face {
+			corners[i] = c.stencils[v]
+		}
+		out.stencils = append(out.stencils, blend(corners...))
+
+		for i, v := range face {
+			next := face[(i+1)%len(face)]
+			prev := face[(i+len(face)-1)%len(face)]
+			out.faces = append(out.faces, []int{v, edgeVertex(v, next), facePoint, edgeVertex(prev, v)})
+			out.sources = append(out.sources, c.sources[f])
+
+			if t.edges[modeling.NewEdge(t.welded[v], t.welded[next])].crease {
+				e := edgeVertex(v, next)
+				out.creases[modeling.NewEdge(v, e)] = true
+				out.creases[modeling.NewEdge(e, next)] = true
+			}
+		}
+	}
+
+	return out
+}
+
+// CatmullClark subdivides a triangle or quad mesh using Catmull-Clark
+// subdivision, producing a smooth surface made entirely of quads.
+func CatmullClark(m modeling.Mesh, params Parameters) (modeling.Mesh, error) {
+	if err := validate(m, params, modeling.TriangleTopology, modeling.QuadTopology); err != nil {
+		return m, err
+	}
+
+	if params.Levels <= 0 || m.PrimitiveCount() == 0 {
+		return m, nil
+	}
+
+	c := newCage(m)
+	for level := 0; level < params.Levels; level++ {
+		creaseAngle := 0.
+		if level == 0 {
+			creaseAngle = params.CreaseAngle
+		}
+		c = catmullClark(c, creaseAngle)
+	}
+
+	if params.KeepQuads {
+		indices := make([]int, 0, len(c.faces)*4)
+		for _, face := range c.faces {
+			indices = append(indices, face...)
+		}
+		return c.toMesh(m, modeling.QuadTopology, indices, c.sources), nil
+	}
+
+	indices := make([]int, 0, len(c.faces)*6)
+	sources := make([]int, 0, len(c.faces)*2)
+	for f, face := range c.faces {
+		indices = append(
+			indices,
+			face[0], face[1], face[2],
+			face[0], face[2], face[3],
+		)
+		sources = append(sources, c.sources[f], c.sources[f])
+	}
+	return c.toMesh(m, modeling.TriangleTopology, indices, sources), nil
+}
@@ -0,0 +1,136 @@
+package subdivide
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func loop(c cage, creaseAngle float64) cage {
+	t := newTopology(c, creaseAngle)
+
+	edgePoints := make(map[modeling.Edge]vector3.Float64, len(t.edges))
+	for e, info := range t.edges {
+		sum := c.positions[e.A].Add(c.positions[e.B])
+		if info.crease {
+			edgePoints[e] = sum.Scale(0.5)
+			continue
+		}
+
+		opposite := vector3.Zero[float64]()
+		for _, f := range info.faces {
+			for _, v := range c.faces[f] {
+				if w := t.welded[v]; w != e.A && w != e.B {
+					opposite = opposite.Add(c.positions[w])
+					break
+				}
+			}
+		}
+		edgePoints[e] = sum.Scale(3. / 8.).Add(opposite.Scale(1. / 8.))
+	}
+
+	out := cage{
+		positions: make([]vector3.Float64, len(c.positions)),
+		stencils:  c.stencils,
+		faces:     make([][]int, 0, len(c.faces)*4),
+		sources:   make([]int, 0, len(c.faces)*4),
+		creases:   make(map[modeling.Edge]bool),
+	}
+
+	for v, p := range c.positions {
+		if t.welded[v] != v {
+			continue
+		}
+
+		if creased, ok := t.creaseRule(c, v); ok {
+			out.positions[v] = creased
+			continue
+		}
+
+		edges := t.vertexEdges[v]
+		if len(edges) < 3 {
+			out.positions[v] = p
+			continue
+		}
+
+		n := float64(len(edges))
+		inner := 3./8. + math.Cos(2*math.Pi/n)/4
+		beta := (5./8. - inner*inner) / n
+
+		neighbors := vector3.Zero[float64]()
+		for _, e := range edges {
+			neighbors = neighbors.Add(c.positions[e.Other(v)])
+		}
+		out.positions[v] = p.Scale(1 - n*beta).Add(neighbors.Scale(beta))
+	}
+
+	for v := range c.positions {
+		out.positions[v] = out.positions[t.welded[v]]
+	}
+
+	edgeVertices := make(map[modeling.Edge]int)
+	edgeVertex := func(a, b int) int {
+		key := modeling.NewEdge(a, b)
+		if index, ok := edgeVertices[key]; ok {
+			return index
+		}
+
+		index := len(out.positions)
+		out.positions = append(out.positions, edgePoints[modeling.NewEdge(t.welded[a], t.welded[b])])
+		out.stencils = append(out.stencils, blend(c.stencils[a], c.stencils[b]))
+		edgeVertices[key] = index
+		return index
+	}
+
+	for f, face := range c.faces {
+		a, b, c2 := face[0], face[1], face[2]
+		ab, bc, ca := edgeVertex(a, b), edgeVertex(b, c2), edgeVertex(c2, a)
+		out.faces = append(
+			out.faces,
+			[]int{a, ab, ca},
+			[]int{b, bc, ab},
+			[]int{c2, ca, bc},
+			[]int{ab, bc, ca},
+		)
+		out.sources = append(out.sources, c.sources[f], c.sources[f], c.sources[f], c.sources[f])
+
+		for i, v := range face {
+			next := face[(i+1)%3]
+			if t.edges[modeling.NewEdge(t.welded[v], t.welded[next])].crease {
+				e := edgeVertex(v, next)
+				out.creases[modeling.NewEdge(v, e)] = true
+				out.creases[modeling.NewEdge(e, next)] = true
+			}
+		}
+	}
+
+	return out
+}
+
+// Loop subdivides a triangle mesh using Loop subdivision, splitting every
+// triangle into four and smoothing the result.
+func Loop(m modeling.Mesh, params Parameters) (modeling.Mesh, error) {
+	if err := validate(m, params, modeling.TriangleTopology); err != nil {
+		return m, err
+	}
+
+	if params.Levels <= 0 || m.PrimitiveCount() == 0 {
+		return m, nil
+	}
+
+	c := newCage(m)
+	for level := 0; level < params.Levels; level++ {
+		creaseAngle := 0.
+		if level == 0 {
+			creaseAngle = params.CreaseAngle
+		}
+		c = loop(c, creaseAngle)
+	}
+
+	indices := make([]int, 0, len(c.faces)*3)
+	for _, face := range c.faces {
+		indices = append(indices, face...)
+	}
+	return c.toMesh(m, modeling.TriangleTopology, indices, c.sources), nil
+}
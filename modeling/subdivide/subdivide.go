@@ -0,0 +1,342 @@
+// Package subdivide smooths meshes by repeatedly splitting their faces.
+//
+// Vertices that share the same position are treated as the same vertex
+// when repositioning, so meshes split along UV seams or hard normals still
+// subdivide as a single surface. Every attribute other than position is
+// interpolated linearly across each of the original mesh's faces, keeping
+// seams intact.
+package subdivide
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+)
+
+// MaxLevels is the most times a mesh can be subdivided in one call. Every
+// level multiplies the number of faces by four.
+const MaxLevels = 6
+
+type Parameters struct {
+	// Number of times the mesh is subdivided, up to MaxLevels. Zero or less
+	// leaves the mesh untouched.
+	Levels int
+
+	// Edges of the original mesh where the faces on either side meet at an
+	// angle greater than this (in radians) are kept sharp. Zero or less
+	// disables angle based creasing. Boundary and non-manifold edges are
+	// always treated as creases.
+	CreaseAngle float64
+
+	// Catmull-Clark subdivision produces quads, which get triangulated
+	// unless this is set, in which case a quad topology mesh is returned.
+	// Ignored by Loop subdivision.
+	KeepQuads bool
+}
+
+type weight struct {
+	vertex int
+	amount float64
+}
+
+// stencil describes a vertex as a weighted sum of the original mesh's
+// vertices, which is how every attribute other than position is
+// interpolated
+type stencil []weight
+
+// dominant returns the original vertex carrying the most weight, which is
+// where integer attributes that can't be blended are taken from
+func (s stencil) dominant() int {
+	vertex := -1
+	amount := 0.
+	for _, w := range s {
+		if vertex == -1 || w.amount > amount {
+			vertex = w.vertex
+			amount = w.amount
+		}
+	}
+	return vertex
+}
+
+func blend(stencils ...stencil) stencil {
+	out := make(stencil, 0, len(stencils))
+	scale := 1. / float64(len(stencils))
+	for _, s := range stencils {
+	next:
+		for _, w := range s {
+			for i := range out {
+				if out[i].vertex == w.vertex {
+					out[i].amount += w.amount * scale
+					continue next
+				}
+			}
+			out = append(out, weight{vertex: w.vertex, amount: w.amount * scale})
+		}
+	}
+	return out
+}
+
+// cage is a polygon mesh mid-subdivision
+type cage struct {
+	positions []vector3.Float64
+	stencils  []stencil
+	faces     [][]int
+
+	// Primitive of the original mesh each face was subdivided from
+	sources []int
+
+	// Edges between two of the cage's vertices that are to be kept sharp
+	creases map[modeling.Edge]bool
+}
+
+type edgeInfo struct {
+	faces  []int
+	crease bool
+}
+
+// topology is the connectivity of a cage, built from its position welded
+// vertices so meshes split along attribute seams subdivide as one surface
+type topology struct {
+	welded      []int
+	edges       map[modeling.Edge]*edgeInfo
+	vertexEdges map[int][]modeling.Edge
+	vertexFaces map[int][]int
+}
+
+func faceNormal(positions []vector3.Float64, face []int) vector3.Float64 {
+	// Newell's method, which handles non-planar quads gracefully
+	normal := vector3.Zero[float64]()
+	for i, v := range face {
+		normal = normal.Add(positions[v].Cross(positions[face[(i+1)%len(face)]]))
+	}
+	if normal.Length() == 0 {
+		return normal
+	}
+	return normal.Normalized()
+}
+
+func newTopology(c cage, creaseAngle float64) topology {
+	t := topology{
+		welded:      modeling.WeldPositions(c.positions),
+		edges:       make(map[modeling.Edge]*edgeInfo),
+		vertexEdges: make(map[int][]modeling.Edge),
+		vertexFaces: make(map[int][]int),
+	}
+
+	for f, face := range c.faces {
+		for i, v := range face {
+			w := t.welded[v]
+			t.vertexFaces[w] = append(t.vertexFaces[w], f)
+
+			e := modeling.NewEdge(w, t.welded[face[(i+1)%len(face)]])
+			info, ok := t.edges[e]
+			if !ok {
+				info = &edgeInfo{}
+				t.edges[e] = info
+				t.vertexEdges[e.A] = append(t.vertexEdges[e.A], e)
+				t.vertexEdges[e.B] = append(t.vertexEdges[e.B], e)
+			}
+			info.faces = append(info.faces, f)
+		}
+	}
+
+	for e := range c.creases {
+		if info, ok := t.edges[modeling.NewEdge(t.welded[e.A], t.welded[e.B])]; ok {
+			info.crease = true
+		}
+	}
+
+	minDot := math.Cos(creaseAngle)
+	for _, info := range t.edges {
+		if len(info.faces) != 2 {
+			info.crease = true
+			continue
+		}
+
+		if creaseAngle > 0 {
+			a := faceNormal(c.positions, c.faces[info.faces[0]])
+			b := faceNormal(c.positions, c.faces[info.faces[1]])
+			if a.Dot(b) < minDot {
+				info.crease = true
+			}
+		}
+	}
+
+	return t
+}
+
+// creaseRule determines how a vertex is repositioned based on the creases
+// running through it. Smooth vertices return false. Vertices on exactly two
+// creases are moved along the crease curve, while corners (more than two
+// creases, or boundary vertices belonging to a single face) stay where they
+// are.
+func (t topology) creaseRule(c cage, w int) (vector3.Float64, bool) {
+	edges := t.vertexEdges[w]
+	creases := make([]int, 0, 2)
+	for _, e := range edges {
+		if t.edges[e].crease {
+			creases = append(creases, e.Other(w))
+		}
+	}
+
+	p := c.positions[w]
+	switch {
+	case len(creases) < 2:
+		return p, false
+
+	case len(creases) > 2 || len(t.vertexFaces[w]) == 1:
+		return p, true
+	}
+
+	return p.Scale(6).
+		Add(c.positions[creases[0]]).
+		Add(c.positions[creases[1]]).
+		DivByConstant(8), true
+}
+
+func validate(m modeling.Mesh, params Parameters, topologies ...modeling.Topology) error {
+	if params.Levels > MaxLevels {
+		return fmt.Errorf("subdivision levels %d exceeds the maximum of %d", params.Levels, MaxLevels)
+	}
+
+	supported := false
+	for _, topo := range topologies {
+		if m.Topology() == topo {
+			supported = true
+		}
+	}
+
+	if !supported {
+		return fmt.Errorf("subdivision does not support %s topology", m.Topology().String())
+	}
+
+	if m.PrimitiveCount() > 0 && !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return fmt.Errorf("subdivision requires the mesh to have the vector3 attribute: '%s'", modeling.PositionAttribute)
+	}
+
+	return nil
+}
+
+func newCage(m modeling.Mesh) cage {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+	c := cage{
+		positions: make([]vector3.Float64, positions.Len()),
+		stencils:  make([]stencil, positions.Len()),
+		faces:     make([][]int, 0, m.PrimitiveCount()),
+		sources:   make([]int, 0, m.PrimitiveCount()),
+	}
+
+	for i := range c.positions {
+		c.positions[i] = positions.At(i)
+		c.stencils[i] = stencil{{vertex: i, amount: 1}}
+	}
+
+	size := m.Topology().IndexSize()
+	indices := m.Indices()
+	for i := 0; i < indices.Len(); i += size {
+		face := make([]int, size)
+		for j := range face {
+			face[j] = indices.At(i + j)
+		}
+		c.faces = append(c.faces, face)
+		c.sources = append(c.sources, len(c.sources))
+	}
+
+	return c
+}
+
+func interpolate[T any](data []T, stencils []stencil, zero T, add func(a, b T) T, scale func(v T, s float64) T) []T {
+	out := make([]T, len(stencils))
+	for i, s := range stencils {
+		v := zero
+		for _, w := range s {
+			v = add(v, scale(data[w.vertex], w.amount))
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// toMesh builds the final mesh, interpolating every attribute other than
+// position linearly across the original mesh's faces. Each primitive is
+// assigned the primitive group of the original face it was subdivided from,
+// and each vertex the integer attributes of its dominant original vertex.
+func (c cage) toMesh(original modeling.Mesh, topo modeling.Topology, indices, sources []int) modeling.Mesh {
+	vertexSources := make([]int, len(c.stencils))
+	for i, s := range c.stencils {
+		vertexSources[i] = s.dominant()
+	}
+
+	result := modeling.NewMesh(topo, indices).
+		SetFloat3Attribute(modeling.PositionAttribute, c.positions).
+		RemapIntAttributes(original, vertexSources).
+		RemapPrimitiveGroups(original, sources)
+
+	for _, attr := range original.Float4Attributes() {
+		data := original.Float4Attribute(attr)
+		values := make([]vector4.Float64, data.Len())
+		for i := range values {
+			values[i] = data.At(i)
+		}
+		result = result.SetFloat4Attribute(attr, interpolate(
+			values, c.stencils, vector4.Zero[float64](),
+			vector4.Float64.Add, vector4.Float64.Scale,
+		))
+	}
+
+	for _, attr := range original.Float3Attributes() {
+		if attr == modeling.PositionAttribute {
+			continue
+		}
+
+		data := original.Float3Attribute(attr)
+		values := make([]vector3.Float64, data.Len())
+		for i := range values {
+			values[i] = data.At(i)
+		}
+		interpolated := interpolate(
+			values, c.stencils, vector3.Zero[float64](),
+			vector3.Float64.Add, vector3.Float64.Scale,
+		)
+
+		if attr == modeling.NormalAttribute {
+			for i, n := range interpolated {
+				if n.Length() > 0 {
+					interpolated[i] = n.Normalized()
+				}
+			}
+		}
+		result = result.SetFloat3Attribute(attr, interpolated)
+	}
+
+	for _, attr := range original.Float2Attributes() {
+		data := original.Float2Attribute(attr)
+		values := make([]vector2.Float64, data.Len())
+		for i := range values {
+			values[i] = data.At(i)
+		}
+		result = result.SetFloat2Attribute(attr, interpolate(
+			values, c.stencils, vector2.Zero[float64](),
+			vector2.Float64.Add, vector2.Float64.Scale,
+		))
+	}
+
+	for _, attr := range original.Float1Attributes() {
+		data := original.Float1Attribute(attr)
+		values := make([]float64, data.Len())
+		for i := range values {
+			values[i] = data.At(i)
+		}
+		result = result.SetFloat1Attribute(attr, interpolate(
+			values, c.stencils, 0,
+			func(a, b float64) float64 { return a + b },
+			func(v, s float64) float64 { return v * s },
+		))
+	}
+
+	return result
+}
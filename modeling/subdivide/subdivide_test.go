@@ -0,0 +1,271 @@
+package subdivide_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/meshops"
+	"github.com/EliCDavis/polyform/modeling/subdivide"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	cubeCorners = []vector3.Float64{
+		vector3.New(-0.5, -0.5, -0.5),
+		vector3.New(-0.5, -0.5, 0.5),
+		vector3.New(-0.5, 0.5, -0.5),
+		vector3.New(-0.5, 0.5, 0.5),
+		vector3.New(0.5, -0.5, -0.5),
+		vector3.New(0.5, -0.5, 0.5),
+		vector3.New(0.5, 0.5, -0.5),
+		vector3.New(0.5, 0.5, 0.5),
+	}
+
+	cubeFaces = [][]int{
+		{0, 1, 3, 2},
+		{4, 6, 7, 5},
+		{0, 4, 5, 1},
+		{2, 3, 7, 6},
+		{0, 2, 6, 4},
+		{1, 5, 7, 3},
+	}
+)
+
+// quadCube builds a unit cube out of quads, sharing its 8 corners
+func quadCube() modeling.Mesh {
+	indices := make([]int, 0)
+	for _, face := range cubeFaces {
+		indices = append(indices, face...)
+	}
+	return modeling.NewMesh(modeling.QuadTopology, indices).
+		SetFloat3Attribute(modeling.PositionAttribute, cubeCorners)
+}
+
+// unweldedQuadCube builds a unit cube out of quads, where every face has
+// its own vertices, with an attribute recording which face they belong to
+func unweldedQuadCube() modeling.Mesh {
+	indices := make([]int, 0)
+	positions := make([]vector3.Float64, 0)
+	ids := make([]float64, 0)
+	for f, face := range cubeFaces {
+		for _, v := range face {
+			indices = append(indices, len(positions))
+			positions = append(positions, cubeCorners[v])
+			ids = append(ids, float64(f))
+		}
+	}
+	return modeling.NewMesh(modeling.QuadTopology, indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat1Attribute("face", ids)
+}
+
+// octahedron builds a closed triangle mesh with an attribute recording each
+// vertex's x position
+func octahedron() modeling.Mesh {
+	positions := []vector3.Float64{
+		vector3.New(1., 0., 0.),
+		vector3.New(-1., 0., 0.),
+		vector3.New(0., 1., 0.),
+		vector3.New(0., -1., 0.),
+		vector3.New(0., 0., 1.),
+		vector3.New(0., 0., -1.),
+	}
+	xs := make([]float64, len(positions))
+	for i, p := range positions {
+		xs[i] = p.X()
+	}
+	return modeling.NewTriangleMesh([]int{
+		0, 2, 4, 2, 1, 4, 1, 3, 4, 3, 0, 4,
+		2, 0, 5, 1, 2, 5, 3, 1, 5, 0, 3, 5,
+	}).
+		SetFloat3Attribute(modeling.PositionAttribute, positions).
+		SetFloat1Attribute("x", xs)
+}
+
+func containsPosition(m modeling.Mesh, target vector3.Float64) bool {
+	found := false
+	m.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		if v.Distance(target) < 1e-9 {
+			found = true
+		}
+	})
+	return found
+}
+
+func TestCatmullClark_Cube(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := quadCube()
+
+	// ACT ====================================================================
+	quads, quadErr := subdivide.CatmullClark(cube, subdivide.Parameters{Levels: 1, KeepQuads: true})
+	tris, triErr := subdivide.CatmullClark(cube, subdivide.Parameters{Levels: 2})
+
+	// ASSERT =================================================================
+	require.NoError(t, quadErr)
+	require.NoError(t, triErr)
+
+	assert.Equal(t, modeling.QuadTopology, quads.Topology())
+	assert.Equal(t, 24, quads.PrimitiveCount())
+
+	// 8 corners, 12 edge points, 6 face points
+	assert.Equal(t, 26, quads.Float3Attribute(modeling.PositionAttribute).Len())
+
+	// Corners are pulled in to (F + 2R + (n-3)P) / n
+	assert.True(t, containsPosition(quads, vector3.Fill(5./18.)))
+
+	// Face points stay on the face, edge points are pulled in
+	assert.True(t, containsPosition(quads, vector3.New(0.5, 0., 0.)))
+	assert.True(t, containsPosition(quads, vector3.New(0.375, 0.375, 0.)))
+
+	assert.Equal(t, modeling.TriangleTopology, tris.Topology())
+	assert.Equal(t, 6*16*2, tris.PrimitiveCount())
+	assert.Less(t, meshops.Volume(tris, modeling.PositionAttribute), 1.)
+	assert.Greater(t, meshops.Volume(tris, modeling.PositionAttribute), 4./3.*math.Pi*0.25*0.25*0.25)
+}
+
+func TestCatmullClark_CreaseAngle(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := quadCube()
+
+	// ACT ====================================================================
+	subdivided, err := subdivide.CatmullClark(cube, subdivide.Parameters{
+		Levels:      2,
+		CreaseAngle: math.Pi / 4,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+
+	// Every edge of a cube is sharp, so nothing should move off the cube
+	assert.InDelta(t, 1., meshops.Volume(subdivided, modeling.PositionAttribute), 1e-9)
+	subdivided.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.InDelta(t, 0.5, v.Abs().MaxComponent(), 1e-9)
+	})
+}
+
+func TestCatmullClark_Seams(t *testing.T) {
+	// ARRANGE ================================================================
+	welded := quadCube()
+	unwelded := unweldedQuadCube()
+
+	// ACT ====================================================================
+	expected, expectedErr := subdivide.CatmullClark(welded, subdivide.Parameters{Levels: 2})
+	actual, actualErr := subdivide.CatmullClark(unwelded, subdivide.Parameters{Levels: 2})
+
+	// ASSERT =================================================================
+	require.NoError(t, expectedErr)
+	require.NoError(t, actualErr)
+	assert.InDelta(t, meshops.Volume(expected, modeling.PositionAttribute), meshops.Volume(actual, modeling.PositionAttribute), 1e-9)
+
+	// Attributes on either side of a seam are never blended together
+	actual.ScanFloat1Attribute("face", func(i int, v float64) {
+		assert.InDelta(t, math.Round(v), v, 1e-9)
+	})
+}
+
+func TestLoop_Octahedron(t *testing.T) {
+	// ARRANGE ================================================================
+	m := octahedron()
+
+	// ACT ====================================================================
+	subdivided, err := subdivide.Loop(m, subdivide.Parameters{Levels: 2})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 8*16, subdivided.PrimitiveCount())
+	assert.Less(t, meshops.Volume(subdivided, modeling.PositionAttribute), 4./3.)
+	assert.Greater(t, meshops.Volume(subdivided, modeling.PositionAttribute), 0.)
+
+	// Attributes are interpolated linearly, while positions are smoothed
+	positions := subdivided.Float3Attribute(modeling.PositionAttribute)
+	xs := subdivided.Float1Attribute("x")
+	moved := false
+	for i := 0; i < positions.Len(); i++ {
+		assert.LessOrEqual(t, math.Abs(xs.At(i)), 1.)
+		if math.Abs(positions.At(i).X()-xs.At(i)) > 1e-6 {
+			moved = true
+		}
+	}
+	assert.True(t, moved)
+}
+
+func TestLoop_Boundary(t *testing.T) {
+	// ARRANGE ================================================================
+	plane := modeling.NewTriangleMesh([]int{0, 2, 1, 0, 3, 2}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(0., 0., 0.),
+			vector3.New(1., 0., 0.),
+			vector3.New(1., 0., 1.),
+			vector3.New(0., 0., 1.),
+		})
+
+	// ACT ====================================================================
+	subdivided, err := subdivide.Loop(plane, subdivide.Parameters{Levels: 3})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 2*64, subdivided.PrimitiveCount())
+
+	// Corners only belonging to a single triangle stay put, while the rest
+	// of the boundary slides along itself
+	assert.True(t, containsPosition(subdivided, vector3.New(1., 0., 0.)))
+	assert.True(t, containsPosition(subdivided, vector3.New(0., 0., 1.)))
+	assert.False(t, containsPosition(subdivided, vector3.New(0., 0., 0.)))
+
+	subdivided.ScanFloat3Attribute(modeling.PositionAttribute, func(i int, v vector3.Float64) {
+		assert.Equal(t, 0., v.Y())
+		assert.GreaterOrEqual(t, v.X(), 0.)
+		assert.LessOrEqual(t, v.X(), 1.)
+		assert.GreaterOrEqual(t, v.Z(), 0.)
+		assert.LessOrEqual(t, v.Z(), 1.)
+	})
+}
+
+func TestLoop_RequiresTriangles(t *testing.T) {
+	_, err := subdivide.Loop(quadCube(), subdivide.Parameters{Levels: 1})
+	assert.EqualError(t, err, "subdivision does not support quad topology")
+}
+
+func TestSubdivide_LimitsLevels(t *testing.T) {
+	levels := subdivide.Parameters{Levels: subdivide.MaxLevels + 1}
+
+	_, ccErr := subdivide.CatmullClark(quadCube(), levels)
+	assert.EqualError(t, ccErr, "subdivision levels 7 exceeds the maximum of 6")
+
+	_, loopErr := subdivide.Loop(octahedron(), levels)
+	assert.EqualError(t, loopErr, "subdivision levels 7 exceeds the maximum of 6")
+}
+
+func TestCatmullClark_PrimitiveGroups(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := quadCube().SetPrimitiveGroups([]modeling.PrimitiveGroup{
+		{Material: "top", Primitives: []int{3}},
+	})
+
+	// ACT ====================================================================
+	quads, quadErr := subdivide.CatmullClark(cube, subdivide.Parameters{Levels: 1, KeepQuads: true})
+	tris, triErr := subdivide.CatmullClark(cube, subdivide.Parameters{Levels: 2})
+
+	// ASSERT =================================================================
+	require.NoError(t, quadErr)
+	require.NoError(t, triErr)
+
+	quadGroups := quads.PrimitiveGroups()
+	require.Len(t, quadGroups, 2)
+	assert.Equal(t, "top", quadGroups[1].Material)
+	assert.Equal(t, []int{12, 13, 14, 15}, quadGroups[1].Primitives)
+
+	triGroups := tris.PrimitiveGroups()
+	require.Len(t, triGroups, 2)
+	assert.Equal(t, "top", triGroups[1].Material)
+	assert.Len(t, triGroups[1].Primitives, 16*2)
+	for _, p := range triGroups[1].Primitives {
+		tri := tris.Tri(p)
+		for _, v := range []int{tri.P1(), tri.P2(), tri.P3()} {
+			assert.Greater(t, tris.Float3Attribute(modeling.PositionAttribute).At(v).Y(), 0.)
+		}
+	}
+}
@@ -9,6 +9,30 @@ import (
 	"github.com/EliCDavis/vector/vector3"
 )
 
+// pointInTriangle determines whether a point lying in the triangle's plane
+// falls within it, using the point's barycentric coordinates. Points along
+// the line of an edge but past the end of it are correctly left out.
+func pointInTriangle(a, b, c, p vector3.Float64) bool {
+	v0 := c.Sub(a)
+	v1 := b.Sub(a)
+	v2 := p.Sub(a)
+
+	dot00 := v0.Dot(v0)
+	dot01 := v0.Dot(v1)
+	dot02 := v0.Dot(v2)
+	dot11 := v1.Dot(v1)
+	dot12 := v1.Dot(v2)
+
+	denom := (dot00 * dot11) - (dot01 * dot01)
+	if denom == 0 {
+		return false
+	}
+
+	u := ((dot11 * dot02) - (dot01 * dot12)) / denom
+	v := ((dot00 * dot12) - (dot01 * dot02)) / denom
+	return u >= 0 && v >= 0 && u+v <= 1
+}
+
 type scopedTri struct {
 	data  []vector3.Float64
 	p1    int
@@ -29,30 +53,8 @@ func (t scopedTri) Plane() geometry.Plane {
 	return *t.plane
 }
 
-// https://gdbooks.gitbooks.io/3dcollisions/content/Chapter4/point_in_triangle.html
 func (t scopedTri) PointInSide(p vector3.Float64) bool {
-	// Move the triangle so that the point becomes the
-	// triangles origin
-	a := t.data[t.p1].Sub(p)
-	b := t.data[t.p2].Sub(p)
-	c := t.data[t.p3].Sub(p)
-
-	// Compute the normal vectors for triangles:
-	// u = normal of PBC
-	// v = normal of PCA
-	// w = normal of PAB
-
-	u := b.Cross(c)
-	v := c.Cross(a)
-
-	// Test to see if the normals are facing
-	// the same direction, return false if not
-	if u.Dot(v) < 0. {
-		return false
-	}
-
-	w := a.Cross(b)
-	return u.Dot(w) >= 0.
+	return pointInTriangle(t.data[t.p1], t.data[t.p2], t.data[t.p3], p)
 }
 
 func (t scopedTri) ClosestPoint(p vector3.Float64) vector3.Float64 {
@@ -253,30 +255,13 @@ func (t Tri) RayIntersects(ray geometry.Ray) (vector3.Float64, bool) {
 	return ray.At(tVal), true
 }
 
-// https://gdbooks.gitbooks.io/3dcollisions/content/Chapter4/point_in_triangle.html
 func (t Tri) PointInSide(p vector3.Float64) bool {
-	// Move the triangle so that the point becomes the
-	// triangles origin
-	a := t.P1Vec3Attr(PositionAttribute).Sub(p)
-	b := t.P2Vec3Attr(PositionAttribute).Sub(p)
-	c := t.P3Vec3Attr(PositionAttribute).Sub(p)
-
-	// Compute the normal vectors for triangles:
-	// u = normal of PBC
-	// v = normal of PCA
-	// w = normal of PAB
-
-	u := b.Cross(c)
-	v := c.Cross(a)
-
-	// Test to see if the normals are facing
-	// the same direction, return false if not
-	if u.Dot(v) < 0. {
-		return false
-	}
-
-	w := a.Cross(b)
-	return u.Dot(w) >= 0.
+	return pointInTriangle(
+		t.P1Vec3Attr(PositionAttribute),
+		t.P2Vec3Attr(PositionAttribute),
+		t.P3Vec3Attr(PositionAttribute),
+		p,
+	)
 }
 
 func (t Tri) LineIntersects(line geometry.Line3D) (vector3.Float64, bool) {
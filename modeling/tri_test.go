@@ -25,6 +25,11 @@ func TestTri_PointInside(t *testing.T) {
 	assert.True(t, tri.PointInSide(vector3.New(.25, .25, 0.)))
 	assert.False(t, tri.PointInSide(vector3.New(-.25, .25, 0.)))
 	assert.False(t, tri.PointInSide(vector3.New(-.25, .25, .25)))
+
+	// Along the line of an edge, past its end
+	assert.False(t, tri.PointInSide(vector3.New(0., 2., 0.)))
+	assert.False(t, tri.PointInSide(vector3.New(-1., 0., 0.)))
+	assert.True(t, tri.PointInSide(vector3.New(0., .5, 0.)))
 }
 
 func TestTri_LineIntersects(t *testing.T) {
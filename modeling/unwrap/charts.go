@@ -0,0 +1,151 @@
+package unwrap
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// surface is the connectivity of a triangle mesh, built from its position
+// welded vertices so meshes already split along seams stay connected
+type surface struct {
+	positions []vector3.Float64
+
+	// welded vertex index for every one of the mesh's vertices
+	welded []int
+
+	// mesh vertex indices making up each triangle
+	triangles [][3]int
+
+	normals []vector3.Float64
+	areas   []float64
+
+	// triangles found on either side of each welded edge
+	edges map[modeling.Edge][]int
+}
+
+func newSurface(m modeling.Mesh) *surface {
+	positions := m.Float3Attribute(modeling.PositionAttribute)
+
+	s := &surface{
+		positions: make([]vector3.Float64, positions.Len()),
+		triangles: make([][3]int, 0, m.PrimitiveCount()),
+		normals:   make([]vector3.Float64, 0, m.PrimitiveCount()),
+		areas:     make([]float64, 0, m.PrimitiveCount()),
+		edges:     make(map[modeling.Edge][]int),
+	}
+
+	for i := range s.positions {
+		s.positions[i] = positions.At(i)
+	}
+	s.welded = modeling.WeldPositions(s.positions)
+
+	indices := m.Indices()
+	for i := 0; i < indices.Len(); i += 3 {
+		tri := [3]int{indices.At(i), indices.At(i + 1), indices.At(i + 2)}
+		p0, p1, p2 := s.positions[tri[0]], s.positions[tri[1]], s.positions[tri[2]]
+		cross := p1.Sub(p0).Cross(p2.Sub(p0))
+		length := cross.Length()
+
+		normal := vector3.Zero[float64]()
+		if length > 0 {
+			normal = cross.DivByConstant(length)
+		}
+
+		t := len(s.triangles)
+		s.triangles = append(s.triangles, tri)
+		s.normals = append(s.normals, normal)
+		s.areas = append(s.areas, length/2)
+
+		for c := 0; c < 3; c++ {
+			e := modeling.NewEdge(s.welded[tri[c]], s.welded[tri[(c+1)%3]])
+			s.edges[e] = append(s.edges[e], t)
+		}
+	}
+
+	return s
+}
+
+// seam determines whether the edge should be cut. Boundaries and
+// non-manifold edges are always seams, otherwise the edge is cut when the
+// faces on either side of it meet at too sharp of an angle.
+func (s *surface) seam(e modeling.Edge, maxAngle float64) bool {
+	tris := s.edges[e]
+	if len(tris) != 2 {
+		return true
+	}
+
+	cos := s.normals[tris[0]].Dot(s.normals[tris[1]])
+	return math.Acos(math.Max(-1, math.Min(1, cos))) > maxAngle
+}
+
+// Seams returns every edge of the mesh that should be cut while unwrapping,
+// as pairs of vertex indices. Vertices that share the same position are
+// considered the same vertex, and are reported using the lowest index.
+func Seams(m modeling.Mesh, params Parameters) ([][2]int, error) {
+	if err := validate(m); err != nil {
+		return nil, err
+	}
+
+	params = params.withDefaults()
+	s := newSurface(m)
+	seams := make([][2]int, 0)
+	for e := range s.edges {
+		if s.seam(e, params.SeamAngle) {
+			seams = append(seams, [2]int{e.A, e.B})
+		}
+	}
+	return seams, nil
+}
+
+// charts segments the surface into groups of triangles that can be
+// flattened with little distortion. Charts are grown outward from a seed
+// triangle, never crossing a seam, and only taking on triangles whose normal
+// stays within the max chart angle of the seed's. Keeping every normal within
+// a cone like this prevents a chart from ever closing in on itself.
+func (s *surface) charts(params Parameters) [][]int {
+	minDot := math.Cos(params.MaxChartAngle)
+	assigned := make([]bool, len(s.triangles))
+	charts := make([][]int, 0)
+
+	for seed := range s.triangles {
+		if assigned[seed] {
+			continue
+		}
+
+		assigned[seed] = true
+		seedNormal := s.normals[seed]
+		chart := []int{seed}
+		for next := 0; next < len(chart); next++ {
+			tri := s.triangles[chart[next]]
+			for c := 0; c < 3; c++ {
+				e := modeling.NewEdge(s.welded[tri[c]], s.welded[tri[(c+1)%3]])
+				if s.seam(e, params.SeamAngle) {
+					continue
+				}
+
+				for _, neighbor := range s.edges[e] {
+					if assigned[neighbor] || s.normals[neighbor].Dot(seedNormal) < minDot {
+						continue
+					}
+					assigned[neighbor] = true
+					chart = append(chart, neighbor)
+				}
+			}
+		}
+
+		charts = append(charts, chart)
+	}
+
+	return charts
+}
+
+// Charts segments the mesh into the groups of triangles that are flattened
+// together while unwrapping. Each chart is a list of triangle indices.
+func Charts(m modeling.Mesh, params Parameters) ([][]int, error) {
+	if err := validate(m); err != nil {
+		return nil, err
+	}
+	return newSurface(m).charts(params.withDefaults()), nil
+}
@@ -0,0 +1,243 @@
+package unwrap
+
+import (
+	"math"
+
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// planeAxes builds two orthonormal axes spanning the plane with the normal
+// provided
+func planeAxes(normal vector3.Float64) (vector3.Float64, vector3.Float64) {
+	if normal.Length() == 0 {
+		normal = vector3.Up[float64]()
+	}
+
+	// Cross with whichever world axis is least aligned with the normal
+	reference := vector3.Right[float64]()
+	if math.Abs(normal.X()) > 0.9 {
+		reference = vector3.Up[float64]()
+	}
+
+	u := reference.Cross(normal).Normalized()
+	v := normal.Cross(u).Normalized()
+	return u, v
+}
+
+type sparseEntry struct {
+	column int
+	value  float64
+}
+
+// sparseSystem is an overdetermined linear system Ax = b, solved in the
+// least squares sense
+type sparseSystem struct {
+	rows    [][]sparseEntry
+	b       []float64
+	columns int
+}
+
+func (s sparseSystem) mul(x []float64) []float64 {
+	out := make([]float64, len(s.rows))
+	for r, row := range s.rows {
+		sum := 0.
+		for _, e := range row {
+			sum += e.value * x[e.column]
+		}
+		out[r] = sum
+	}
+	return out
+}
+
+func (s sparseSystem) mulTranspose(y []float64) []float64 {
+	out := make([]float64, s.columns)
+	for r, row := range s.rows {
+		for _, e := range row {
+			out[e.column] += e.value * y[r]
+		}
+	}
+	return out
+}
+
+func dot(a, b []float64) float64 {
+	sum := 0.
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// solve runs conjugate gradient on the normal equations (CGLS), starting
+// from the initial guess provided
+func (s sparseSystem) solve(x []float64, maxIterations int, tolerance float64) []float64 {
+	ax := s.mul(x)
+	r := make([]float64, len(s.b))
+	for i := range r {
+		r[i] = s.b[i] - ax[i]
+	}
+
+	g := s.mulTranspose(r)
+	p := append([]float64(nil), g...)
+	gamma := dot(g, g)
+	threshold := tolerance * tolerance * math.Max(gamma, 1e-30)
+
+	for iteration := 0; iteration < maxIterations && gamma > threshold; iteration++ {
+		q := s.mul(p)
+		qq := dot(q, q)
+		if qq == 0 {
+			break
+		}
+
+		alpha := gamma / qq
+		for i := range x {
+			x[i] += alpha * p[i]
+		}
+		for i := range r {
+			r[i] -= alpha * q[i]
+		}
+
+		g = s.mulTranspose(r)
+		newGamma := dot(g, g)
+		beta := newGamma / gamma
+		gamma = newGamma
+		for i := range p {
+			p[i] = g[i] + beta*p[i]
+		}
+	}
+
+	return x
+}
+
+// project flattens the chart by projecting it onto the plane perpendicular
+// to the normal provided
+func project(positions []vector3.Float64, normal vector3.Float64) []vector2.Float64 {
+	u, v := planeAxes(normal)
+	out := make([]vector2.Float64, len(positions))
+	for i, p := range positions {
+		out[i] = vector2.New(p.Dot(u), p.Dot(v))
+	}
+	return out
+}
+
+// lscm computes a least squares conformal map of the chart, as described in
+// "Least Squares Conformal Maps for Automatic Texture Atlas Generation" by
+// Lévy et al. The triangles index into the positions provided. The
+// projection is used both as the initial guess, and to decide where the
+// two pinned vertices required to make the solution unique are placed.
+func lscm(positions []vector3.Float64, triangles [][3]int, projection []vector2.Float64) []vector2.Float64 {
+	if len(positions) < 3 {
+		return projection
+	}
+
+	// Pin the two vertices furthest apart along the projection's widest axis
+	lo, hi := projection[0], projection[0]
+	for _, p := range projection {
+		lo = vector2.New(math.Min(lo.X(), p.X()), math.Min(lo.Y(), p.Y()))
+		hi = vector2.New(math.Max(hi.X(), p.X()), math.Max(hi.Y(), p.Y()))
+	}
+	axis := 0
+	if hi.Y()-lo.Y() > hi.X()-lo.X() {
+		axis = 1
+	}
+
+	pinA, pinB := 0, 0
+	for i, p := range projection {
+		if p.Component(axis) < projection[pinA].Component(axis) {
+			pinA = i
+		}
+		if p.Component(axis) > projection[pinB].Component(axis) {
+			pinB = i
+		}
+	}
+	if pinA == pinB {
+		return projection
+	}
+
+	// Map each free vertex to the two columns of its u and v components
+	columns := make([]int, len(positions))
+	free := 0
+	for i := range positions {
+		if i == pinA || i == pinB {
+			columns[i] = -1
+			continue
+		}
+		columns[i] = free
+		free += 2
+	}
+
+	system := sparseSystem{columns: free}
+	for _, tri := range triangles {
+		p0, p1, p2 := positions[tri[0]], positions[tri[1]], positions[tri[2]]
+
+		// Lay the triangle flat within its own plane
+		e1 := p1.Sub(p0)
+		length := e1.Length()
+		normal := e1.Cross(p2.Sub(p0))
+		twiceArea := normal.Length()
+		if length == 0 || twiceArea < 1e-14 {
+			continue
+		}
+		e1 = e1.DivByConstant(length)
+		e2 := normal.DivByConstant(twiceArea).Cross(e1)
+		local := [3]vector2.Float64{
+			vector2.Zero[float64](),
+			vector2.New(length, 0),
+			vector2.New(p2.Sub(p0).Dot(e1), p2.Sub(p0).Dot(e2)),
+		}
+
+		// Cauchy-Riemann equations for the linear map across the triangle,
+		// weighted by the triangle's area:
+		//   du/dx - dv/dy = 0
+		//   du/dy + dv/dx = 0
+		scale := 1 / math.Sqrt(twiceArea)
+		realRow := make([]sparseEntry, 0, 6)
+		imaginaryRow := make([]sparseEntry, 0, 6)
+		realB, imaginaryB := 0., 0.
+		for j := 0; j < 3; j++ {
+			next, prev := local[(j+1)%3], local[(j+2)%3]
+			a := (next.Y() - prev.Y()) * scale
+			b := (prev.X() - next.X()) * scale
+
+			vertex := tri[j]
+			if column := columns[vertex]; column != -1 {
+				realRow = append(realRow, sparseEntry{column, a}, sparseEntry{column + 1, -b})
+				imaginaryRow = append(imaginaryRow, sparseEntry{column, b}, sparseEntry{column + 1, a})
+				continue
+			}
+
+			pinned := projection[vertex]
+			realB -= a*pinned.X() - b*pinned.Y()
+			imaginaryB -= b*pinned.X() + a*pinned.Y()
+		}
+
+		system.rows = append(system.rows, realRow, imaginaryRow)
+		system.b = append(system.b, realB, imaginaryB)
+	}
+
+	x := make([]float64, free)
+	for i, column := range columns {
+		if column == -1 {
+			continue
+		}
+		x[column] = projection[i].X()
+		x[column+1] = projection[i].Y()
+	}
+
+	x = system.solve(x, min(free*2, 10_000)+100, 1e-10)
+
+	out := make([]vector2.Float64, len(positions))
+	for i, column := range columns {
+		if column == -1 {
+			out[i] = projection[i]
+			continue
+		}
+
+		uv := vector2.New(x[column], x[column+1])
+		if math.IsNaN(uv.X()) || math.IsNaN(uv.Y()) || math.IsInf(uv.X(), 0) || math.IsInf(uv.Y(), 0) {
+			return projection
+		}
+		out[i] = uv
+	}
+	return out
+}
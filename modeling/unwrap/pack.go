@@ -0,0 +1,123 @@
+package unwrap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/vector/vector2"
+)
+
+// orient rotates the chart in place so its principal axis runs along u,
+// with the chart's bounds starting at the origin. The chart's resulting
+// width and height are returned, with the width always being the larger of
+// the two.
+func orient(uvs []vector2.Float64) (float64, float64) {
+	if len(uvs) == 0 {
+		return 0, 0
+	}
+
+	center := vector2.Zero[float64]()
+	for _, uv := range uvs {
+		center = center.Add(uv)
+	}
+	center = center.DivByConstant(float64(len(uvs)))
+
+	// Principal axis of the 2x2 covariance matrix
+	xx, xy, yy := 0., 0., 0.
+	for _, uv := range uvs {
+		d := uv.Sub(center)
+		xx += d.X() * d.X()
+		xy += d.X() * d.Y()
+		yy += d.Y() * d.Y()
+	}
+	angle := 0.5 * math.Atan2(2*xy, xx-yy)
+	cos, sin := math.Cos(-angle), math.Sin(-angle)
+
+	lo := vector2.Fill(math.Inf(1))
+	hi := vector2.Fill(math.Inf(-1))
+	for i, uv := range uvs {
+		d := uv.Sub(center)
+		rotated := vector2.New(d.X()*cos-d.Y()*sin, d.X()*sin+d.Y()*cos)
+		uvs[i] = rotated
+		lo = vector2.New(math.Min(lo.X(), rotated.X()), math.Min(lo.Y(), rotated.Y()))
+		hi = vector2.New(math.Max(hi.X(), rotated.X()), math.Max(hi.Y(), rotated.Y()))
+	}
+
+	size := hi.Sub(lo)
+	swap := size.Y() > size.X()
+	for i, uv := range uvs {
+		uv = uv.Sub(lo)
+		if swap {
+			// Rotate a quarter turn, keeping the winding intact
+			uv = vector2.New(size.Y()-uv.Y(), uv.X())
+		}
+		uvs[i] = uv
+	}
+
+	if swap {
+		return size.Y(), size.X()
+	}
+	return size.X(), size.Y()
+}
+
+// pack lays every chart out within the unit square using shelf packing,
+// tallest charts first. Charts keep their relative scale to one another.
+// Padding is the space left around each chart, as a fraction of the atlas'
+// size.
+func pack(charts [][]vector2.Float64, padding float64) {
+	if len(charts) == 0 {
+		return
+	}
+
+	widths := make([]float64, len(charts))
+	heights := make([]float64, len(charts))
+	totalArea := 0.
+	for i, chart := range charts {
+		widths[i], heights[i] = orient(chart)
+		totalArea += widths[i] * heights[i]
+	}
+
+	// Atlases come out roughly square, so the square root of the area
+	// covered is a fair estimate of the atlas' final size
+	pad := padding * math.Sqrt(totalArea)
+
+	order := make([]int, len(charts))
+	targetWidth := 0.
+	paddedArea := 0.
+	for i := range order {
+		order[i] = i
+		targetWidth = math.Max(targetWidth, widths[i]+pad)
+		paddedArea += (widths[i] + pad) * (heights[i] + pad)
+	}
+	targetWidth = math.Max(targetWidth, math.Sqrt(paddedArea))
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return heights[order[i]] > heights[order[j]]
+	})
+
+	x, y, shelfHeight, atlasWidth := pad, pad, 0., 0.
+	offsets := make([]vector2.Float64, len(charts))
+	for _, c := range order {
+		if x > pad && x+widths[c] > targetWidth {
+			x = pad
+			y += shelfHeight + pad
+			shelfHeight = 0
+		}
+
+		offsets[c] = vector2.New(x, y)
+		x += widths[c] + pad
+		atlasWidth = math.Max(atlasWidth, x)
+		shelfHeight = math.Max(shelfHeight, heights[c])
+	}
+
+	size := math.Max(atlasWidth, y+shelfHeight+pad)
+	if size == 0 {
+		size = 1
+	}
+
+	for c, chart := range charts {
+		for i, uv := range chart {
+			chart[i] = uv.Add(offsets[c]).DivByConstant(size)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package unwrap
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EliCDavis/iter"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+const (
+	DefaultSeamAngle     = math.Pi / 3
+	DefaultMaxChartAngle = math.Pi * 0.4
+	DefaultPadding       = 0.005
+)
+
+type Parameters struct {
+	// Edges where the faces on either side meet at an angle greater than
+	// this (in radians) are cut into seams. Defaults to DefaultSeamAngle
+	// when 0 or less.
+	SeamAngle float64
+
+	// A face only joins a chart if its normal is within this angle (in
+	// radians) of the normal of the face the chart was grown from. Defaults
+	// to DefaultMaxChartAngle when 0 or less.
+	MaxChartAngle float64
+
+	// Space left around each chart within the atlas, as a fraction of the
+	// atlas' size. Defaults to DefaultPadding when 0, and negative values
+	// disable padding entirely.
+	Padding float64
+
+	// Attribute the UVs are written to. Defaults to
+	// modeling.TexCoordAttribute when empty.
+	Attribute string
+}
+
+func (p Parameters) withDefaults() Parameters {
+	if p.SeamAngle <= 0 {
+		p.SeamAngle = DefaultSeamAngle
+	}
+
+	if p.MaxChartAngle <= 0 {
+		p.MaxChartAngle = DefaultMaxChartAngle
+	}
+
+	if p.Padding == 0 {
+		p.Padding = DefaultPadding
+	} else if p.Padding < 0 {
+		p.Padding = 0
+	}
+
+	if p.Attribute == "" {
+		p.Attribute = modeling.TexCoordAttribute
+	}
+
+	return p
+}
+
+func validate(m modeling.Mesh) error {
+	if m.Topology() != modeling.TriangleTopology {
+		return fmt.Errorf("uv unwrapping requires a triangle topology, received: %s", m.Topology().String())
+	}
+
+	if m.PrimitiveCount() > 0 && !m.HasFloat3Attribute(modeling.PositionAttribute) {
+		return fmt.Errorf("uv unwrapping requires the mesh to have the vector3 attribute: '%s'", modeling.PositionAttribute)
+	}
+
+	return nil
+}
+
+// flatten parameterizes the chart, returning the welded vertices the chart
+// is made of alongside their UVs. Charts are scaled so their area in UV
+// space matches their area on the surface, giving every chart the same
+// texel density once packed.
+func (s *surface) flatten(chart []int) ([]int, []vector2.Float64) {
+	local := make(map[int]int)
+	vertices := make([]int, 0)
+	triangles := make([][3]int, len(chart))
+	normal := vector3.Zero[float64]()
+	area := 0.
+
+	for i, t := range chart {
+		for c, v := range s.triangles[t] {
+			welded := s.welded[v]
+			index, ok := local[welded]
+			if !ok {
+				index = len(vertices)
+				local[welded] = index
+				vertices = append(vertices, welded)
+			}
+			triangles[i][c] = index
+		}
+		normal = normal.Add(s.normals[t].Scale(s.areas[t]))
+		area += s.areas[t]
+	}
+
+	positions := make([]vector3.Float64, len(vertices))
+	for i, v := range vertices {
+		positions[i] = s.positions[v]
+	}
+
+	if normal.Length() > 0 {
+		normal = normal.Normalized()
+	}
+	uvs := lscm(positions, triangles, project(positions, normal))
+
+	// Conformal maps are free to flip the chart over, so make sure the
+	// chart keeps the winding of the surface
+	uvArea := 0.
+	for _, tri := range triangles {
+		a, b, c := uvs[tri[0]], uvs[tri[1]], uvs[tri[2]]
+		uvArea += (b.X()-a.X())*(c.Y()-a.Y()) - (c.X()-a.X())*(b.Y()-a.Y())
+	}
+	uvArea /= 2
+
+	if uvArea < 0 {
+		for i, uv := range uvs {
+			uvs[i] = vector2.New(-uv.X(), uv.Y())
+		}
+		uvArea = -uvArea
+	}
+
+	if uvArea > 0 && area > 0 {
+		scale := math.Sqrt(area / uvArea)
+		for i, uv := range uvs {
+			uvs[i] = uv.Scale(scale)
+		}
+	}
+
+	return vertices, uvs
+}
+
+func gather[T any](data *iter.ArrayIterator[T], sources []int) []T {
+	out := make([]T, len(sources))
+	for i, source := range sources {
+		out[i] = data.At(source)
+	}
+	return out
+}
+
+// Unwrap generates a texture atlas for the mesh. The mesh is cut along
+// seams into charts (see Seams and Charts), each chart is flattened with a
+// least squares conformal map, and the charts are packed together into the
+// unit square.
+//
+// Vertices along the seams are duplicated, one for every chart they belong
+// to, with all other attributes copied over to the duplicates. The UVs are
+// written to the attribute specified by the parameters, replacing any
+// existing data.
+func Unwrap(m modeling.Mesh, params Parameters) (modeling.Mesh, error) {
+	if err := validate(m); err != nil {
+		return m, err
+	}
+
+	params = params.withDefaults()
+	if m.PrimitiveCount() == 0 {
+		return m, nil
+	}
+
+	s := newSurface(m)
+	charts := s.charts(params)
+
+	type chartVertex struct {
+		vertex int
+		chart  int
+	}
+
+	chartUVs := make([][]vector2.Float64, len(charts))
+	chartLookups := make([]map[int]int, len(charts))
+	for c, chart := range charts {
+		vertices, uvs := s.flatten(chart)
+		chartUVs[c] = uvs
+
+		lookup := make(map[int]int, len(vertices))
+		for i, v := range vertices {
+			lookup[v] = i
+		}
+		chartLookups[c] = lookup
+	}
+
+	pack(chartUVs, params.Padding)
+
+	remapped := make(map[chartVertex]int)
+	sources := make([]int, 0)
+	uvs := make([]vector2.Float64, 0)
+	indices := make([]int, len(s.triangles)*3)
+	for c, chart := range charts {
+		for _, t := range chart {
+			for corner, v := range s.triangles[t] {
+				key := chartVertex{vertex: v, chart: c}
+				index, ok := remapped[key]
+				if !ok {
+					index = len(sources)
+					remapped[key] = index
+					sources = append(sources, v)
+					uvs = append(uvs, chartUVs[c][chartLookups[c][s.welded[v]]])
+				}
+				indices[(t*3)+corner] = index
+			}
+		}
+	}
+
+	result := modeling.NewTriangleMesh(indices).
+		RemapIntAttributes(m, sources).
+		CopyPrimitiveGroups(m)
+	for _, attr := range m.Float4Attributes() {
+		result = result.SetFloat4Attribute(attr, gather(m.Float4Attribute(attr), sources))
+	}
+	for _, attr := range m.Float3Attributes() {
+		result = result.SetFloat3Attribute(attr, gather(m.Float3Attribute(attr), sources))
+	}
+	for _, attr := range m.Float2Attributes() {
+		if attr == params.Attribute {
+			continue
+		}
+		result = result.SetFloat2Attribute(attr, gather(m.Float2Attribute(attr), sources))
+	}
+	for _, attr := range m.Float1Attributes() {
+		result = result.SetFloat1Attribute(attr, gather(m.Float1Attribute(attr), sources))
+	}
+
+	return result.SetFloat2Attribute(params.Attribute, uvs), nil
+}
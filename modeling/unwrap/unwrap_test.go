@@ -0,0 +1,149 @@
+package unwrap_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/modeling/primitives"
+	"github.com/EliCDavis/polyform/modeling/unwrap"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grid builds a flat, y-up plane made of size x size quads
+func grid(size int) modeling.Mesh {
+	positions := make([]vector3.Float64, 0)
+	for z := 0; z <= size; z++ {
+		for x := 0; x <= size; x++ {
+			positions = append(positions, vector3.New(float64(x), 0, float64(z)))
+		}
+	}
+
+	indices := make([]int, 0)
+	for z := 0; z < size; z++ {
+		for x := 0; x < size; x++ {
+			bl := z*(size+1) + x
+			br := bl + 1
+			tl := bl + size + 1
+			tr := tl + 1
+			indices = append(indices, bl, tl, tr, bl, tr, br)
+		}
+	}
+
+	return modeling.NewTriangleMesh(indices).
+		SetFloat3Attribute(modeling.PositionAttribute, positions)
+}
+
+func uvArea(tri modeling.Tri, attr string) float64 {
+	a := tri.P1Vec2Attr(attr)
+	b := tri.P2Vec2Attr(attr)
+	c := tri.P3Vec2Attr(attr)
+	return ((b.X()-a.X())*(c.Y()-a.Y()) - (c.X()-a.X())*(b.Y()-a.Y())) / 2
+}
+
+func assertValidAtlas(t *testing.T, m modeling.Mesh) {
+	t.Helper()
+	require.True(t, m.HasFloat2Attribute(modeling.TexCoordAttribute))
+
+	m.ScanFloat2Attribute(modeling.TexCoordAttribute, func(i int, uv vector2.Float64) {
+		assert.GreaterOrEqual(t, uv.X(), 0.)
+		assert.LessOrEqual(t, uv.X(), 1.)
+		assert.GreaterOrEqual(t, uv.Y(), 0.)
+		assert.LessOrEqual(t, uv.Y(), 1.)
+	})
+
+	total := 0.
+	m.ScanPrimitives(func(i int, p modeling.Primitive) {
+		area := uvArea(p.(modeling.Tri), modeling.TexCoordAttribute)
+		assert.Greater(t, area, 0.)
+		total += area
+	})
+
+	// Charts can't overlap one another, so they can't cover more than the
+	// atlas itself
+	assert.LessOrEqual(t, total, 1.)
+}
+
+func TestUnwrap_Plane(t *testing.T) {
+	// ARRANGE ================================================================
+	plane := grid(8)
+
+	// ACT ====================================================================
+	unwrapped, err := unwrap.Unwrap(plane, unwrap.Parameters{})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assertValidAtlas(t, unwrapped)
+
+	// A plane is a single chart, so no vertices need splitting
+	assert.Equal(t, plane.PrimitiveCount(), unwrapped.PrimitiveCount())
+	assert.Equal(t, 81, unwrapped.Float3Attribute(modeling.PositionAttribute).Len())
+
+	// Flattening something already flat shouldn't introduce any distortion
+	var scale float64
+	unwrapped.ScanPrimitives(func(i int, p modeling.Primitive) {
+		tri := p.(modeling.Tri)
+		length := tri.P1Vec3Attr(modeling.PositionAttribute).Distance(tri.P2Vec3Attr(modeling.PositionAttribute))
+		uvLength := tri.P1Vec2Attr(modeling.TexCoordAttribute).Distance(tri.P2Vec2Attr(modeling.TexCoordAttribute))
+		if i == 0 {
+			scale = uvLength / length
+		}
+		assert.InDelta(t, scale, uvLength/length, 1e-6)
+	})
+}
+
+func TestUnwrap_Cube(t *testing.T) {
+	// ARRANGE ================================================================
+	cube := primitives.UnitCube()
+
+	// ACT ====================================================================
+	seams, seamErr := unwrap.Seams(cube, unwrap.Parameters{})
+	charts, chartErr := unwrap.Charts(cube, unwrap.Parameters{})
+	unwrapped, err := unwrap.Unwrap(cube, unwrap.Parameters{})
+
+	// ASSERT =================================================================
+	require.NoError(t, seamErr)
+	require.NoError(t, chartErr)
+	require.NoError(t, err)
+
+	assert.Len(t, seams, 12)
+	assert.Len(t, charts, 6)
+	assertValidAtlas(t, unwrapped)
+
+	// Each of the cube's 8 corners is shared by 3 faces
+	assert.Equal(t, 24, unwrapped.Float3Attribute(modeling.PositionAttribute).Len())
+	assert.Equal(t, cube.PrimitiveCount(), unwrapped.PrimitiveCount())
+}
+
+func TestUnwrap_Sphere(t *testing.T) {
+	// ARRANGE ================================================================
+	sphere := primitives.UVSphere(1, 16, 16)
+
+	// ACT ====================================================================
+	unwrapped, err := unwrap.Unwrap(sphere, unwrap.Parameters{
+		Attribute: "atlas",
+		Padding:   -1,
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.True(t, unwrapped.HasFloat2Attribute("atlas"))
+	assert.Equal(t, sphere.PrimitiveCount(), unwrapped.PrimitiveCount())
+
+	// Existing attributes are carried over to split vertices
+	require.True(t, unwrapped.HasFloat3Attribute(modeling.NormalAttribute))
+	unwrapped.ScanFloat3Attribute(modeling.NormalAttribute, func(i int, n vector3.Float64) {
+		assert.InDelta(t, 1., n.Length(), 1e-6)
+	})
+
+	unwrapped.ScanPrimitives(func(i int, p modeling.Primitive) {
+		assert.Greater(t, uvArea(p.(modeling.Tri), "atlas"), 0.)
+	})
+}
+
+func TestUnwrap_RequiresTriangles(t *testing.T) {
+	_, err := unwrap.Unwrap(modeling.EmptyPointcloud(), unwrap.Parameters{})
+	assert.EqualError(t, err, "uv unwrapping requires a triangle topology, received: point")
+}
@@ -0,0 +1,93 @@
+package nodes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EliCDavis/polyform/refutil"
+	"github.com/EliCDavis/polyform/utils"
+)
+
+// ErrorOutput is an output port that can fail to produce its value
+type ErrorOutput interface {
+	OutputPort
+
+	// Err evaluates the output if it's out of date, returning the error
+	// encountered while producing its value, if any
+	Err() error
+
+	// ErrContext is Err, but gives up on evaluating the output once the
+	// context is done. Cancelled evaluations are not cached.
+	ErrContext(ctx context.Context) error
+
+	// CachedErr returns the error encountered during the output's last
+	// evaluation without evaluating it again. Outputs that are out of date
+	// report no error.
+	CachedErr() error
+}
+
+// NodeError is the error produced by a node while evaluating one of its
+// output ports. It's passed along untouched to every node downstream of the
+// failing one, so it always references the node the problem originated from.
+type NodeError struct {
+	Node Node
+	Port string
+	Err  error
+}
+
+func (ne NodeError) Error() string {
+	name := refutil.GetTypeNameWithoutPackage(ne.Node)
+	if named, ok := ne.Node.(Named); ok {
+		name = named.Name()
+	}
+	return fmt.Sprintf("%s output %q: %s", name, ne.Port, ne.Err.Error())
+}
+
+func (ne NodeError) Unwrap() error {
+	return ne.Err
+}
+
+// OutputError evaluates the output port, returning the error encountered
+// while producing its value. Ports that can't fail always return nil.
+func OutputError(port OutputPort) error {
+	return OutputErrorContext(context.Background(), port)
+}
+
+// OutputErrorContext is OutputError, but stops evaluating the port once the
+// context is done, returning the context's error
+func OutputErrorContext(ctx context.Context, port OutputPort) error {
+	if errorOutput, ok := port.(ErrorOutput); ok {
+		return errorOutput.ErrContext(ctx)
+	}
+	return ctx.Err()
+}
+
+// inputError returns the first error found among the outputs connected to
+// the node's inputs, evaluating them if need be
+func inputError(ctx context.Context, node Node) error {
+	for _, input := range utils.SortMapByKey(node.Inputs()) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		switch v := input.Val.(type) {
+		case SingleValueInputPort:
+			if port := v.Value(); port != nil {
+				if err := OutputErrorContext(ctx, port); err != nil {
+					return err
+				}
+			}
+
+		case ArrayValueInputPort:
+			for _, port := range v.Value() {
+				if port == nil {
+					continue
+				}
+				if err := OutputErrorContext(ctx, port); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package nodes
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -90,6 +92,7 @@ type StructOutput[T any] struct {
 	node         Node
 	data         any
 	val          T
+	err          error
 	cache        *structOutputCache
 }
 
@@ -101,15 +104,76 @@ func (so StructOutput[T]) Node() Node {
 	return so.node
 }
 
-func (so *StructOutput[T]) Value() T {
-	var val StructOutput[T]
+func (so *StructOutput[T]) evaluate(ctx context.Context) StructOutput[T] {
 	if !so.cache.Outdated(so.functionName) {
-		val = so.cache.Get(so.functionName).(StructOutput[T])
-	} else {
-		val = refutil.CallStructMethod(so.data, so.functionName)[0].(StructOutput[T])
-		so.cache.Cache(so.functionName, val)
+		return so.cache.Get(so.functionName).(StructOutput[T])
+	}
+
+	val := so.call(ctx)
+
+	// Whatever went wrong might have been due to us being cancelled, so we
+	// leave the cache alone and try again the next time we're asked
+	if val.err != nil && ctx.Err() != nil {
+		return val
+	}
+
+	so.cache.Cache(so.functionName, val)
+	return val
+}
+
+// call runs the function that builds the output's value, capturing any
+// error or panic the function runs into. If any of the node's inputs have
+// failed, the function is never ran and their error is passed along instead.
+//
+// Functions that take a context as their only argument are handed it, so
+// long running nodes can check for cancellation.
+func (so *StructOutput[T]) call(ctx context.Context) (val StructOutput[T]) {
+	if err := inputError(ctx, so.node); err != nil {
+		val.err = err
+		return
+	}
+
+	defer func() {
+		if recErr := recover(); recErr != nil {
+			err, ok := recErr.(error)
+			if !ok {
+				err = fmt.Errorf("%v", recErr)
+			}
+			val = StructOutput[T]{
+				err: NodeError{Node: so.node, Port: so.displayName, Err: fmt.Errorf("panic: %w", err)},
+			}
+		}
+	}()
+
+	var args []any
+	if refutil.MethodTakes[context.Context](so.data, so.functionName) {
+		args = append(args, ctx)
+	}
+
+	val = refutil.CallStructMethod(so.data, so.functionName, args...)[0].(StructOutput[T])
+	if val.err != nil {
+		val.err = NodeError{Node: so.node, Port: so.displayName, Err: val.err}
 	}
-	return val.val
+	return
+}
+
+func (so *StructOutput[T]) Value() T {
+	return so.evaluate(context.Background()).val
+}
+
+func (so *StructOutput[T]) Err() error {
+	return so.evaluate(context.Background()).err
+}
+
+func (so *StructOutput[T]) ErrContext(ctx context.Context) error {
+	return so.evaluate(ctx).err
+}
+
+func (so *StructOutput[T]) CachedErr() error {
+	if so.cache.Outdated(so.functionName) {
+		return nil
+	}
+	return so.cache.Get(so.functionName).(StructOutput[T]).err
 }
 
 func (so StructOutput[T]) Version() int {
@@ -138,12 +202,14 @@ func (so *StructOutput[T]) Set(v T) {
 	so.val = v
 }
 
-func (so StructOutput[T]) LogError(err error) {
+// LogError records an error encountered while building the output. The
+// error is reported against the node and handed to every node downstream of
+// it in place of evaluating them.
+func (so *StructOutput[T]) LogError(err error) {
 	if err == nil {
 		return
 	}
-
-	// Do capture
+	so.err = errors.Join(so.err, err)
 }
 
 // <<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<<
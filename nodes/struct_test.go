@@ -1,6 +1,8 @@
 package nodes_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/EliCDavis/polyform/nodes"
@@ -239,3 +241,112 @@ func TestNodeInfo(t *testing.T) {
 		})
 	}
 }
+
+// ================================================================================================
+
+type ErrorTestStructNode = nodes.Struct[ErrorTestStruct]
+
+type ErrorTestStruct struct {
+	In nodes.Output[float64]
+}
+
+func (ets ErrorTestStruct) Logged() nodes.StructOutput[float64] {
+	out := nodes.NewStructOutput(ets.In.Value())
+	if ets.In.Value() < 0 {
+		out.LogError(errors.New("value can not be negative"))
+	}
+	return out
+}
+
+func (ets ErrorTestStruct) Panics() nodes.StructOutput[float64] {
+	if ets.In.Value() < 0 {
+		panic(errors.New("value can not be negative"))
+	}
+	return nodes.NewStructOutput(ets.In.Value())
+}
+
+func TestStruct_Errors(t *testing.T) {
+	for _, port := range []string{"Logged", "Panics"} {
+		t.Run(port, func(t *testing.T) {
+			// ARRANGE ========================================================
+			value := nodes.NewValue(-1.)
+			failing := &ErrorTestStructNode{
+				Data: ErrorTestStruct{
+					In: nodes.GetNodeOutputPort[float64](value, "Value"),
+				},
+			}
+			downstream := &ArrayTestStructNode{
+				Data: ArrayTestStruct{
+					Values: []nodes.Output[float64]{
+						nodes.NewValue(1.).Outputs()["Value"].(nodes.Output[float64]),
+						nodes.GetNodeOutputPort[float64](failing, port),
+					},
+				},
+			}
+			failingOut := nodes.GetNodeOutputPort[float64](failing, port).(nodes.ErrorOutput)
+			downstreamOut := nodes.GetNodeOutputPort[float64](downstream, "Sum")
+
+			// ACT ============================================================
+			cachedBefore := failingOut.CachedErr()
+			err := nodes.OutputError(downstreamOut)
+			cachedAfter := failingOut.CachedErr()
+			sum := downstreamOut.Value()
+			value.Set(2)
+			fixedErr := nodes.OutputError(downstreamOut)
+
+			// ASSERT =========================================================
+			assert.NoError(t, cachedBefore)
+
+			var nodeErr nodes.NodeError
+			require.ErrorAs(t, err, &nodeErr)
+			assert.Equal(t, failing, nodeErr.Node)
+			assert.Equal(t, port, nodeErr.Port)
+			assert.ErrorContains(t, err, "Error Test Struct output \""+port+"\": ")
+			assert.ErrorContains(t, err, "value can not be negative")
+			assert.Equal(t, err, cachedAfter)
+			assert.Equal(t, 0., sum)
+
+			assert.NoError(t, fixedErr)
+			assert.Equal(t, 3., downstreamOut.Value())
+		})
+	}
+}
+
+// ================================================================================================
+
+type ContextTestStructNode = nodes.Struct[ContextTestStruct]
+
+type ContextTestStruct struct {
+	In nodes.Output[float64]
+}
+
+func (cts ContextTestStruct) Out(ctx context.Context) nodes.StructOutput[float64] {
+	out := nodes.NewStructOutput(cts.In.Value())
+	out.LogError(ctx.Err())
+	return out
+}
+
+func TestStruct_Context(t *testing.T) {
+	// ARRANGE ================================================================
+	n := &ContextTestStructNode{
+		Data: ContextTestStruct{
+			In: nodes.NewValue(2.).Outputs()["Value"].(nodes.Output[float64]),
+		},
+	}
+	out := nodes.GetNodeOutputPort[float64](n, "Out")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT ====================================================================
+	cancelledErr := nodes.OutputErrorContext(ctx, out)
+	cachedErr := out.(nodes.ErrorOutput).CachedErr()
+	err := nodes.OutputErrorContext(context.Background(), out)
+
+	// ASSERT =================================================================
+	assert.ErrorIs(t, cancelledErr, context.Canceled)
+	assert.NoError(t, cachedErr, "cancelled evaluations should not be cached")
+	assert.NoError(t, err)
+	assert.Equal(t, 2., out.Value())
+	assert.Equal(t, 0, out.Version())
+}
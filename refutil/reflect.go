@@ -168,6 +168,18 @@ func HasMethod(in any, methodName string) bool {
 	return method != reflect.Value{}
 }
 
+// MethodTakes reports whether the method exists and takes a single argument
+// of exactly type T
+func MethodTakes[T any](in any, methodName string) bool {
+	method := reflect.ValueOf(in).MethodByName(methodName)
+	if (method == reflect.Value{}) {
+		return false
+	}
+
+	methodType := method.Type()
+	return methodType.NumIn() == 1 && methodType.In(0) == reflect.TypeFor[T]()
+}
+
 func CallStructMethod(in any, methodName string, args ...any) []any {
 	method := reflect.ValueOf(in).MethodByName(methodName)
 	bitch := reflect.Value{}
@@ -44,6 +44,14 @@ func TestFuncNamesOfType(t *testing.T) {
 	assert.Contains(t, v, "ABC")
 }
 
+func TestMethodTakes(t *testing.T) {
+	ts := TestStruct{}
+	assert.True(t, refutil.MethodTakes[[]byte](ts, "Read"))
+	assert.False(t, refutil.MethodTakes[string](ts, "Read"))
+	assert.False(t, refutil.MethodTakes[[]byte](ts, "ABC"))
+	assert.False(t, refutil.MethodTakes[[]byte](ts, "Missing"))
+}
+
 func TestFuncNamesOfType_Interface(t *testing.T) {
 	ts := TestStruct{}
 	var reader io.Reader = &ts
@@ -0,0 +1,126 @@
+package rendering
+
+import (
+	"math"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Light is a source of illumination without any surface for a ray to hit,
+// like the punctual lights found within glTF scenes. Surfaces are lit by
+// them directly, by casting a shadow ray towards each light.
+type Light interface {
+	// Illuminate returns the direction from the point towards the light, the
+	// distance to the light, and the radiance arriving at the point
+	Illuminate(point vector3.Float64) (direction vector3.Float64, distance float64, radiance vector3.Float64)
+}
+
+// LitMaterial is a material that lights can shine on directly
+type LitMaterial interface {
+	Material
+
+	// Shade returns the light reflected back along the incoming ray from the
+	// radiance arriving at the hit from the direction provided
+	Shade(in geometry.Ray, rec *HitRecord, direction, radiance vector3.Float64) vector3.Float64
+}
+
+// rangeFalloff smoothly brings a light's contribution to zero at its range,
+// as recommended by KHR_lights_punctual. Lights without a range fall off with
+// the inverse square of the distance alone.
+func rangeFalloff(distance, lightRange float64) float64 {
+	falloff := 1. / math.Max(distance*distance, 0.0001)
+	if lightRange <= 0 {
+		return falloff
+	}
+	window := math.Max(0, math.Min(1, 1-math.Pow(distance/lightRange, 4)))
+	return falloff * window
+}
+
+// PointLight emits light equally in all directions from a single point
+type PointLight struct {
+	position   vector3.Float64
+	color      vector3.Float64
+	intensity  float64
+	lightRange float64
+}
+
+// NewPointLight creates a light at the position provided. A range of zero
+// lets the light reach infinitely far.
+func NewPointLight(position, color vector3.Float64, intensity, lightRange float64) PointLight {
+	return PointLight{
+		position:   position,
+		color:      color,
+		intensity:  intensity,
+		lightRange: lightRange,
+	}
+}
+
+func (pl PointLight) Illuminate(point vector3.Float64) (vector3.Float64, float64, vector3.Float64) {
+	toLight := pl.position.Sub(point)
+	distance := toLight.Length()
+	radiance := pl.color.Scale(pl.intensity * rangeFalloff(distance, pl.lightRange))
+	return toLight.DivByConstant(distance), distance, radiance
+}
+
+// DirectionalLight emits parallel rays of light from infinitely far away,
+// like the sun
+type DirectionalLight struct {
+	direction vector3.Float64
+	color     vector3.Float64
+	intensity float64
+}
+
+// NewDirectionalLight creates a light whose rays travel in the direction
+// provided
+func NewDirectionalLight(direction, color vector3.Float64, intensity float64) DirectionalLight {
+	return DirectionalLight{
+		direction: direction.Normalized(),
+		color:     color,
+		intensity: intensity,
+	}
+}
+
+func (dl DirectionalLight) Illuminate(point vector3.Float64) (vector3.Float64, float64, vector3.Float64) {
+	return dl.direction.Scale(-1), inf, dl.color.Scale(dl.intensity)
+}
+
+// SpotLight emits light from a single point within a cone, fading out
+// between the inner and outer cone angles
+type SpotLight struct {
+	position   vector3.Float64
+	direction  vector3.Float64
+	color      vector3.Float64
+	intensity  float64
+	lightRange float64
+	cosInner   float64
+	cosOuter   float64
+}
+
+// NewSpotLight creates a light at the position provided, pointing in the
+// direction provided. Cone angles are in radians, measured from the center
+// of the cone. A range of zero lets the light reach infinitely far.
+func NewSpotLight(position, direction, color vector3.Float64, intensity, lightRange, innerConeAngle, outerConeAngle float64) SpotLight {
+	return SpotLight{
+		position:   position,
+		direction:  direction.Normalized(),
+		color:      color,
+		intensity:  intensity,
+		lightRange: lightRange,
+		cosInner:   math.Cos(innerConeAngle),
+		cosOuter:   math.Cos(outerConeAngle),
+	}
+}
+
+func (sl SpotLight) Illuminate(point vector3.Float64) (vector3.Float64, float64, vector3.Float64) {
+	toLight := sl.position.Sub(point)
+	distance := toLight.Length()
+	direction := toLight.DivByConstant(distance)
+
+	cos := direction.Scale(-1).Dot(sl.direction)
+	cone := math.Max(0, math.Min(1, (cos-sl.cosOuter)/math.Max(sl.cosInner-sl.cosOuter, 0.0001)))
+	cone *= cone
+
+	radiance := sl.color.Scale(sl.intensity * cone * rangeFalloff(distance, sl.lightRange))
+	return direction, distance, radiance
+}
@@ -2,7 +2,6 @@ package materials
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/EliCDavis/polyform/math/geometry"
 	"github.com/EliCDavis/polyform/rendering"
@@ -18,7 +17,7 @@ type Lambertian struct {
 func NewLambertian(tex rendering.Texture) *Lambertian {
 	return &Lambertian{
 		tex: tex,
-		r:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		r:   newRand(),
 	}
 }
 
@@ -2,7 +2,6 @@ package materials
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/EliCDavis/polyform/math/geometry"
 	"github.com/EliCDavis/polyform/rendering"
@@ -20,7 +19,7 @@ func NewMetal(color vector3.Float64) Metal {
 	return Metal{
 		color: color,
 		fuzz:  0,
-		r:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		r:     newRand(),
 	}
 }
 
@@ -28,7 +27,7 @@ func NewFuzzyMetal(color vector3.Float64, fuzz float64) Metal {
 	return Metal{
 		color: color,
 		fuzz:  fuzz,
-		r:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		r:     newRand(),
 	}
 }
 
@@ -0,0 +1,223 @@
+package materials
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// PBRParameters describes a surface using the metallic-roughness model from
+// physically based rendering, as found within glTF
+type PBRParameters struct {
+	// Linear multiplier for the base color
+	BaseColor vector3.Float64
+
+	// Optional texture for the base color, encoded with the sRGB transfer
+	// function
+	BaseColorTexture rendering.Texture
+
+	Metallic  float64
+	Roughness float64
+
+	// Optional texture scaling metalness by its blue channel and roughness by
+	// its green channel, encoded linearly
+	MetallicRoughnessTexture rendering.Texture
+
+	// Optional tangent space normal map, encoded linearly. Requires the
+	// surface to have texture coordinates.
+	NormalTexture rendering.Texture
+	NormalScale   float64
+
+	// Linear color of the light emitted by the surface
+	Emissive vector3.Float64
+
+	// Unlit surfaces show their base color as is, without reacting to any
+	// light
+	Unlit bool
+}
+
+// PBR is a metallic-roughness material. Light reflecting off the surface is
+// split between a diffuse lobe tinted by the base color and a specular lobe
+// whose blurriness is controlled by roughness, weighted by Schlick's
+// approximation of fresnel.
+type PBR struct {
+	params PBRParameters
+	r      *rand.Rand
+}
+
+func NewPBR(params PBRParameters) PBR {
+	return PBR{
+		params: params,
+		r:      newRand(),
+	}
+}
+
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// surface is the material's properties at a specific point
+type surface struct {
+	normal    vector3.Float64
+	baseColor vector3.Float64
+	metallic  float64
+	roughness float64
+}
+
+// f0 is the reflectance of the surface when viewed head on
+func (s surface) f0() vector3.Float64 {
+	dielectric := vector3.Fill(0.04)
+	return dielectric.Scale(1 - s.metallic).Add(s.baseColor.Scale(s.metallic))
+}
+
+func (s surface) fresnel(cos float64) vector3.Float64 {
+	f0 := s.f0()
+	return f0.Add(vector3.One[float64]().Sub(f0).Scale(math.Pow(1-math.Max(cos, 0), 5)))
+}
+
+func (p PBR) baseColor(uv vector2.Float64, point vector3.Float64) vector3.Float64 {
+	if p.params.BaseColorTexture == nil {
+		return p.params.BaseColor
+	}
+
+	texel := p.params.BaseColorTexture.Value(uv, point)
+	return p.params.BaseColor.MultByVector(vector3.New(
+		srgbToLinear(texel.X()),
+		srgbToLinear(texel.Y()),
+		srgbToLinear(texel.Z()),
+	))
+}
+
+func (p PBR) surface(rec *rendering.HitRecord) surface {
+	s := surface{
+		normal:    rec.Normal,
+		baseColor: p.baseColor(rec.UV, rec.Point),
+		metallic:  p.params.Metallic,
+		roughness: p.params.Roughness,
+	}
+
+	if p.params.MetallicRoughnessTexture != nil {
+		texel := p.params.MetallicRoughnessTexture.Value(rec.UV, rec.Point)
+		s.metallic *= texel.Z()
+		s.roughness *= texel.Y()
+	}
+	s.roughness = math.Max(s.roughness, 0.02)
+
+	if p.params.NormalTexture != nil {
+		s.normal = p.mappedNormal(rec)
+	}
+
+	return s
+}
+
+// mappedNormal perturbs the surface normal by the normal texture, using the
+// tangent frame the hit surface derived from its texture coordinates
+func (p PBR) mappedNormal(rec *rendering.HitRecord) vector3.Float64 {
+	tangent, ok := rec.Float3Data["tangent"]
+	if !ok {
+		return rec.Normal
+	}
+	bitangent := rec.Float3Data["bitangent"]
+
+	n := rec.Normal
+	tangent = tangent.Sub(n.Scale(n.Dot(tangent)))
+	bitangent = bitangent.Sub(n.Scale(n.Dot(bitangent)))
+	if tangent.NearZero() || bitangent.NearZero() {
+		return n
+	}
+
+	texel := p.params.NormalTexture.Value(rec.UV, rec.Point).Scale(2).Sub(vector3.One[float64]())
+	mapped := tangent.Normalized().Scale(texel.X() * p.params.NormalScale).
+		Add(bitangent.Normalized().Scale(texel.Y() * p.params.NormalScale)).
+		Add(n.Scale(texel.Z()))
+
+	if mapped.NearZero() {
+		return n
+	}
+	return mapped.Normalized()
+}
+
+func (p PBR) Scatter(in geometry.Ray, rec *rendering.HitRecord, attenuation *vector3.Float64, scattered *geometry.Ray) bool {
+	if p.params.Unlit {
+		return false
+	}
+
+	s := p.surface(rec)
+	view := in.Direction().Normalized()
+	fresnel := s.fresnel(view.Scale(-1).Dot(s.normal))
+
+	// Chance of taking the specular lobe, which metals always take
+	specular := (fresnel.X() + fresnel.Y() + fresnel.Z()) / 3
+	if s.metallic >= 1 {
+		specular = 1
+	}
+	specular = math.Max(0.05, math.Min(1, specular))
+
+	if p.r.Float64() < specular {
+		reflected := view.Reflect(s.normal).
+			Add(vector3.RandInUnitSphere(p.r).Scale(s.roughness * s.roughness))
+		*scattered = geometry.NewRay(rec.Point, reflected)
+		*attenuation = fresnel.DivByConstant(specular)
+		return reflected.Dot(s.normal) > 0
+	}
+
+	scatterDir := s.normal.Add(vector3.RandNormal(p.r))
+	if scatterDir.NearZero() {
+		scatterDir = s.normal
+	}
+	*scattered = geometry.NewRay(rec.Point, scatterDir)
+	*attenuation = s.baseColor.
+		MultByVector(vector3.One[float64]().Sub(fresnel)).
+		Scale((1 - s.metallic) / (1 - specular))
+	return true
+}
+
+// Shade evaluates the Cook-Torrance BRDF with a GGX distribution for light
+// arriving from the direction provided
+func (p PBR) Shade(in geometry.Ray, rec *rendering.HitRecord, direction, radiance vector3.Float64) vector3.Float64 {
+	if p.params.Unlit {
+		return vector3.Zero[float64]()
+	}
+
+	s := p.surface(rec)
+	view := in.Direction().Normalized().Scale(-1)
+
+	nl := s.normal.Dot(direction)
+	if nl <= 0 {
+		return vector3.Zero[float64]()
+	}
+	nv := math.Max(s.normal.Dot(view), 0.0001)
+
+	half := direction.Add(view).Normalized()
+	nh := math.Max(s.normal.Dot(half), 0)
+
+	alpha := s.roughness * s.roughness
+	a2 := alpha * alpha
+	d := nh*nh*(a2-1) + 1
+	distribution := a2 / (math.Pi * d * d)
+
+	k := alpha / 2
+	geometryTerm := (nv / (nv*(1-k) + k)) * (nl / (nl*(1-k) + k))
+
+	fresnel := s.fresnel(view.Dot(half))
+	specular := fresnel.Scale(distribution * geometryTerm / (4 * nv * nl))
+	diffuse := s.baseColor.
+		MultByVector(vector3.One[float64]().Sub(fresnel)).
+		Scale((1 - s.metallic) / math.Pi)
+
+	return diffuse.Add(specular).MultByVector(radiance).Scale(nl)
+}
+
+func (p PBR) Emitted(uv vector2.Float64, point vector3.Float64) vector3.Float64 {
+	if p.params.Unlit {
+		return p.baseColor(uv, point).Add(p.params.Emissive)
+	}
+	return p.params.Emissive
+}
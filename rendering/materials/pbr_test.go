@@ -0,0 +1,62 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/EliCDavis/polyform/math/geometry"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/materials"
+	"github.com/EliCDavis/polyform/rendering/textures"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPBR_NormalMapGreenPointsUp(t *testing.T) {
+	// ARRANGE ================================================================
+	// A quad facing +Z, with the top of its texture along +Y. The normal map
+	// tilts every normal towards the top of the image, which is where glTF
+	// points the green channel.
+	quad := modeling.NewTriangleMesh([]int{0, 1, 2, 0, 2, 3}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(-1., -1., 0.),
+			vector3.New(1., -1., 0.),
+			vector3.New(1., 1., 0.),
+			vector3.New(-1., 1., 0.),
+		}).
+		SetFloat3Attribute(modeling.NormalAttribute, []vector3.Float64{
+			vector3.Forward[float64](),
+			vector3.Forward[float64](),
+			vector3.Forward[float64](),
+			vector3.Forward[float64](),
+		}).
+		SetFloat2Attribute(modeling.TexCoordAttribute, []vector2.Float64{
+			vector2.New(0., 1.),
+			vector2.New(1., 1.),
+			vector2.New(1., 0.),
+			vector2.New(0., 0.),
+		})
+
+	mat := materials.NewPBR(materials.PBRParameters{
+		BaseColor:     vector3.One[float64](),
+		Roughness:     1,
+		NormalTexture: textures.NewSolidColorTexture(vector3.New(0.5, 0.8, 0.9)),
+		NormalScale:   1,
+	})
+	mesh := rendering.NewMesh(quad, mat)
+
+	ray := rendering.NewTemporalRay(vector3.New(0., 0., 5.), vector3.New(0., 0., -1.), 0)
+	rec := rendering.NewHitRecord()
+	require.True(t, mesh.Hit(&ray, 0.001, 100, rec))
+
+	// ACT ====================================================================
+	lightRay := geometry.NewRay(ray.Origin(), ray.Direction())
+	up := mat.Shade(lightRay, rec, vector3.Up[float64](), vector3.One[float64]())
+	down := mat.Shade(lightRay, rec, vector3.Down[float64](), vector3.One[float64]())
+
+	// ASSERT =================================================================
+	assert.Greater(t, up.X(), 0.)
+	assert.Equal(t, vector3.Zero[float64](), down)
+}
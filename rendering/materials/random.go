@@ -0,0 +1,25 @@
+package materials
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// runtimeSource draws from the runtime's per thread generator, letting a
+// single material scatter rays from every goroutine rendering at once
+// without any locking
+type runtimeSource struct{}
+
+func (runtimeSource) Int63() int64 {
+	return randv2.Int64()
+}
+
+func (runtimeSource) Uint64() uint64 {
+	return randv2.Uint64()
+}
+
+func (runtimeSource) Seed(int64) {}
+
+func newRand() *rand.Rand {
+	return rand.New(runtimeSource{})
+}
@@ -88,6 +88,31 @@ type Mesh struct {
 	tree            trees.Tree
 	v3Atr           []string
 	v2Atr           []string
+
+	// Per triangle tangent frames derived from texture coordinates, used
+	// for normal mapping
+	tangents, bitangents []vector3.Float64
+}
+
+// tangentFrame finds the direction across the triangle's surface that the U
+// texture coordinate increases in, and the direction V decreases in. Images
+// place v=0 at their top, so decreasing V is "up" in a tangent space normal
+// map, matching the +Y of glTF's normal textures.
+func tangentFrame(tri intersectingTri, uv1, uv2, uv3 vector2.Float64) (vector3.Float64, vector3.Float64) {
+	e1 := tri.p2.Sub(tri.p1)
+	e2 := tri.p3.Sub(tri.p1)
+	d1 := uv2.Sub(uv1)
+	d2 := uv3.Sub(uv1)
+
+	det := d1.X()*d2.Y() - d2.X()*d1.Y()
+	if math.Abs(det) < 1e-12 {
+		return vector3.Zero[float64](), vector3.Zero[float64]()
+	}
+
+	r := 1. / det
+	tangent := e1.Scale(d2.Y()).Sub(e2.Scale(d1.Y())).Scale(r)
+	bitangent := e1.Scale(d2.X()).Sub(e2.Scale(d1.X())).Scale(r)
+	return tangent.Normalized(), bitangent.Normalized()
 }
 
 func NewMesh(mesh modeling.Mesh, mat Material) Mesh {
@@ -149,8 +174,25 @@ func NewMeshWithAttributes(mesh modeling.Mesh, mat Material, v3Data, v2Data []st
 		eles[i] = its[i]
 	}
 
+	var tangents, bitangents []vector3.Float64
+	if mesh.HasFloat2Attribute(modeling.TexCoordAttribute) {
+		tangents = make([]vector3.Float64, len(its))
+		bitangents = make([]vector3.Float64, len(its))
+		for i, tri := range its {
+			primitive := mesh.Tri(i)
+			tangents[i], bitangents[i] = tangentFrame(
+				tri,
+				primitive.P1Vec2Attr(modeling.TexCoordAttribute),
+				primitive.P2Vec2Attr(modeling.TexCoordAttribute),
+				primitive.P3Vec2Attr(modeling.TexCoordAttribute),
+			)
+		}
+	}
+
 	return Mesh{
 		tris:            its,
+		tangents:        tangents,
+		bitangents:      bitangents,
 		mat:             mat,
 		tree:            trees.NewOctree(eles),
 		ancillaryV3Data: ancillaryV3Data,
@@ -239,14 +281,21 @@ func (s Mesh) Hit(ray *TemporalRay, minDistance, maxDistance float64, hitRecord
 	for _, keyword := range s.v2Atr {
 		hitRecord.Float2Data[keyword] = v2P1Data[keyword].Scale(barycentric.X()).
 			Add(v2P2Data[keyword].Scale(barycentric.Y())).
-			Add(v2P3Data[keyword].Scale(barycentric.Z())).
-			Normalized()
+			Add(v2P3Data[keyword].Scale(barycentric.Z()))
 
 		if keyword == modeling.TexCoordAttribute {
 			hitRecord.UV = hitRecord.Float2Data[keyword]
 		}
 	}
 
+	if s.tangents != nil {
+		hitRecord.Float3Data["tangent"] = s.tangents[closestTriIndex]
+		hitRecord.Float3Data["bitangent"] = s.bitangents[closestTriIndex]
+	} else {
+		delete(hitRecord.Float3Data, "tangent")
+		delete(hitRecord.Float3Data, "bitangent")
+	}
+
 	hitRecord.Material = s.mat
 	hitRecord.SetFaceNormal(*ray, hitRecord.Normal)
 
@@ -0,0 +1,319 @@
+package rendering
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/EliCDavis/polyform/math/quaternion"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/vector/vector2"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// Rasterizing draws points and gaussian splats straight to the image through
+// a pinhole version of the camera, ignoring its aperture and shutter. Unlike
+// the path tracer, colors are written out as is, as point clouds and splats
+// store colors ready to be displayed.
+
+// Zeroth order spherical harmonic, relating FDC values to colors
+const shC0 = 0.28209479177387814
+
+// projection maps world space onto the pixels of an image
+type projection struct {
+	origin        vector3.Float64
+	u, v, w       vector3.Float64
+	fx, fy        float64
+	width, height int
+	camera        Camera
+}
+
+func (c Camera) projection(imageWidth int) projection {
+	imageHeight := int(float64(imageWidth) / c.aspectRatio)
+
+	// The viewport is centered the focus distance in front of the origin
+	focusDist := c.origin.Sub(c.lowerLeftCorner.Add(c.horizontal.Scale(0.5)).Add(c.vertical.Scale(0.5))).Length()
+
+	return projection{
+		origin: c.origin,
+		u:      c.u,
+		v:      c.v,
+		w:      c.w,
+		fx:     focusDist * float64(imageWidth) / c.horizontal.Length(),
+		fy:     focusDist * float64(imageHeight) / c.vertical.Length(),
+		width:  imageWidth,
+		height: imageHeight,
+		camera: c,
+	}
+}
+
+// view moves the point into the camera's space, where +X runs right, +Y
+// runs up, and +Z is the distance in front of the camera
+func (p projection) view(point vector3.Float64) vector3.Float64 {
+	d := point.Sub(p.origin)
+	return vector3.New(d.Dot(p.u), d.Dot(p.v), -d.Dot(p.w))
+}
+
+// pixel is where a point in the camera's space lands on the image, with the
+// first row at the top
+func (p projection) pixel(view vector3.Float64) vector2.Float64 {
+	return vector2.New(
+		float64(p.width)/2+p.fx*view.X()/view.Z(),
+		float64(p.height)/2-p.fy*view.Y()/view.Z(),
+	)
+}
+
+// background fills the image with the camera's background as seen through
+// the center of each pixel
+func (p projection) background() []vector3.Float64 {
+	pixels := make([]vector3.Float64, p.width*p.height)
+	for y := range p.height {
+		t := 1 - (float64(y)+0.5)/float64(p.height)
+		for x := range p.width {
+			s := (float64(x) + 0.5) / float64(p.width)
+			dir := p.camera.lowerLeftCorner.
+				Add(p.camera.horizontal.Scale(s)).
+				Add(p.camera.vertical.Scale(t)).
+				Sub(p.origin)
+			pixels[(y*p.width)+x] = p.camera.background(dir)
+		}
+	}
+	return pixels
+}
+
+func (p projection) image(pixels []vector3.Float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, p.width, p.height))
+	for i, c := range pixels {
+		c = c.Scale(255).Clamp(0, 255)
+		img.SetRGBA(i%p.width, i/p.width, color.RGBA{
+			R: uint8(math.Round(c.X())),
+			G: uint8(math.Round(c.Y())),
+			B: uint8(math.Round(c.Z())),
+			A: 255,
+		})
+	}
+	return img
+}
+
+// RasterizePoints draws each point of the cloud as an opaque disc the
+// diameter of pointSize pixels, with closer points drawn over those further
+// away. Points are colored by the cloud's color attribute when present, and
+// white otherwise.
+func RasterizePoints(cloud modeling.Mesh, camera Camera, imageWidth int, pointSize float64) (*image.RGBA, error) {
+	if !cloud.HasFloat3Attribute(modeling.PositionAttribute) {
+		return nil, errors.New("point cloud requires a position attribute to rasterize")
+	}
+
+	p := camera.projection(imageWidth)
+	pixels := p.background()
+	depth := make([]float64, len(pixels))
+	for i := range depth {
+		depth[i] = math.Inf(1)
+	}
+
+	positions := cloud.Float3Attribute(modeling.PositionAttribute)
+	colors := make([]vector3.Float64, positions.Len())
+	for i := range colors {
+		colors[i] = vector3.One[float64]()
+	}
+	if cloud.HasFloat3Attribute(modeling.ColorAttribute) {
+		cloud.ScanFloat3Attribute(modeling.ColorAttribute, func(i int, v vector3.Float64) {
+			colors[i] = v
+		})
+	}
+	radius := math.Max(pointSize, 1) / 2
+
+	for i := range positions.Len() {
+		view := p.view(positions.At(i))
+		if view.Z() <= 0 {
+			continue
+		}
+
+		center := p.pixel(view)
+		minX := max(int(math.Floor(center.X()-radius)), 0)
+		maxX := min(int(math.Ceil(center.X()+radius)), p.width-1)
+		minY := max(int(math.Floor(center.Y()-radius)), 0)
+		maxY := min(int(math.Ceil(center.Y()+radius)), p.height-1)
+
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				dx := float64(x) + 0.5 - center.X()
+				dy := float64(y) + 0.5 - center.Y()
+
+				// Always draw the pixel the point lands in, so points smaller
+				// than a pixel don't disappear
+				inside := (dx*dx)+(dy*dy) <= radius*radius ||
+					(x == int(math.Floor(center.X())) && y == int(math.Floor(center.Y())))
+
+				index := (y * p.width) + x
+				if !inside || view.Z() >= depth[index] {
+					continue
+				}
+				depth[index] = view.Z()
+				pixels[index] = colors[i]
+			}
+		}
+	}
+
+	return p.image(pixels), nil
+}
+
+// projectedSplat is a gaussian splat flattened onto the image
+type projectedSplat struct {
+	center vector2.Float64
+	depth  float64
+
+	// Inverse of the splat's 2D covariance
+	conicA, conicB, conicC float64
+
+	radius  float64
+	opacity float64
+	color   vector3.Float64
+}
+
+// project flattens the 3D gaussian onto the image using the local affine
+// approximation of the perspective projection from EWA splatting
+func (p projection) project(position, logScale vector3.Float64, rotation quaternion.Quaternion) (projectedSplat, bool) {
+	view := p.view(position)
+	if view.Z() <= 0.0001 {
+		return projectedSplat{}, false
+	}
+
+	z := view.Z()
+	scale := logScale.Exp()
+
+	// Jacobian of the projection at the splat's center
+	j00 := p.fx / z
+	j02 := -p.fx * view.X() / (z * z)
+	j11 := -p.fy / z
+	j12 := p.fy * view.Y() / (z * z)
+
+	var a, b, c float64
+	axes := [3]vector3.Float64{
+		vector3.Right[float64](),
+		vector3.Up[float64](),
+		vector3.Forward[float64](),
+	}
+	for i, axis := range axes {
+		// Each column of the rotation scale matrix contributes to the
+		// covariance, taken into the camera's space then onto the image
+		column := rotation.Rotate(axis).Scale(scale.Component(i))
+		cx, cy, cz := column.Dot(p.u), column.Dot(p.v), -column.Dot(p.w)
+
+		px := j00*cx + j02*cz
+		py := j11*cy + j12*cz
+		a += px * px
+		b += px * py
+		c += py * py
+	}
+
+	// Dilate the splat so it always covers at least a pixel
+	a += 0.3
+	c += 0.3
+
+	det := a*c - b*b
+	if det <= 0 {
+		return projectedSplat{}, false
+	}
+
+	mid := (a + c) / 2
+	largestEigenvalue := mid + math.Sqrt(math.Max(0.1, mid*mid-det))
+
+	return projectedSplat{
+		center: p.pixel(view),
+		depth:  z,
+		conicA: c / det,
+		conicB: -b / det,
+		conicC: a / det,
+		radius: math.Ceil(3 * math.Sqrt(largestEigenvalue)),
+	}, true
+}
+
+// RasterizeSplats draws a 3D gaussian splat point cloud, made up of the
+// position, scale, rotation, opacity, and FDC attributes used by 3DGS. Scales
+// are stored as logarithms, opacities before the sigmoid, and rotations as
+// (w, x, y, z) quaternions. Splats are sorted by their distance to the camera
+// and alpha composited front to back. Only the zeroth order spherical
+// harmonic is used for color.
+func RasterizeSplats(splats modeling.Mesh, camera Camera, imageWidth int) (*image.RGBA, error) {
+	if !splats.HasFloat3Attribute(modeling.PositionAttribute) ||
+		!splats.HasFloat3Attribute(modeling.ScaleAttribute) ||
+		!splats.HasFloat4Attribute(modeling.RotationAttribute) ||
+		!splats.HasFloat1Attribute(modeling.OpacityAttribute) ||
+		!splats.HasFloat3Attribute(modeling.FDCAttribute) {
+		return nil, errors.New("gaussian splats require position, scale, rotation, opacity, and FDC attributes to rasterize")
+	}
+
+	p := camera.projection(imageWidth)
+
+	positions := splats.Float3Attribute(modeling.PositionAttribute)
+	scales := splats.Float3Attribute(modeling.ScaleAttribute)
+	rotations := splats.Float4Attribute(modeling.RotationAttribute)
+	opacities := splats.Float1Attribute(modeling.OpacityAttribute)
+	fdcs := splats.Float3Attribute(modeling.FDCAttribute)
+
+	projected := make([]projectedSplat, 0, positions.Len())
+	for i := range positions.Len() {
+		rot := rotations.At(i)
+		rotation := quaternion.New(vector3.New(rot.Y(), rot.Z(), rot.W()), rot.X()).Normalize()
+
+		splat, ok := p.project(positions.At(i), scales.At(i), rotation)
+		if !ok {
+			continue
+		}
+
+		splat.opacity = 1. / (1. + math.Exp(-opacities.At(i)))
+		splat.color = fdcs.At(i).Scale(shC0).Add(vector3.Fill(0.5)).Clamp(0, 1)
+		projected = append(projected, splat)
+	}
+
+	sort.SliceStable(projected, func(i, j int) bool {
+		return projected[i].depth < projected[j].depth
+	})
+
+	pixels := make([]vector3.Float64, p.width*p.height)
+	transmittance := make([]float64, len(pixels))
+	for i := range transmittance {
+		transmittance[i] = 1
+	}
+
+	for _, splat := range projected {
+		minX := max(int(math.Floor(splat.center.X()-splat.radius)), 0)
+		maxX := min(int(math.Ceil(splat.center.X()+splat.radius)), p.width-1)
+		minY := max(int(math.Floor(splat.center.Y()-splat.radius)), 0)
+		maxY := min(int(math.Ceil(splat.center.Y()+splat.radius)), p.height-1)
+
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				index := (y * p.width) + x
+				if transmittance[index] < 0.0001 {
+					continue
+				}
+
+				dx := float64(x) + 0.5 - splat.center.X()
+				dy := float64(y) + 0.5 - splat.center.Y()
+				power := -0.5*(splat.conicA*dx*dx+splat.conicC*dy*dy) - splat.conicB*dx*dy
+				if power > 0 {
+					continue
+				}
+
+				alpha := math.Min(0.99, splat.opacity*math.Exp(power))
+				if alpha < 1./255. {
+					continue
+				}
+
+				pixels[index] = pixels[index].Add(splat.color.Scale(alpha * transmittance[index]))
+				transmittance[index] *= 1 - alpha
+			}
+		}
+	}
+
+	background := p.background()
+	for i := range pixels {
+		pixels[i] = pixels[i].Add(background[i].Scale(transmittance[i]))
+	}
+
+	return p.image(pixels), nil
+}
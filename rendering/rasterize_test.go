@@ -0,0 +1,176 @@
+package rendering_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/EliCDavis/vector/vector4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frontCamera sits along +Z looking back at the origin, with +X running to
+// the right of the image and +Y up
+func frontCamera() rendering.Camera {
+	return rendering.NewCamera(
+		90, 1, 0, 5,
+		vector3.New(0., 0., 5.), vector3.Zero[float64](), vector3.Up[float64](),
+		0, 0,
+		func(v vector3.Float64) vector3.Float64 { return vector3.Zero[float64]() },
+	)
+}
+
+// fdc is the FDC value that displays as the color provided
+func fdc(c vector3.Float64) vector3.Float64 {
+	return c.Sub(vector3.Fill(0.5)).DivByConstant(0.28209479177387814)
+}
+
+func logit(v float64) float64 {
+	return math.Log(v / (1 - v))
+}
+
+func TestRasterizePoints(t *testing.T) {
+	// ARRANGE ================================================================
+	cloud := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {
+				vector3.New(0., 0., -1.), // Hidden behind the red point
+				vector3.New(0., 0., 0.),
+				vector3.New(2.5, 2.5, 0.),
+			},
+			modeling.ColorAttribute: {
+				vector3.New(0., 0., 1.),
+				vector3.New(1., 0., 0.),
+				vector3.New(0., 1., 0.),
+			},
+		},
+		nil,
+		nil,
+	)
+
+	// ACT ====================================================================
+	img, err := rendering.RasterizePoints(cloud, frontCamera(), 40, 3)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 40, img.Bounds().Dx())
+	assert.Equal(t, 40, img.Bounds().Dy())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, img.RGBAAt(20, 20))
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, img.RGBAAt(19, 19))
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, img.RGBAAt(30, 10), "+X is right, +Y is up")
+	assert.Equal(t, color.RGBA{A: 255}, img.RGBAAt(5, 5))
+}
+
+func TestRasterizePoints_RequiresPositions(t *testing.T) {
+	_, err := rendering.RasterizePoints(modeling.EmptyPointcloud(), frontCamera(), 10, 1)
+	assert.EqualError(t, err, "point cloud requires a position attribute to rasterize")
+}
+
+func splatCloud(positions, colors []vector3.Float64, opacities []float64) modeling.Mesh {
+	scales := make([]vector3.Float64, len(positions))
+	rotations := make([]vector4.Float64, len(positions))
+	fdcs := make([]vector3.Float64, len(positions))
+	logits := make([]float64, len(positions))
+	for i := range positions {
+		scales[i] = vector3.Fill(math.Log(0.5))
+		rotations[i] = vector4.New(1., 0., 0., 0.)
+		fdcs[i] = fdc(colors[i])
+		logits[i] = logit(opacities[i])
+	}
+
+	return modeling.NewPointCloud(
+		map[string][]vector4.Float64{
+			modeling.RotationAttribute: rotations,
+		},
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: positions,
+			modeling.ScaleAttribute:    scales,
+			modeling.FDCAttribute:      fdcs,
+		},
+		nil,
+		map[string][]float64{
+			modeling.OpacityAttribute: logits,
+		},
+	)
+}
+
+func TestRasterizeSplats(t *testing.T) {
+	// ARRANGE ================================================================
+	// Listed back to front, to ensure they're sorted before compositing
+	splats := splatCloud(
+		[]vector3.Float64{
+			vector3.New(0., 0., -1.),
+			vector3.New(0., 0., 1.),
+		},
+		[]vector3.Float64{
+			vector3.New(1., 0., 0.),
+			vector3.New(0., 1., 0.),
+		},
+		[]float64{0.99, 0.5},
+	)
+
+	// ACT ====================================================================
+	img, err := rendering.RasterizeSplats(splats, frontCamera(), 40)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+
+	// Nearly half of the green splat in front, and what's left of the red
+	// behind it, as the pixel's center sits just off of the splats' centers
+	center := img.RGBAAt(20, 20)
+	assert.InDelta(t, 123, int(center.G), 2)
+	assert.InDelta(t, 121, int(center.R), 2)
+	assert.Zero(t, center.B)
+
+	// Gaussians fade out towards their edges
+	edge := img.RGBAAt(24, 20)
+	assert.Less(t, edge.G, center.G)
+	assert.Greater(t, edge.G, uint8(0))
+
+	assert.Equal(t, color.RGBA{A: 255}, img.RGBAAt(2, 2))
+}
+
+func TestRasterizeSplats_Anisotropic(t *testing.T) {
+	// ARRANGE ================================================================
+	// Stretched along X, then rotated a quarter turn about Z to lie along Y
+	splats := splatCloud(
+		[]vector3.Float64{vector3.Zero[float64]()},
+		[]vector3.Float64{vector3.New(1., 1., 1.)},
+		[]float64{0.99},
+	).
+		SetFloat3Attribute(modeling.ScaleAttribute, []vector3.Float64{
+			vector3.New(math.Log(1.), math.Log(0.05), math.Log(0.05)),
+		}).
+		SetFloat4Attribute(modeling.RotationAttribute, []vector4.Float64{
+			vector4.New(math.Sqrt2/2, 0., 0., math.Sqrt2/2),
+		})
+
+	// ACT ====================================================================
+	img, err := rendering.RasterizeSplats(splats, frontCamera(), 40)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Greater(t, img.RGBAAt(20, 16).R, uint8(50))
+	assert.Greater(t, img.RGBAAt(20, 24).R, uint8(50))
+	assert.Zero(t, img.RGBAAt(24, 20).R)
+	assert.Zero(t, img.RGBAAt(16, 20).R)
+}
+
+func TestRasterizeSplats_RequiresAttributes(t *testing.T) {
+	cloud := modeling.NewPointCloud(
+		nil,
+		map[string][]vector3.Float64{
+			modeling.PositionAttribute: {vector3.Zero[float64]()},
+		},
+		nil,
+		nil,
+	)
+
+	_, err := rendering.RasterizeSplats(cloud, frontCamera(), 10)
+	assert.EqualError(t, err, "gaussian splats require position, scale, rotation, opacity, and FDC attributes to rasterize")
+}
@@ -2,11 +2,8 @@ package rendering
 
 import (
 	"image"
-	"image/color"
 	"image/png"
-	"math/rand"
 	"os"
-	"time"
 
 	"github.com/EliCDavis/polyform/math/geometry"
 	"github.com/EliCDavis/polyform/math/sample"
@@ -15,7 +12,33 @@ import (
 
 var inf float64 = 10000 // math.Inf(1)
 
-func colorFromRay(tr TemporalRay, world Hittable, background sample.Vec3ToVec3, depth int) vector3.Float64 {
+// directLight sums the light arriving at the hit straight from each of the
+// lights that aren't blocked by something else in the scene
+func directLight(in geometry.Ray, tr TemporalRay, rec *HitRecord, world Hittable, lights []Light) vector3.Float64 {
+	total := vector3.Zero[float64]()
+	mat, ok := rec.Material.(LitMaterial)
+	if !ok {
+		return total
+	}
+
+	shadowRecord := NewHitRecord()
+	for _, light := range lights {
+		direction, distance, radiance := light.Illuminate(rec.Point)
+		if radiance.X() <= 0 && radiance.Y() <= 0 && radiance.Z() <= 0 {
+			continue
+		}
+
+		shadow := NewTemporalRay(rec.Point, direction, tr.time)
+		if world.Hit(&shadow, 0.001, distance, shadowRecord) {
+			continue
+		}
+
+		total = total.Add(mat.Shade(in, rec, direction, radiance))
+	}
+	return total
+}
+
+func colorFromRay(tr TemporalRay, world Hittable, lights []Light, background sample.Vec3ToVec3, depth int) vector3.Float64 {
 	if depth < 0 {
 		return vector3.Zero[float64]()
 	}
@@ -29,6 +52,9 @@ func colorFromRay(tr TemporalRay, world Hittable, background sample.Vec3ToVec3,
 	scattered := geometry.NewRay(vector3.Zero[float64](), vector3.Zero[float64]())
 	attenuation := vector3.Zero[float64]()
 	emitted := hitRecord.Material.Emitted(hitRecord.UV, hitRecord.Point)
+	if len(lights) > 0 {
+		emitted = emitted.Add(directLight(ray, tr, hitRecord, world, lights))
+	}
 
 	if !hitRecord.Material.Scatter(ray, hitRecord, &attenuation, &scattered) {
 		return emitted
@@ -37,6 +63,7 @@ func colorFromRay(tr TemporalRay, world Hittable, background sample.Vec3ToVec3,
 	return colorFromRay(
 		NewTemporalRay(scattered.Origin(), scattered.Direction(), tr.time),
 		world,
+		lights,
 		background,
 		depth-1,
 	).MultByVector(attenuation).Add(emitted)
@@ -48,6 +75,19 @@ func RenderToFile(
 	camera Camera,
 	imgPath string,
 	completion chan<- float64,
+) error {
+	return RenderLitToFile(maxRayBounce, samplesPerPixel, imageWidth, hittables, nil, camera, imgPath, completion)
+}
+
+// RenderLitToFile renders the hittables illuminated by the lights provided,
+// along with any emissive materials, writing the result out as a PNG
+func RenderLitToFile(
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	lights []Light,
+	camera Camera,
+	imgPath string,
+	completion chan<- float64,
 ) error {
 	f, err := os.Create(imgPath)
 	if err != nil {
@@ -56,49 +96,27 @@ func RenderToFile(
 
 	defer f.Close()
 
-	imageHeight := int(float64(imageWidth) / camera.aspectRatio)
-	img := image.NewRGBA(image.Rect(0, 0, imageWidth, imageHeight))
-
-	var world HitList = hittables
-	// bvh := NewBVH(hittables, camera.timeStart, camera.timeEnd)
-
-	totalPixels := float64(imageHeight * imageWidth)
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for y := 0; y < imageHeight; y++ {
-		for x := 0; x < imageWidth; x++ {
-
-			col := vector3.Zero[float64]()
-
-			for s := 0; s < samplesPerPixel; s++ {
-				u := (float64(x) + rand.Float64()) / float64(imageWidth-1)
-				v := (float64(y) + rand.Float64()) / float64(imageHeight-1)
-				col = col.Add(colorFromRay(camera.GetRay(r, u, v), &world, camera.background, maxRayBounce))
-			}
-
-			col = col.
-				DivByConstant(float64(samplesPerPixel)).
-				Sqrt().
-				Scale(255).
-				Clamp(0, 255)
-
-			img.Set(x, imageHeight-y, &color.RGBA{
-				uint8(col.X()),
-				uint8(col.Y()),
-				uint8(col.Z()),
-				255,
-			})
-
-			if completion != nil {
-				completion <- float64((y*imageWidth)+x) / totalPixels
-			}
-		}
-	}
-
-	err = png.Encode(f, img)
-
-	if completion != nil {
-		close(completion)
-	}
+	img := RenderLit(maxRayBounce, samplesPerPixel, imageWidth, hittables, lights, camera, completion)
+	return png.Encode(f, img)
+}
 
-	return err
+// RenderLit renders the hittables illuminated by the lights provided, along
+// with any emissive materials, spreading the work across all cores. The
+// completion channel, if provided, is sent the progress of the render and
+// closed once finished.
+func RenderLit(
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	hittables []Hittable,
+	lights []Light,
+	camera Camera,
+	completion chan<- float64,
+) *image.RGBA {
+	img, _ := Render(hittables, lights, camera, RenderParameters{
+		ImageWidth:         imageWidth,
+		MaxRayBounce:       maxRayBounce,
+		MaxSamplesPerPixel: samplesPerPixel,
+		SamplesPerPass:     samplesPerPixel,
+		Completion:         completion,
+	})
+	return img
 }
@@ -0,0 +1,223 @@
+package scenes
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/materials"
+	"github.com/EliCDavis/polyform/rendering/textures"
+	"github.com/EliCDavis/vector/vector3"
+)
+
+func colorToVector(c color.Color) vector3.Float64 {
+	r, g, b, _ := c.RGBA()
+	return vector3.New(float64(r), float64(g), float64(b)).DivByConstant(math.MaxUint16)
+}
+
+func texture(t *gltf.PolyformTexture) rendering.Texture {
+	if t == nil || t.Image == nil {
+		return nil
+	}
+	return textures.NewImage(t.Image)
+}
+
+// Material converts a glTF material into one the renderer understands. Nil
+// materials take on the defaults defined by the glTF specification. Textures
+// are only sampled from images already loaded into memory, using the first
+// set of texture coordinates and repeating in both directions.
+func Material(mat *gltf.PolyformMaterial) rendering.Material {
+	params := materials.PBRParameters{
+		BaseColor:   vector3.One[float64](),
+		Metallic:    1,
+		Roughness:   1,
+		NormalScale: 1,
+	}
+
+	if mat == nil {
+		return materials.NewPBR(params)
+	}
+
+	if pbr := mat.PbrMetallicRoughness; pbr != nil {
+		if pbr.BaseColorFactor != nil {
+			params.BaseColor = colorToVector(pbr.BaseColorFactor)
+		}
+		if pbr.MetallicFactor != nil {
+			params.Metallic = *pbr.MetallicFactor
+		}
+		if pbr.RoughnessFactor != nil {
+			params.Roughness = *pbr.RoughnessFactor
+		}
+		params.BaseColorTexture = texture(pbr.BaseColorTexture)
+		params.MetallicRoughnessTexture = texture(pbr.MetallicRoughnessTexture)
+	}
+
+	if mat.NormalTexture != nil {
+		params.NormalTexture = texture(mat.NormalTexture.PolyformTexture)
+		if mat.NormalTexture.Scale != nil {
+			params.NormalScale = *mat.NormalTexture.Scale
+		}
+	}
+
+	if mat.EmissiveFactor != nil {
+		params.Emissive = colorToVector(mat.EmissiveFactor)
+	}
+
+	for _, ext := range mat.Extensions {
+		switch e := ext.(type) {
+		case gltf.PolyformUnlit, *gltf.PolyformUnlit:
+			params.Unlit = true
+
+		case gltf.PolyformEmissiveStrength:
+			params.Emissive = params.Emissive.Scale(emissiveStrength(e))
+
+		case *gltf.PolyformEmissiveStrength:
+			params.Emissive = params.Emissive.Scale(emissiveStrength(*e))
+		}
+	}
+
+	return materials.NewPBR(params)
+}
+
+func emissiveStrength(e gltf.PolyformEmissiveStrength) float64 {
+	if e.EmissiveStrength == nil {
+		return 1
+	}
+	return *e.EmissiveStrength
+}
+
+// Light converts a glTF punctual light into one the renderer understands.
+// Polyform's lights only carry a position, so directional and spot lights
+// point down -Z, the direction glTF lights face before any rotation. Spot
+// lights use the specification's default cone angles.
+func Light(light gltf.KHR_LightsPunctual) (rendering.Light, error) {
+	c := vector3.One[float64]()
+	if light.Color != nil {
+		c = colorToVector(light.Color)
+	}
+
+	intensity := 1.
+	if light.Intensity != nil {
+		intensity = *light.Intensity
+	}
+
+	lightRange := 0.
+	if light.Range != nil {
+		lightRange = *light.Range
+	}
+
+	forward := vector3.Backwards[float64]()
+
+	switch light.Type {
+	case gltf.KHR_LightsPunctualType_Point, "":
+		return rendering.NewPointLight(light.Position, c, intensity, lightRange), nil
+
+	case gltf.KHR_LightsPunctualType_Directional:
+		return rendering.NewDirectionalLight(forward, c, intensity), nil
+
+	case gltf.KHR_LightsPunctualType_Spot:
+		return rendering.NewSpotLight(light.Position, forward, c, intensity, lightRange, 0, math.Pi/4), nil
+	}
+
+	return nil, fmt.Errorf("unrecognized light type %q", light.Type)
+}
+
+// transformMesh moves the mesh's positions and normals into world space
+func transformMesh(m modeling.Mesh, transform trs.TRS) modeling.Mesh {
+	m = m.ApplyTRS(transform)
+	if !m.HasFloat3Attribute(modeling.NormalAttribute) {
+		return m
+	}
+
+	// Normals are transformed by the inverse transpose, which for a TRS
+	// amounts to dividing by the scale before rotating
+	scale := transform.Scale()
+	return m.ModifyFloat3Attribute(modeling.NormalAttribute, func(i int, v vector3.Float64) vector3.Float64 {
+		return transform.RotateDirection(vector3.New(v.X()/scale.X(), v.Y()/scale.Y(), v.Z()/scale.Z())).Normalized()
+	})
+}
+
+// modelHittables builds a hittable for every instance of each of the model's
+// material slots
+func modelHittables(model gltf.PolyformModel) []rendering.Hittable {
+	if model.Mesh == nil || model.Mesh.Topology() != modeling.TriangleTopology || model.Mesh.PrimitiveCount() == 0 {
+		return nil
+	}
+	mesh := *model.Mesh
+
+	transforms := model.GpuInstances
+	if len(transforms) == 0 {
+		transforms = []trs.TRS{trs.Identity()}
+		if model.TRS != nil {
+			transforms[0] = *model.TRS
+		}
+	}
+
+	hittables := make([]rendering.Hittable, 0)
+	indices := mesh.Indices()
+	for _, group := range mesh.PrimitiveGroups() {
+		mat := model.Material
+		if slot, ok := model.Materials[group.Material]; ok {
+			mat = slot
+		}
+
+		groupIndices := make([]int, 0, len(group.Primitives)*3)
+		for _, primitive := range group.Primitives {
+			groupIndices = append(
+				groupIndices,
+				indices.At(primitive*3),
+				indices.At(primitive*3+1),
+				indices.At(primitive*3+2),
+			)
+		}
+		groupMesh := mesh.SetIndices(groupIndices).ClearPrimitiveGroups()
+
+		renderMat := Material(mat)
+		for _, transform := range transforms {
+			hittables = append(hittables, rendering.NewMesh(transformMesh(groupMesh, transform), renderMat))
+		}
+	}
+	return hittables
+}
+
+// FromGLTF converts a glTF scene into the hittables and lights the renderer
+// works with. Only triangle meshes are rendered, positioned by each model's
+// TRS or GPU instances, in their bind pose. Materials are converted with
+// Material, and lights with Light.
+func FromGLTF(scene gltf.PolyformScene) ([]rendering.Hittable, []rendering.Light, error) {
+	hittables := make([]rendering.Hittable, 0)
+	for _, model := range scene.Models {
+		hittables = append(hittables, modelHittables(model)...)
+	}
+
+	lights := make([]rendering.Light, len(scene.Lights))
+	for i, light := range scene.Lights {
+		l, err := Light(light)
+		if err != nil {
+			return nil, nil, fmt.Errorf("light %d: %w", i, err)
+		}
+		lights[i] = l
+	}
+
+	return hittables, lights, nil
+}
+
+// RenderGLTFToFile renders the glTF scene from the camera's point of view,
+// writing the result out as a PNG
+func RenderGLTFToFile(
+	maxRayBounce, samplesPerPixel, imageWidth int,
+	scene gltf.PolyformScene,
+	camera rendering.Camera,
+	imgPath string,
+	completion chan<- float64,
+) error {
+	hittables, lights, err := FromGLTF(scene)
+	if err != nil {
+		return err
+	}
+	return rendering.RenderLitToFile(maxRayBounce, samplesPerPixel, imageWidth, hittables, lights, camera, imgPath, completion)
+}
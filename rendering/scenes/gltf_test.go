@@ -0,0 +1,184 @@
+package scenes_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/EliCDavis/polyform/formats/gltf"
+	"github.com/EliCDavis/polyform/math/trs"
+	"github.com/EliCDavis/polyform/modeling"
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/scenes"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// floor is a 2x2 square lying flat on the XZ plane, with the half along -X
+// assigned to the "left" material slot and the half along +X to "right"
+func floor() modeling.Mesh {
+	return modeling.NewTriangleMesh([]int{
+		0, 1, 4, 0, 4, 3,
+		1, 2, 5, 1, 5, 4,
+	}).
+		SetFloat3Attribute(modeling.PositionAttribute, []vector3.Float64{
+			vector3.New(-1., 0., -1.),
+			vector3.New(0., 0., -1.),
+			vector3.New(1., 0., -1.),
+			vector3.New(-1., 0., 1.),
+			vector3.New(0., 0., 1.),
+			vector3.New(1., 0., 1.),
+		}).
+		SetPrimitiveGroups([]modeling.PrimitiveGroup{
+			{Material: "left", Primitives: []int{0, 1}},
+			{Material: "right", Primitives: []int{2, 3}},
+		})
+}
+
+func matteMaterial(c color.Color) *gltf.PolyformMaterial {
+	metallic := 0.
+	roughness := 1.
+	return &gltf.PolyformMaterial{
+		PbrMetallicRoughness: &gltf.PolyformPbrMetallicRoughness{
+			BaseColorFactor: c,
+			MetallicFactor:  &metallic,
+			RoughnessFactor: &roughness,
+		},
+	}
+}
+
+// topDown looks straight down at the floor, with +X running to the right of
+// the image
+func topDown() rendering.Camera {
+	return rendering.NewCamera(
+		60, 1, 0, 2,
+		vector3.New(0., 2., 0.), vector3.Zero[float64](), vector3.New(0., 0., -1.),
+		0, 0,
+		func(v vector3.Float64) vector3.Float64 { return vector3.Zero[float64]() },
+	)
+}
+
+func TestFromGLTF_LightsMaterialSlots(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := floor()
+	intensity := 2.
+	scene := gltf.PolyformScene{
+		Models: []gltf.PolyformModel{{
+			Mesh: &mesh,
+			Materials: map[string]*gltf.PolyformMaterial{
+				"left":  matteMaterial(color.RGBA{R: 255, A: 255}),
+				"right": matteMaterial(color.RGBA{B: 255, A: 255}),
+			},
+		}},
+		Lights: []gltf.KHR_LightsPunctual{{
+			Type:      gltf.KHR_LightsPunctualType_Point,
+			Intensity: &intensity,
+			Position:  vector3.New(0., 1., 0.),
+		}},
+	}
+
+	// ACT ====================================================================
+	hittables, lights, err := scenes.FromGLTF(scene)
+	require.NoError(t, err)
+	img := rendering.RenderLit(2, 4, 32, hittables, lights, topDown(), nil)
+
+	// ASSERT =================================================================
+	assert.Len(t, hittables, 2)
+	assert.Len(t, lights, 1)
+
+	// Specular highlights of dielectrics aren't tinted by the base color, so
+	// a little of each channel makes it through
+	left := img.RGBAAt(8, 16)
+	assert.Greater(t, left.R, left.G+100)
+	assert.Greater(t, left.R, left.B+100)
+
+	right := img.RGBAAt(24, 16)
+	assert.Greater(t, right.B, right.R+100)
+	assert.Greater(t, right.B, right.G+100)
+
+	// Nothing but the black background past the floor's edge
+	corner := img.RGBAAt(1, 1)
+	assert.Zero(t, corner.R)
+	assert.Zero(t, corner.B)
+}
+
+func TestFromGLTF_LightsBelowFloorLeaveItDark(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := floor()
+	scene := gltf.PolyformScene{
+		Models: []gltf.PolyformModel{{
+			Mesh:     &mesh,
+			Material: matteMaterial(color.White),
+		}},
+		Lights: []gltf.KHR_LightsPunctual{{
+			Position: vector3.New(0., -1., 0.),
+		}},
+	}
+
+	// ACT ====================================================================
+	hittables, lights, err := scenes.FromGLTF(scene)
+	require.NoError(t, err)
+	img := rendering.RenderLit(2, 4, 32, hittables, lights, topDown(), nil)
+
+	// ASSERT =================================================================
+	assert.Len(t, hittables, 2, "material slots missing from the model's materials fall back to its material")
+	assert.Equal(t, color.RGBA{A: 255}, img.RGBAAt(16, 16))
+}
+
+func TestFromGLTF_UnlitMaterialsIgnoreLighting(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := floor().ClearPrimitiveGroups()
+	offset := trs.Position(vector3.New(0., 0.5, 0.))
+	scene := gltf.PolyformScene{
+		Models: []gltf.PolyformModel{{
+			Mesh: &mesh,
+			TRS:  &offset,
+			Material: &gltf.PolyformMaterial{
+				PbrMetallicRoughness: &gltf.PolyformPbrMetallicRoughness{
+					BaseColorFactor: color.RGBA{G: 255, A: 255},
+				},
+				Extensions: []gltf.MaterialExtension{gltf.PolyformUnlit{}},
+			},
+		}},
+	}
+
+	// ACT ====================================================================
+	hittables, lights, err := scenes.FromGLTF(scene)
+	require.NoError(t, err)
+	img := rendering.RenderLit(2, 2, 32, hittables, lights, topDown(), nil)
+
+	// ASSERT =================================================================
+	assert.Len(t, hittables, 1)
+	assert.Empty(t, lights)
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, img.RGBAAt(16, 16))
+}
+
+func TestFromGLTF_GpuInstances(t *testing.T) {
+	// ARRANGE ================================================================
+	mesh := floor()
+	scene := gltf.PolyformScene{
+		Models: []gltf.PolyformModel{{
+			Mesh: &mesh,
+			GpuInstances: []trs.TRS{
+				trs.Position(vector3.New(0., 0., 0.)),
+				trs.Position(vector3.New(0., 1., 0.)),
+				trs.Position(vector3.New(0., 2., 0.)),
+			},
+		}},
+	}
+
+	// ACT ====================================================================
+	hittables, _, err := scenes.FromGLTF(scene)
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, hittables, 6)
+	assert.InDelta(t, 2., hittables[2].BoundingBox(0, 0).Max().Y(), 1e-9)
+}
+
+func TestFromGLTF_UnrecognizedLight(t *testing.T) {
+	_, _, err := scenes.FromGLTF(gltf.PolyformScene{
+		Lights: []gltf.KHR_LightsPunctual{{Type: "area"}},
+	})
+	assert.EqualError(t, err, `light 0: unrecognized light type "area"`)
+}
@@ -2,6 +2,7 @@ package textures
 
 import (
 	"image"
+	"math"
 
 	"github.com/EliCDavis/vector/vector2"
 	"github.com/EliCDavis/vector/vector3"
@@ -20,10 +21,16 @@ func NewImage(c image.Image) ImageTexture {
 	}
 }
 
+// wrap repeats texture coordinates outside of [0, 1] across the texture
+func wrap(v float64, size int) int {
+	return min(int(float64(size)*(v-math.Floor(v))), size-1)
+}
+
 func (it ImageTexture) Value(uv vector2.Float64, p vector3.Float64) vector3.Float64 {
+	bounds := it.i.Bounds()
 	r, g, b, _ := it.i.At(
-		int(float64(it.w)*uv.X()),
-		int(float64(it.h)*uv.Y()),
+		bounds.Min.X+wrap(uv.X(), it.w),
+		bounds.Min.Y+wrap(uv.Y(), it.h),
 	).RGBA()
 
 	v := vector3.New(
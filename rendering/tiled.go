@@ -0,0 +1,305 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/EliCDavis/vector/vector3"
+)
+
+// RenderParameters configures a tiled, progressive render. The image is
+// rendered over a series of passes, each of which spreads tiles of the image
+// across workers to take a few more samples of every pixel still being
+// sampled.
+type RenderParameters struct {
+	ImageWidth   int
+	MaxRayBounce int
+
+	// Most samples taken for any one pixel
+	MaxSamplesPerPixel int
+
+	// Samples every pixel receives before it's considered for convergence.
+	// Defaults to 16, or MaxSamplesPerPixel if that's fewer.
+	MinSamplesPerPixel int
+
+	// Pixels stop being sampled once the standard error of their mean
+	// brightness falls below this. Zero disables adaptive sampling, taking
+	// MaxSamplesPerPixel samples of every pixel.
+	NoiseThreshold float64
+
+	// Samples taken of each pixel still being sampled every pass. Defaults to
+	// 4.
+	SamplesPerPass int
+
+	// Width and height of the tiles handed out to workers. Defaults to 32.
+	TileSize int
+
+	// Number of goroutines rendering tiles. Defaults to the number of CPUs.
+	Workers int
+
+	// Called with the image rendered so far after every pass, including the
+	// last. Returning an error stops the render.
+	Progress func(RenderProgress) error
+
+	// Sent the fraction of the sample budget spent as tiles finish, and
+	// closed once the render completes
+	Completion chan<- float64
+}
+
+func (rp RenderParameters) withDefaults() RenderParameters {
+	if rp.MaxSamplesPerPixel <= 0 {
+		rp.MaxSamplesPerPixel = 1
+	}
+
+	if rp.MinSamplesPerPixel <= 0 {
+		rp.MinSamplesPerPixel = 16
+	}
+	rp.MinSamplesPerPixel = min(rp.MinSamplesPerPixel, rp.MaxSamplesPerPixel)
+
+	if rp.SamplesPerPass <= 0 {
+		rp.SamplesPerPass = 4
+	}
+
+	if rp.TileSize <= 0 {
+		rp.TileSize = 32
+	}
+
+	if rp.Workers <= 0 {
+		rp.Workers = runtime.NumCPU()
+	}
+	return rp
+}
+
+// RenderProgress describes a render after one of its passes
+type RenderProgress struct {
+	Pass int
+
+	// Total samples taken across all pixels
+	Samples int
+
+	// Pixels that have neither converged nor spent their sample budget
+	ActivePixels int
+
+	Image *image.RGBA
+}
+
+// pixelEstimate accumulates the samples taken of a single pixel
+type pixelEstimate struct {
+	sum         vector3.Float64
+	luminance   float64
+	luminanceSq float64
+	samples     int
+	done        bool
+}
+
+func (pe *pixelEstimate) add(sample vector3.Float64) {
+	l := (0.2126 * sample.X()) + (0.7152 * sample.Y()) + (0.0722 * sample.Z())
+	pe.sum = pe.sum.Add(sample)
+	pe.luminance += l
+	pe.luminanceSq += l * l
+	pe.samples++
+}
+
+func (pe pixelEstimate) converged(params RenderParameters) bool {
+	if pe.samples >= params.MaxSamplesPerPixel {
+		return true
+	}
+
+	if params.NoiseThreshold <= 0 || pe.samples < params.MinSamplesPerPixel {
+		return false
+	}
+
+	n := float64(pe.samples)
+	mean := pe.luminance / n
+	variance := math.Max(0, (pe.luminanceSq/n)-(mean*mean))
+	return math.Sqrt(variance/n) <= params.NoiseThreshold
+}
+
+func (pe pixelEstimate) color() color.RGBA {
+	if pe.samples == 0 {
+		return color.RGBA{A: 255}
+	}
+
+	col := pe.sum.
+		DivByConstant(float64(pe.samples)).
+		Sqrt().
+		Scale(255).
+		Clamp(0, 255)
+
+	return color.RGBA{
+		uint8(col.X()),
+		uint8(col.Y()),
+		uint8(col.Z()),
+		255,
+	}
+}
+
+func imageTiles(width, height, size int) []image.Rectangle {
+	tiles := make([]image.Rectangle, 0)
+	for y := 0; y < height; y += size {
+		for x := 0; x < width; x += size {
+			tiles = append(tiles, image.Rect(x, y, min(x+size, width), min(y+size, height)))
+		}
+	}
+	return tiles
+}
+
+// renderer holds everything shared between the workers of a render
+type renderer struct {
+	world     HitList
+	lights    []Light
+	camera    Camera
+	params    RenderParameters
+	width     int
+	height    int
+	estimates []pixelEstimate
+}
+
+// renderTile takes another pass worth of samples of every pixel within the
+// tile still being sampled, returning the number of samples taken
+func (rr renderer) renderTile(tile image.Rectangle, r *rand.Rand) int {
+	taken := 0
+	for row := tile.Min.Y; row < tile.Max.Y; row++ {
+		// Rows run top to bottom while the camera's viewport runs bottom to
+		// top
+		y := rr.height - 1 - row
+		for x := tile.Min.X; x < tile.Max.X; x++ {
+			estimate := &rr.estimates[(row*rr.width)+x]
+			if estimate.done {
+				continue
+			}
+
+			samples := min(rr.params.SamplesPerPass, rr.params.MaxSamplesPerPixel-estimate.samples)
+			for range samples {
+				u := (float64(x) + r.Float64()) / float64(rr.width)
+				v := (float64(y) + r.Float64()) / float64(rr.height)
+				estimate.add(colorFromRay(rr.camera.GetRay(r, u, v), &rr.world, rr.lights, rr.camera.background, rr.params.MaxRayBounce))
+			}
+			taken += samples
+			estimate.done = estimate.converged(rr.params)
+		}
+	}
+	return taken
+}
+
+func (rr renderer) resolve(img *image.RGBA) {
+	for i, estimate := range rr.estimates {
+		img.SetRGBA(i%rr.width, i/rr.width, estimate.color())
+	}
+}
+
+// Render spreads the work of rendering the hittables across all cores,
+// progressively refining the image over a series of passes until every pixel
+// has either converged or spent its sample budget.
+func Render(hittables []Hittable, lights []Light, camera Camera, params RenderParameters) (*image.RGBA, error) {
+	params = params.withDefaults()
+	if params.Completion != nil {
+		defer close(params.Completion)
+	}
+
+	width := params.ImageWidth
+	height := int(float64(width) / camera.aspectRatio)
+	rr := renderer{
+		world:     hittables,
+		lights:    lights,
+		camera:    camera,
+		params:    params,
+		width:     width,
+		height:    height,
+		estimates: make([]pixelEstimate, width*height),
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	tiles := imageTiles(width, height, params.TileSize)
+	budget := float64(width * height * params.MaxSamplesPerPixel)
+
+	var samplesLock sync.Mutex
+	samples := 0
+
+	for pass := 1; ; pass++ {
+		queue := make(chan image.Rectangle, len(tiles))
+		for _, tile := range tiles {
+			queue <- tile
+		}
+		close(queue)
+
+		var wg sync.WaitGroup
+		for worker := range params.Workers {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(seed))
+				for tile := range queue {
+					taken := rr.renderTile(tile, r)
+
+					samplesLock.Lock()
+					samples += taken
+					if params.Completion != nil {
+						params.Completion <- float64(samples) / budget
+					}
+					samplesLock.Unlock()
+				}
+			}(time.Now().UnixNano() + int64(worker))
+		}
+		wg.Wait()
+
+		active := 0
+		for _, estimate := range rr.estimates {
+			if !estimate.done {
+				active++
+			}
+		}
+
+		if params.Progress != nil || active == 0 {
+			rr.resolve(img)
+		}
+
+		if params.Progress != nil {
+			err := params.Progress(RenderProgress{
+				Pass:         pass,
+				Samples:      samples,
+				ActivePixels: active,
+				Image:        img,
+			})
+			if err != nil {
+				return img, err
+			}
+		}
+
+		if active == 0 {
+			return img, nil
+		}
+	}
+}
+
+// CheckpointToFile builds a progress callback that writes the image rendered
+// so far out as a PNG after every pass, replacing the previous checkpoint
+func CheckpointToFile(imgPath string) func(RenderProgress) error {
+	return func(progress RenderProgress) error {
+		// Write to the side and swap it into place, so the file never holds
+		// a partially written image
+		tmpPath := imgPath + ".tmp"
+		f, err := os.Create(tmpPath)
+		if err != nil {
+			return err
+		}
+
+		if err := png.Encode(f, progress.Image); err != nil {
+			f.Close()
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		return os.Rename(tmpPath, imgPath)
+	}
+}
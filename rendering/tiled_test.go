@@ -0,0 +1,155 @@
+package rendering_test
+
+import (
+	"errors"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EliCDavis/polyform/rendering"
+	"github.com/EliCDavis/polyform/rendering/materials"
+	"github.com/EliCDavis/vector/vector3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// glowingScene surrounds the camera with an emissive sphere, so every sample
+// of every pixel comes back the same
+func glowingScene() ([]rendering.Hittable, rendering.Camera) {
+	hittables := []rendering.Hittable{
+		rendering.NewSphere(vector3.Zero[float64](), 10, materials.NewDiffuseLightWithColor(vector3.Fill(0.25))),
+	}
+	camera := rendering.NewCamera(
+		60, 1, 0, 1,
+		vector3.Zero[float64](), vector3.New(0., 0., -1.), vector3.Up[float64](),
+		0, 0,
+		func(v vector3.Float64) vector3.Float64 { return vector3.Zero[float64]() },
+	)
+	return hittables, camera
+}
+
+func TestRender_AdaptiveSamplingStopsConvergedPixels(t *testing.T) {
+	// ARRANGE ================================================================
+	hittables, camera := glowingScene()
+	progress := make([]rendering.RenderProgress, 0)
+
+	// ACT ====================================================================
+	img, err := rendering.Render(hittables, nil, camera, rendering.RenderParameters{
+		ImageWidth:         16,
+		MaxRayBounce:       4,
+		MaxSamplesPerPixel: 64,
+		MinSamplesPerPixel: 4,
+		NoiseThreshold:     0.001,
+		SamplesPerPass:     2,
+		TileSize:           5,
+		Workers:            3,
+		Progress: func(rp rendering.RenderProgress) error {
+			progress = append(progress, rp)
+			return nil
+		},
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	require.Len(t, progress, 2)
+
+	assert.Equal(t, 1, progress[0].Pass)
+	assert.Equal(t, 16*16*2, progress[0].Samples)
+	assert.Equal(t, 16*16, progress[0].ActivePixels)
+
+	assert.Equal(t, 2, progress[1].Pass)
+	assert.Equal(t, 16*16*4, progress[1].Samples)
+	assert.Equal(t, 0, progress[1].ActivePixels)
+	assert.Same(t, img, progress[1].Image)
+
+	assert.Equal(t, 16, img.Bounds().Dx())
+	assert.Equal(t, 16, img.Bounds().Dy())
+	for y := range 16 {
+		for x := range 16 {
+			require.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, img.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestRender_WithoutNoiseThresholdSpendsFullBudget(t *testing.T) {
+	// ARRANGE ================================================================
+	hittables, camera := glowingScene()
+	completion := make(chan float64, 1000)
+	passes := 0
+	samples := 0
+
+	// ACT ====================================================================
+	_, err := rendering.Render(hittables, nil, camera, rendering.RenderParameters{
+		ImageWidth:         8,
+		MaxRayBounce:       4,
+		MaxSamplesPerPixel: 6,
+		SamplesPerPass:     4,
+		TileSize:           3,
+		Completion:         completion,
+		Progress: func(rp rendering.RenderProgress) error {
+			passes++
+			samples = rp.Samples
+			return nil
+		},
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+	assert.Equal(t, 2, passes)
+	assert.Equal(t, 8*8*6, samples)
+
+	last := 0.
+	for fraction := range completion {
+		assert.GreaterOrEqual(t, fraction, last)
+		last = fraction
+	}
+	assert.Equal(t, 1., last)
+}
+
+func TestRender_ProgressErrorStopsRender(t *testing.T) {
+	hittables, camera := glowingScene()
+	passes := 0
+
+	_, err := rendering.Render(hittables, nil, camera, rendering.RenderParameters{
+		ImageWidth:         4,
+		MaxSamplesPerPixel: 100,
+		SamplesPerPass:     1,
+		Progress: func(rp rendering.RenderProgress) error {
+			passes++
+			return errors.New("stop")
+		},
+	})
+
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 1, passes)
+}
+
+func TestCheckpointToFile(t *testing.T) {
+	// ARRANGE ================================================================
+	hittables, camera := glowingScene()
+	imgPath := filepath.Join(t.TempDir(), "checkpoint.png")
+
+	// ACT ====================================================================
+	_, err := rendering.Render(hittables, nil, camera, rendering.RenderParameters{
+		ImageWidth:         12,
+		MaxSamplesPerPixel: 4,
+		SamplesPerPass:     1,
+		Progress:           rendering.CheckpointToFile(imgPath),
+	})
+
+	// ASSERT =================================================================
+	require.NoError(t, err)
+
+	f, err := os.Open(imgPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	require.NoError(t, err)
+	assert.Equal(t, 12, img.Bounds().Dx())
+
+	_, err = os.Stat(imgPath + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -405,6 +405,7 @@ export class ProducerViewManager {
                 break;
 
             case "splat":
+            case "spz":
                 this.loadSplat(fileToLoad, manifestUrl + fileToLoad)
                 break;
 
@@ -26,6 +26,7 @@ export interface PortReference {
 
 export interface NodeInstanceOutputPort {
     version: number;
+    error?: string;
 }
 
 export interface NodeInstanceOutput {